@@ -9,17 +9,25 @@ import (
 	"sync"
 	"syscall"
 
-	"github.com/The-Gleb/product_catalog/internal/adapter/db"
-	"github.com/The-Gleb/product_catalog/internal/adapter/dummyjson"
+	"github.com/The-Gleb/product_catalog/internal/adapter/notifier"
+	"github.com/The-Gleb/product_catalog/internal/adapter/source"
 	"github.com/The-Gleb/product_catalog/internal/config"
 	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	admin_handlers "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler/admin"
 	category_handlers "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler/category"
+	mfa_handlers "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler/mfa"
+	password_handlers "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler/password"
 	product_handlers "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler/product"
+	session_handlers "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler/session"
+	sync_handlers "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler/sync"
 	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/The-Gleb/product_catalog/internal/domain/service"
 	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
 	"github.com/The-Gleb/product_catalog/internal/logger"
-	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	"github.com/The-Gleb/product_catalog/pkg/backoff"
+	"github.com/The-Gleb/product_catalog/pkg/breaker"
+	"github.com/The-Gleb/product_catalog/pkg/token"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -32,47 +40,169 @@ func main() {
 }
 
 func Run() error {
-	config := config.MustBuild("")
+	config := config.MustBuild("catalog")
 	logger.Initialize("debug")
-	client, err := postgresql.NewClient(context.Background(), config.DB)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrator, err := newMigrator(context.Background(), config.DB)
+		if err != nil {
+			return err
+		}
+		return runMigrate(context.Background(), migrator, os.Args[2:])
+	}
+
+	storages, err := newStorages(context.Background(), config)
 	if err != nil {
 		return err
 	}
 
-	productStorage := db.NewProductStorage(client)
-	categoryStorage := db.NewCategoryStorage(client)
-	sessionStorage := db.NewSessionStorage(client)
-	userStorage := db.NewUserStorage(client)
+	notifier, err := newNotifier(config.Notifier)
+	if err != nil {
+		return err
+	}
 
-	productClient := dummyjson.NewProductClient(config.DummyJSONAddress)
+	sourceClients, err := source.NewRegistry().Build(config.Sources())
+	if err != nil {
+		return err
+	}
+	productClients := make([]service.ProductClient, 0, len(sourceClients))
+	syncBreakers := make(map[string]*breaker.Breaker, len(sourceClients))
+	for _, c := range sourceClients {
+		b := breaker.New(config.SyncBreakerThreshold, config.SyncBreakerCooldown)
+		syncBreakers[c.Source()] = b
+		productClients = append(productClients, service.NewCircuitBreakerClient(c, b))
+	}
 
-	productService := service.NewProductService(productStorage, productClient, config.ProductUpdateInterval)
-	categoryService := service.NewCategoryService(categoryStorage)
-	sessionService := service.NewSessionService(sessionStorage)
-	userService := service.NewUserService(userStorage)
+	productService := service.NewProductService(
+		storages.product,
+		productClients,
+		storages.syncState,
+		storages.reconcileReport,
+		config.SyncPageSize,
+		entity.DeleteMode(config.ReconcileDeleteMode),
+	)
+	categoryService := service.NewCategoryService(storages.category)
+	signingKey, err := config.SigningKey()
+	if err != nil {
+		return err
+	}
+	signer, err := token.NewSigner(signingKey)
+	if err != nil {
+		return err
+	}
+	sessionService := service.NewSessionService(storages.session, signer, service.TokenConfig{
+		AccessTTL:  config.AccessTokenTTL,
+		RefreshTTL: config.TokenTTL,
+	})
+	userService := service.NewUserService(storages.user)
+	apiKeyService := service.NewAPIKeyService(storages.apiKey)
+	loginThrottleService := service.NewLoginThrottleService(storages.loginAttempt, service.LoginThrottleConfig{
+		MaxFailures:   config.LoginThrottle.MaxFailures,
+		MaxIPFailures: config.LoginThrottle.MaxIPFailures,
+		Window:        config.LoginThrottle.Window,
+		Delay:         config.LoginThrottle.Delay,
+		MaxDelay:      config.LoginThrottle.MaxDelay,
+		Lockout:       config.LoginThrottle.Lockout,
+	})
+	mfaService := service.NewMFAService(storages.mfa, signer, service.MFAConfig{
+		Issuer:       config.MFA.Issuer,
+		ChallengeTTL: config.MFA.ChallengeTTL,
+	})
+	passwordResetService := service.NewPasswordResetService(storages.passwordReset, notifier, service.PasswordResetConfig{
+		TTL: config.PasswordReset.TTL,
+		URL: config.PasswordReset.URL,
+	})
+	searchService := service.NewSearchService(storages.productSearcher, storages.productFacets)
+	syncWorker := service.NewSyncWorker(productService, syncBreakers, service.SyncWorkerConfig{
+		Interval:   config.ProductUpdateInterval,
+		FullResync: config.FullResync,
+		Reconcile:  config.Reconcile,
+		Backoff: backoff.Backoff{
+			Initial: config.SyncRetryDelay,
+			Max:     config.SyncRetryMaxDelay,
+		},
+	})
 
 	productUsecase := usecase.NewProductUsecase(productService)
 	categoryUsecase := usecase.NewCategoryUsecase(categoryService)
-	registerUsecase := usecase.NewRegisterUsecase(userService, sessionService)
-	loginUsecase := usecase.NewLoginUsecase(userService, sessionService)
-	authUsecase := usecase.NewAuthUsecase(sessionService)
+	searchUsecase := usecase.NewSearchUsecase(searchService)
+	healthUsecase := usecase.NewHealthUsecase(syncWorker)
+	syncUsecase := usecase.NewSyncUsecase(productService)
+	bannedPasswords, err := config.PasswordPolicy.BannedPasswords()
+	if err != nil {
+		return err
+	}
+	passwordPolicy := entity.NewPasswordPolicy(config.PasswordPolicy.MinLength, bannedPasswords)
+
+	registerUsecase := usecase.NewRegisterUsecase(userService, sessionService, passwordPolicy)
+	loginUsecase := usecase.NewLoginUsecase(userService, sessionService, loginThrottleService, mfaService)
+	authUsecase := usecase.NewAuthUsecase(sessionService, apiKeyService)
+	sessionUsecase := usecase.NewSessionUsecase(sessionService)
+	authorizeUsecase := usecase.NewAuthorizeUsecase(userService)
+	adminUsecase := usecase.NewAdminUsecase(userService)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyService)
+	mfaUsecase := usecase.NewMFAUsecase(userService, mfaService)
+	passwordUsecase := usecase.NewPasswordUsecase(userService, sessionService, loginThrottleService, passwordResetService, passwordPolicy)
+
+	if config.Admin.Login != "" {
+		err = adminUsecase.BootstrapAdmin(context.Background(), entity.Credentials{
+			Login:    config.Admin.Login,
+			Password: config.Admin.Password,
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	authMiddleware := middleware.NewAuthMiddleware(authUsecase)
+	permissionMiddleware := middleware.NewPermissionMiddleware(authMiddleware, authorizeUsecase)
+	catalogWrite := permissionMiddleware.Require(entity.PermissionCatalogWrite)
+	syncManage := permissionMiddleware.Require(entity.PermissionSyncManage)
+	usersManage := permissionMiddleware.Require(entity.PermissionUsersManage)
 
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
 
 	v1.NewRegisterHandler(registerUsecase).AddToRouter(r)
 	v1.NewLoginHandler(loginUsecase).AddToRouter(r)
+	v1.NewLoginMFAHandler(loginUsecase).AddToRouter(r)
+	v1.NewRefreshHandler(sessionUsecase).AddToRouter(r)
+	v1.NewLogoutHandler(sessionUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	v1.NewHealthHandler(healthUsecase).AddToRouter(r)
+	session_handlers.NewSessionsHandler(sessionUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	session_handlers.NewRevokeSessionHandler(sessionUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	session_handlers.NewRevokeAllSessionsHandler(sessionUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	mfa_handlers.NewEnrollMFAHandler(mfaUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	mfa_handlers.NewConfirmMFAHandler(mfaUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	mfa_handlers.NewDisableMFAHandler(mfaUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	password_handlers.NewChangePasswordHandler(passwordUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	password_handlers.NewRequestPasswordResetHandler(passwordUsecase).AddToRouter(r)
+	password_handlers.NewResetPasswordHandler(passwordUsecase).AddToRouter(r)
 	product_handlers.NewGetProductsByCategoryHandler(productUsecase).AddToRouter(r)
-	product_handlers.NewAddProductHandler(productUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
-	product_handlers.NewDeleteProductHandler(productService).Middlewares(authMiddleware.Do).AddToRouter(r)
-	product_handlers.NewUpdateProductNameHandler(productUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
-	product_handlers.NewUpdateProductCategoryHandler(productService).Middlewares(authMiddleware.Do).AddToRouter(r)
+	product_handlers.NewGetProductByIDHandler(productUsecase).AddToRouter(r)
+	product_handlers.NewSearchProductsHandler(searchUsecase).AddToRouter(r)
+	product_handlers.NewProductFacetsHandler(searchUsecase).AddToRouter(r)
+	product_handlers.NewAddProductHandler(productUsecase).Middlewares(catalogWrite).AddToRouter(r)
+	product_handlers.NewDeleteProductHandler(productService).Middlewares(catalogWrite).AddToRouter(r)
+	product_handlers.NewUpdateProductNameHandler(productUsecase).Middlewares(catalogWrite).AddToRouter(r)
+	product_handlers.NewUpdateProductDetailsHandler(productUsecase).Middlewares(catalogWrite).AddToRouter(r)
+	product_handlers.NewUpdateProductCategoryHandler(productService).Middlewares(catalogWrite).AddToRouter(r)
 	category_handlers.NewGetAllCategoriesHandler(categoryUsecase).AddToRouter(r)
+	category_handlers.NewGetCategorySubtreeHandler(categoryUsecase).AddToRouter(r)
+	category_handlers.NewGetCategoryAncestorsHandler(categoryUsecase).AddToRouter(r)
+
+	category_handlers.NewAddCategoryHandler(categoryUsecase).Middlewares(catalogWrite).AddToRouter(r)
+	category_handlers.NewDeleteCategoryHandler(categoryUsecase).Middlewares(catalogWrite).AddToRouter(r)
+	category_handlers.NewUpdateCategoryNameHandler(categoryUsecase).Middlewares(catalogWrite).AddToRouter(r)
+	category_handlers.NewMoveCategoryHandler(categoryUsecase).Middlewares(catalogWrite).AddToRouter(r)
+
+	sync_handlers.NewReconcileHandler(syncUsecase).Middlewares(syncManage).AddToRouter(r)
+	sync_handlers.NewReconcileReportsHandler(syncUsecase).Middlewares(syncManage).AddToRouter(r)
 
-	category_handlers.NewAddCategoryHandler(categoryUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
-	category_handlers.NewDeleteCategoryHandler(categoryUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
-	category_handlers.NewUpdateCategoryNameHandler(categoryUsecase).Middlewares(authMiddleware.Do).AddToRouter(r)
+	admin_handlers.NewGrantRoleHandler(adminUsecase).Middlewares(usersManage).AddToRouter(r)
+	admin_handlers.NewRevokeRoleHandler(adminUsecase).Middlewares(usersManage).AddToRouter(r)
+	admin_handlers.NewCreateAPIKeyHandler(apiKeyUsecase).Middlewares(usersManage).AddToRouter(r)
+	admin_handlers.NewAPIKeysHandler(apiKeyUsecase).Middlewares(usersManage).AddToRouter(r)
+	admin_handlers.NewRevokeAPIKeyHandler(apiKeyUsecase).Middlewares(usersManage).AddToRouter(r)
 
 	server := http.Server{
 		Addr:    config.RunAddress,
@@ -88,11 +218,7 @@ func Run() error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		err := productService.CheckNewProducts(ctx)
-		if err != nil {
-			slog.Error("error in updating products from dummyjson")
-			ServerShutdownSignal <- syscall.SIGINT
-		}
+		syncWorker.Run(ctx)
 	}()
 
 	wg.Add(1)
@@ -115,3 +241,24 @@ func Run() error {
 	slog.Info("server shutdown")
 	return nil
 }
+
+// newNotifier builds the notifier the config selects.
+func newNotifier(c config.Notifier) (service.Notifier, error) {
+	if c.Kind == "smtp" {
+		return notifier.NewSMTPNotifier(notifier.SMTPConfig{
+			Addr:     c.SMTP.Addr,
+			Username: c.SMTP.Username,
+			Password: c.SMTP.Password,
+			From:     c.SMTP.From,
+		})
+	}
+
+	if c.LogFile == "" {
+		return notifier.NewLogNotifier(os.Stderr), nil
+	}
+	f, err := os.OpenFile(c.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return notifier.NewLogNotifier(f), nil
+}
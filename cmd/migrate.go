@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/db"
+	"github.com/The-Gleb/product_catalog/internal/adapter/migration"
+	"github.com/The-Gleb/product_catalog/internal/adapter/sqlite"
+	"github.com/The-Gleb/product_catalog/internal/config"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	sqliteclient "github.com/The-Gleb/product_catalog/pkg/client/sqlite"
+)
+
+const migrateUsage = "usage: catalog migrate up | down [N] | status | goto VERSION"
+
+// runMigrate runs the migrate subcommand: up applies the pending migrations,
+// down rolls back the last N, 1 by default, goto moves the schema to a
+// version and status lists the migrations.
+func runMigrate(ctx context.Context, migrator *migration.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	var err error
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		return migrator.Up(ctx)
+
+	case args[0] == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("down takes a positive number of migrations, got %q", args[1])
+			}
+		}
+		return migrator.Down(ctx, steps)
+
+	case args[0] == "goto" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("goto takes a version, got %q", args[1])
+		}
+		return migrator.Goto(ctx, version)
+
+	case args[0] == "status" && len(args) == 1:
+		current, statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("schema version %d, latest %d\n", current, migrator.Latest())
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Fprintf(w, "%06d\t%s\t%s\n", s.Version, s.Name, state)
+		}
+		return w.Flush()
+	}
+
+	return errors.New(migrateUsage)
+}
+
+// newMigrator connects to the database of the driver c selects and returns
+// the migrator of its schema.
+func newMigrator(ctx context.Context, c config.Database) (*migration.Migrator, error) {
+	if c.Driver == "sqlite" {
+		client, err := sqliteclient.NewClient(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewMigrator(client, sqlite.Migrations)
+	}
+
+	client, err := postgresql.NewClient(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewMigrator(client, db.Migrations)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/db"
+	"github.com/The-Gleb/product_catalog/internal/adapter/memory"
+	"github.com/The-Gleb/product_catalog/internal/adapter/sqlite"
+	"github.com/The-Gleb/product_catalog/internal/config"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	sqliteclient "github.com/The-Gleb/product_catalog/pkg/client/sqlite"
+)
+
+// storages are the storages of one backend.
+type storages struct {
+	product         service.ProductStorage
+	productFacets   service.ProductFacetCounter
+	productSearcher service.ProductSearcher
+	category        service.CategoryStorage
+	session         service.SessionStorage
+	user            service.UserStorage
+	syncState       service.SyncStateStorage
+	reconcileReport service.ReconcileReportStorage
+	apiKey          service.APIKeyStorage
+	mfa             service.MFAStorage
+	passwordReset   service.PasswordResetStorage
+	loginAttempt    service.LoginAttemptStorage
+}
+
+func newDBStorages(client postgresql.Client, c config.LoginThrottle) storages {
+	productStorage := db.NewProductStorage(client)
+	s := storages{
+		product:         productStorage,
+		productFacets:   productStorage,
+		productSearcher: db.NewProductSearcher(client),
+		category:        db.NewCategoryStorage(client),
+		session:         db.NewSessionStorage(client),
+		user:            db.NewUserStorage(client),
+		syncState:       db.NewSyncStateStorage(client),
+		reconcileReport: db.NewReconcileReportStorage(client),
+		apiKey:          db.NewAPIKeyStorage(client),
+		mfa:             db.NewMFAStorage(client),
+		passwordReset:   db.NewPasswordResetStorage(client),
+		loginAttempt:    db.NewLoginAttemptStorage(client),
+	}
+	if c.Store == "memory" {
+		s.loginAttempt = memory.NewLoginAttemptStorage()
+	}
+	return s
+}
+
+func newSQLiteStorages(client *sql.DB, c config.LoginThrottle) storages {
+	productStorage := sqlite.NewProductStorage(client)
+	s := storages{
+		product:         productStorage,
+		productFacets:   productStorage,
+		productSearcher: sqlite.NewProductSearcher(client),
+		category:        sqlite.NewCategoryStorage(client),
+		session:         sqlite.NewSessionStorage(client),
+		user:            sqlite.NewUserStorage(client),
+		syncState:       sqlite.NewSyncStateStorage(client),
+		reconcileReport: sqlite.NewReconcileReportStorage(client),
+		apiKey:          sqlite.NewAPIKeyStorage(client),
+		mfa:             sqlite.NewMFAStorage(client),
+		passwordReset:   sqlite.NewPasswordResetStorage(client),
+		loginAttempt:    sqlite.NewLoginAttemptStorage(client),
+	}
+	if c.Store == "memory" {
+		s.loginAttempt = memory.NewLoginAttemptStorage()
+	}
+	return s
+}
+
+// newMemoryStorages builds storages sharing one in-memory store.
+func newMemoryStorages() storages {
+	store := memory.NewStore()
+	productStorage := memory.NewProductStorage(store)
+	return storages{
+		product:         productStorage,
+		productFacets:   productStorage,
+		productSearcher: memory.NewProductSearcher(store),
+		category:        memory.NewCategoryStorage(store),
+		session:         memory.NewSessionStorage(store),
+		user:            memory.NewUserStorage(store),
+		syncState:       memory.NewSyncStateStorage(store),
+		reconcileReport: memory.NewReconcileReportStorage(store),
+		apiKey:          memory.NewAPIKeyStorage(store),
+		mfa:             memory.NewMFAStorage(store),
+		passwordReset:   memory.NewPasswordResetStorage(store),
+		loginAttempt:    memory.NewLoginAttemptStorage(),
+	}
+}
+
+// newStorages builds the storages of the backend the config selects, on the
+// database of its driver. The database is migrated first if the config asks
+// for it.
+func newStorages(ctx context.Context, c *config.Config) (storages, error) {
+	if c.Storage == "memory" {
+		return newMemoryStorages(), nil
+	}
+
+	if c.DB.Driver == "sqlite" {
+		client, err := sqliteclient.NewClient(ctx, c.DB)
+		if err != nil {
+			return storages{}, err
+		}
+
+		if c.MigrateOnBoot {
+			migrator, err := sqlite.NewMigrator(client, sqlite.Migrations)
+			if err != nil {
+				return storages{}, err
+			}
+			err = migrator.Up(ctx)
+			if err != nil {
+				return storages{}, err
+			}
+		}
+
+		return newSQLiteStorages(client, c.LoginThrottle), nil
+	}
+
+	client, err := postgresql.NewClient(ctx, c.DB)
+	if err != nil {
+		return storages{}, err
+	}
+
+	if c.MigrateOnBoot {
+		migrator, err := db.NewMigrator(client, db.Migrations)
+		if err != nil {
+			return storages{}, err
+		}
+		err = migrator.Up(ctx)
+		if err != nil {
+			return storages{}, err
+		}
+	}
+
+	return newDBStorages(client, c.LoginThrottle), nil
+}
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"context"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ service.APIKeyStorage = new(apiKeyStorage)
+
+type apiKeyStorage struct {
+	client postgresql.Client
+}
+
+func NewAPIKeyStorage(client postgresql.Client) *apiKeyStorage {
+	return &apiKeyStorage{
+		client: client,
+	}
+}
+
+const apiKeyColumns = `id, name, prefix, hash, scopes, COALESCE(created_by, 0),
+	created_at, expires_at, revoked_at, last_used_at`
+
+func scanAPIKey(row pgx.Row) (entity.APIKey, error) {
+	var (
+		key    entity.APIKey
+		scopes []string
+	)
+	err := row.Scan(
+		&key.ID, &key.Name, &key.Prefix, &key.Hash, &scopes, &key.CreatedBy,
+		&key.CreatedAt, &key.ExpiresAt, &key.RevokedAt, &key.LastUsedAt,
+	)
+	key.Scopes = make([]entity.Permission, len(scopes))
+	for i, s := range scopes {
+		key.Scopes[i] = entity.Permission(s)
+	}
+	return key, err
+}
+
+func (ks *apiKeyStorage) Create(ctx context.Context, key entity.APIKey) (entity.APIKey, error) {
+	scopes := make([]string, len(key.Scopes))
+	for i, s := range key.Scopes {
+		scopes[i] = string(s)
+	}
+
+	var createdBy *int64
+	if key.CreatedBy != 0 {
+		createdBy = &key.CreatedBy
+	}
+
+	row := ks.client.QueryRow(
+		ctx,
+		`INSERT INTO api_key
+			(name, prefix, hash, scopes, created_by, created_at, expires_at)
+		VALUES
+			($1,$2,$3,$4,$5,$6,$7)
+		RETURNING `+apiKeyColumns+`;`,
+		key.Name, key.Prefix, key.Hash, scopes, createdBy, key.CreatedAt, key.ExpiresAt,
+	)
+
+	created, err := scanAPIKey(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return entity.APIKey{}, errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		slog.Error("error adding api key to db",
+			"error", err,
+		)
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return created, nil
+}
+
+func (ks *apiKeyStorage) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	row := ks.client.QueryRow(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_key
+		WHERE hash = $1;`,
+		hash,
+	)
+
+	key, err := scanAPIKey(row)
+	if err != nil {
+		if stdErrors.Is(err, pgx.ErrNoRows) {
+			return entity.APIKey{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting api key from db",
+			"error", err,
+		)
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return key, nil
+}
+
+// GetAll returns all API keys, revoked ones included, newest first.
+func (ks *apiKeyStorage) GetAll(ctx context.Context) ([]entity.APIKey, error) {
+	rows, err := ks.client.Query(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_key
+		ORDER BY id DESC;`,
+	)
+	if err != nil {
+		slog.Error("error getting api keys from db",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	keys, err := pgx.CollectRows[entity.APIKey](
+		rows, func(row pgx.CollectableRow) (entity.APIKey, error) {
+			return scanAPIKey(row)
+		},
+	)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return keys, nil
+}
+
+// Revoke marks a key revoked. Keys that don't exist or are already revoked
+// are reported as not found.
+func (ks *apiKeyStorage) Revoke(ctx context.Context, ID int64, revokedAt time.Time) error {
+	c, err := ks.client.Exec(
+		ctx,
+		`UPDATE api_key SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL;`,
+		ID, revokedAt,
+	)
+	if err != nil {
+		slog.Error("error revoking api key",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	return nil
+}
+
+func (ks *apiKeyStorage) Touch(ctx context.Context, ID int64, lastUsedAt time.Time) error {
+	_, err := ks.client.Exec(
+		ctx,
+		`UPDATE api_key SET last_used_at = $2
+		WHERE id = $1;`,
+		ID, lastUsedAt,
+	)
+	if err != nil {
+		slog.Error("error updating api key last use",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_apiKeyStorage(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"api_key", "session", "user",
+	)
+	userStorage := NewUserStorage(client)
+	user, err := userStorage.Create(
+		context.Background(),
+		entity.User{Login: "login1", Password: "password1"},
+	)
+	require.NoError(t, err)
+
+	apiKeyStorage := NewAPIKeyStorage(client)
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	key := entity.APIKey{
+		Name:      "importer",
+		Prefix:    "pck_aaaaaaaa",
+		Hash:      "hash1",
+		Scopes:    []entity.Permission{entity.PermissionCatalogWrite, entity.PermissionSyncManage},
+		CreatedBy: user.ID,
+		CreatedAt: time.Now().Truncate(time.Millisecond),
+		ExpiresAt: &expiresAt,
+	}
+
+	created, err := apiKeyStorage.Create(context.Background(), key)
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+	require.Equal(t, key.Scopes, created.Scopes)
+	require.Equal(t, user.ID, created.CreatedBy)
+
+	_, err = apiKeyStorage.Create(context.Background(), key)
+	require.Equal(t, errors.ErrAlreadyExists, errors.Code(err))
+
+	got, err := apiKeyStorage.GetByHash(context.Background(), "hash1")
+	require.NoError(t, err)
+	require.Equal(t, created.ID, got.ID)
+	require.True(t, got.ExpiresAt.Equal(expiresAt))
+	require.Nil(t, got.LastUsedAt)
+
+	_, err = apiKeyStorage.GetByHash(context.Background(), "hash2")
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	err = apiKeyStorage.Touch(context.Background(), created.ID, time.Now())
+	require.NoError(t, err)
+
+	err = apiKeyStorage.Revoke(context.Background(), created.ID, time.Now())
+	require.NoError(t, err)
+	err = apiKeyStorage.Revoke(context.Background(), created.ID, time.Now())
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	keys, err := apiKeyStorage.GetAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.NotNil(t, keys[0].RevokedAt)
+	require.NotNil(t, keys[0].LastUsedAt)
+}
@@ -3,7 +3,6 @@ package db
 import (
 	"context"
 	stdErrors "errors"
-	"fmt"
 	"log/slog"
 
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
@@ -17,6 +16,9 @@ import (
 
 var _ service.CategoryStorage = new(categoryStorage)
 
+// categoryMoveLockKey is the advisory lock that serializes category moves.
+const categoryMoveLockKey int64 = 0x6361745f6d6f7665
+
 type categoryStorage struct {
 	client postgresql.Client
 }
@@ -28,23 +30,23 @@ func NewCategoryStorage(client postgresql.Client) *categoryStorage {
 }
 
 func (s *categoryStorage) Add(ctx context.Context, category entity.AddCategoryDTO) error {
-	query := fmt.Sprintf(
+	c, err := s.client.Exec(
+		ctx,
 		`INSERT INTO "category"
-			(name)
+			(name, parent_id)
 		VALUES
-			('%s');`,
+			($1, $2);`,
 		category.Name,
-	)
-	slog.Debug(query)
-	c, err := s.client.Exec(
-		ctx,
-		query,
+		category.ParentID,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
 			return errors.NewDomainError(errors.ErrAlreadyExists, "")
 		}
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return errors.NewDomainError(errors.ErrCategoryNotFound, "parent")
+		}
 		slog.Error("error inserting into category",
 			"error", err,
 		)
@@ -60,7 +62,7 @@ func (s *categoryStorage) GetAll(ctx context.Context) ([]entity.Category, error)
 
 	rows, err := s.client.Query(
 		ctx,
-		`SELECT * FROM category;`,
+		`SELECT id, name, parent_id FROM category;`,
 	)
 	if err != nil {
 		slog.Error("error selcting from category",
@@ -70,24 +72,183 @@ func (s *categoryStorage) GetAll(ctx context.Context) ([]entity.Category, error)
 	}
 	defer rows.Close()
 
-	cats, err := pgx.CollectRows[entity.Category](
-		rows, func(row pgx.CollectableRow) (entity.Category, error) {
-			var cat entity.Category
-			err := row.Scan(&cat.ID, &cat.Name)
-			return cat, err
-		},
+	cats, err := pgx.CollectRows[entity.Category](rows, scanCategory)
+	if err != nil {
+		slog.Error("error collecting rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return cats, nil
+
+}
+
+// GetSubtree returns the category with the given ID followed by all of its
+// descendants.
+func (s *categoryStorage) GetSubtree(ctx context.Context, ID int64) ([]entity.Category, error) {
+
+	rows, err := s.client.Query(
+		ctx,
+		`WITH RECURSIVE subtree AS (
+			SELECT id, name, parent_id, 0 AS depth
+			FROM category
+			WHERE id = $1
+			UNION ALL
+			SELECT c.id, c.name, c.parent_id, subtree.depth + 1
+			FROM category c
+			JOIN subtree ON c.parent_id = subtree.id
+		)
+		SELECT id, name, parent_id FROM subtree
+		ORDER BY depth, id;`,
+		ID,
+	)
+	if err != nil {
+		slog.Error("error selecting category subtree",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer rows.Close()
+
+	cats, err := pgx.CollectRows[entity.Category](rows, scanCategory)
+	if err != nil {
+		slog.Error("error collecting rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	if len(cats) == 0 {
+		return nil, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return cats, nil
+
+}
+
+// GetAncestors returns the path from the root down to the category with the
+// given ID, the category itself included.
+func (s *categoryStorage) GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error) {
+
+	rows, err := s.client.Query(
+		ctx,
+		`WITH RECURSIVE ancestors AS (
+			SELECT id, name, parent_id, 0 AS depth
+			FROM category
+			WHERE id = $1
+			UNION ALL
+			SELECT c.id, c.name, c.parent_id, ancestors.depth + 1
+			FROM category c
+			JOIN ancestors ON c.id = ancestors.parent_id
+		)
+		SELECT id, name, parent_id FROM ancestors
+		ORDER BY depth DESC;`,
+		ID,
 	)
+	if err != nil {
+		slog.Error("error selecting category ancestors",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer rows.Close()
+
+	cats, err := pgx.CollectRows[entity.Category](rows, scanCategory)
 	if err != nil {
 		slog.Error("error collecting rows",
 			"error", err,
 		)
 		return nil, errors.NewDomainError(errors.ErrDB, "")
 	}
+	if len(cats) == 0 {
+		return nil, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
 
 	return cats, nil
 
 }
 
+func (s *categoryStorage) Move(ctx context.Context, category entity.MoveCategoryDTO) error {
+	tx, err := s.client.Begin(ctx)
+	if err != nil {
+		slog.Error("error beginnig transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback(ctx)
+
+	// Concurrent moves could build a cycle out of two individually valid
+	// moves, so they are serialized. Only moves take the lock, so other
+	// reads and writes of category don't wait.
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1);`, categoryMoveLockKey)
+	if err != nil {
+		slog.Error("error locking category moves",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	if category.NewParentID != nil {
+		var cycle bool
+		err = tx.QueryRow(
+			ctx,
+			`WITH RECURSIVE subtree AS (
+				SELECT id FROM category
+				WHERE id = $1
+				UNION ALL
+				SELECT c.id FROM category c
+				JOIN subtree ON c.parent_id = subtree.id
+			)
+			SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2);`,
+			category.CategoryID,
+			*category.NewParentID,
+		).Scan(&cycle)
+		if err != nil {
+			slog.Error("error checking category subtree",
+				"error", err,
+			)
+			return errors.NewDomainError(errors.ErrDB, "")
+		}
+		if cycle {
+			return errors.NewDomainError(errors.ErrCategoryCycle, "")
+		}
+	}
+
+	c, err := tx.Exec(
+		ctx,
+		`UPDATE category
+		SET parent_id = $2
+		WHERE id = $1;`,
+		category.CategoryID,
+		category.NewParentID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return errors.NewDomainError(errors.ErrCategoryNotFound, "parent")
+		}
+		slog.Error("error updating category parent",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		slog.Error("error commiting transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+
+}
+
 func (s *categoryStorage) UpdateName(ctx context.Context, category entity.UpdateCategoryNameDTO) error {
 
 	c, err := s.client.Exec(
@@ -143,3 +304,9 @@ func (s *categoryStorage) Delete(ctx context.Context, ID int64) error {
 	return nil
 
 }
+
+func scanCategory(row pgx.CollectableRow) (entity.Category, error) {
+	var cat entity.Category
+	err := row.Scan(&cat.ID, &cat.Name, &cat.ParentID)
+	return cat, err
+}
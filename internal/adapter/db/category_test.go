@@ -6,6 +6,7 @@ import (
 
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
 	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/require"
 )
@@ -90,6 +91,15 @@ func Test_categoryStorage_Add(t *testing.T) {
 			wantErr:   true,
 			errorCode: errors.ErrAlreadyExists,
 		},
+		{
+			name: "parent doesn't exist",
+			dto: entity.AddCategoryDTO{
+				Name:     "smartphone",
+				ParentID: new(int64),
+			},
+			wantErr:   true,
+			errorCode: errors.ErrCategoryNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -277,3 +287,209 @@ func Test_categoryStorage_Delete(t *testing.T) {
 		})
 	}
 }
+
+func insertCategoryTree(t *testing.T, client postgresql.Client) {
+	_, err := client.Exec(
+		context.Background(),
+		`INSERT INTO category
+			("id", "name", "parent_id")
+		VALUES
+			(1,'electronics',NULL),
+			(2,'phones',1),
+			(3,'smartphones',2),
+			(4,'laptops',1),
+			(5,'furniture',NULL);`,
+	)
+	require.NoError(t, err)
+}
+
+func Test_categoryStorage_GetSubtree(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+	insertCategoryTree(t, client)
+	storage := NewCategoryStorage(client)
+
+	electronicsID, phonesID := int64(1), int64(2)
+
+	tests := []struct {
+		name      string
+		ID        int64
+		want      []entity.Category
+		wantErr   bool
+		errorCode errors.ErrorCode
+	}{
+		{
+			name: "root with descendants",
+			ID:   1,
+			want: []entity.Category{
+				{ID: 1, Name: "electronics"},
+				{ID: 2, ParentID: &electronicsID, Name: "phones"},
+				{ID: 4, ParentID: &electronicsID, Name: "laptops"},
+				{ID: 3, ParentID: &phonesID, Name: "smartphones"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "leaf",
+			ID:   3,
+			want: []entity.Category{
+				{ID: 3, ParentID: &phonesID, Name: "smartphones"},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "id not found",
+			ID:        12,
+			wantErr:   true,
+			errorCode: errors.ErrNoDataFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories, err := storage.GetSubtree(context.Background(), tt.ID)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.want, categories)
+		})
+	}
+}
+
+func Test_categoryStorage_GetAncestors(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+	insertCategoryTree(t, client)
+	storage := NewCategoryStorage(client)
+
+	electronicsID, phonesID := int64(1), int64(2)
+
+	tests := []struct {
+		name      string
+		ID        int64
+		want      []entity.Category
+		wantErr   bool
+		errorCode errors.ErrorCode
+	}{
+		{
+			name: "nested category",
+			ID:   3,
+			want: []entity.Category{
+				{ID: 1, Name: "electronics"},
+				{ID: 2, ParentID: &electronicsID, Name: "phones"},
+				{ID: 3, ParentID: &phonesID, Name: "smartphones"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "root",
+			ID:   5,
+			want: []entity.Category{
+				{ID: 5, Name: "furniture"},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "id not found",
+			ID:        12,
+			wantErr:   true,
+			errorCode: errors.ErrNoDataFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories, err := storage.GetAncestors(context.Background(), tt.ID)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.want, categories)
+		})
+	}
+}
+
+func Test_categoryStorage_Move(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+	insertCategoryTree(t, client)
+	storage := NewCategoryStorage(client)
+
+	parent := func(ID int64) *int64 { return &ID }
+
+	tests := []struct {
+		name      string
+		dto       entity.MoveCategoryDTO
+		wantErr   bool
+		errorCode errors.ErrorCode
+	}{
+		{
+			name:    "move subtree",
+			dto:     entity.MoveCategoryDTO{CategoryID: 2, NewParentID: parent(4)},
+			wantErr: false,
+		},
+		{
+			name:    "make root",
+			dto:     entity.MoveCategoryDTO{CategoryID: 4, NewParentID: nil},
+			wantErr: false,
+		},
+		{
+			name:      "into itself",
+			dto:       entity.MoveCategoryDTO{CategoryID: 4, NewParentID: parent(4)},
+			wantErr:   true,
+			errorCode: errors.ErrCategoryCycle,
+		},
+		{
+			name:      "into own descendant",
+			dto:       entity.MoveCategoryDTO{CategoryID: 4, NewParentID: parent(3)},
+			wantErr:   true,
+			errorCode: errors.ErrCategoryCycle,
+		},
+		{
+			name:      "parent doesn't exist",
+			dto:       entity.MoveCategoryDTO{CategoryID: 4, NewParentID: parent(12)},
+			wantErr:   true,
+			errorCode: errors.ErrCategoryNotFound,
+		},
+		{
+			name:      "category doesn't exist",
+			dto:       entity.MoveCategoryDTO{CategoryID: 12, NewParentID: parent(1)},
+			wantErr:   true,
+			errorCode: errors.ErrNoDataFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := storage.Move(context.Background(), tt.dto)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			var parentID *int64
+			row := client.QueryRow(
+				context.Background(),
+				`SELECT parent_id FROM category
+				WHERE id = $1;`,
+				tt.dto.CategoryID,
+			)
+			err = row.Scan(&parentID)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.dto.NewParentID, parentID)
+		})
+	}
+}
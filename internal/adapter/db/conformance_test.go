@@ -0,0 +1,30 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/storagetest"
+)
+
+func Test_conformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storages {
+		client := getTestClient(t)
+		cleanTables(
+			t, client,
+			"product_category", "product", "category", "session", "user", "sync_state",
+			"reconcile_report", "api_key", "recovery_code", "user_mfa", "password_reset", "login_attempt",
+		)
+		return storagetest.Storages{
+			Product:         NewProductStorage(client),
+			Category:        NewCategoryStorage(client),
+			User:            NewUserStorage(client),
+			Session:         NewSessionStorage(client),
+			SyncState:       NewSyncStateStorage(client),
+			ReconcileReport: NewReconcileReportStorage(client),
+			APIKey:          NewAPIKeyStorage(client),
+			MFA:             NewMFAStorage(client),
+			PasswordReset:   NewPasswordResetStorage(client),
+			LoginAttempt:    NewLoginAttemptStorage(client),
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loginAttemptStorage(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(t, client, "login_attempt")
+
+	storage := NewLoginAttemptStorage(client)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+
+	_, err := storage.Get(ctx, "login:login1")
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	attempts, err := storage.RecordFailure(ctx, "login:login1", now, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts.Failures)
+
+	attempts, err = storage.RecordFailure(ctx, "login:login1", now.Add(time.Second), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts.Failures)
+
+	require.NoError(t, storage.Lock(ctx, "login:login1", now.Add(time.Hour)))
+	require.NoError(t, storage.Lock(ctx, "login:login1", now.Add(time.Minute)))
+
+	attempts, err = storage.Get(ctx, "login:login1")
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts.Failures)
+	require.True(t, attempts.LockedUntil.Equal(now.Add(time.Hour)))
+
+	// The count starts over once the last failure is out of the window.
+	attempts, err = storage.RecordFailure(ctx, "login:login1", now.Add(2*time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts.Failures)
+
+	require.NoError(t, storage.Reset(ctx, "login:login1"))
+	_, err = storage.Get(ctx, "login:login1")
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+}
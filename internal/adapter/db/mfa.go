@@ -0,0 +1,238 @@
+package db
+
+import (
+	"context"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	"github.com/jackc/pgx/v5"
+)
+
+var _ service.MFAStorage = new(mfaStorage)
+
+type mfaStorage struct {
+	client postgresql.Client
+}
+
+func NewMFAStorage(client postgresql.Client) *mfaStorage {
+	return &mfaStorage{
+		client: client,
+	}
+}
+
+func (ms *mfaStorage) Get(ctx context.Context, userID int64) (entity.MFA, error) {
+	row := ms.client.QueryRow(
+		ctx,
+		`SELECT user_id, secret, enabled, last_step FROM user_mfa
+		WHERE user_id = $1;`,
+		userID,
+	)
+
+	var mfa entity.MFA
+	err := row.Scan(&mfa.UserID, &mfa.Secret, &mfa.Enabled, &mfa.LastStep)
+	if err != nil {
+		if stdErrors.Is(err, pgx.ErrNoRows) {
+			return entity.MFA{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting mfa from db",
+			"error", err,
+		)
+		return entity.MFA{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return mfa, nil
+}
+
+// Save stores a pending enrollment. An enabled one is never replaced, that
+// is reported as ErrAlreadyExists.
+func (ms *mfaStorage) Save(ctx context.Context, mfa entity.MFA, recoveryCodes []string) error {
+	tx, err := ms.client.Begin(ctx)
+	if err != nil {
+		slog.Error("error beginnig transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback(ctx)
+
+	c, err := tx.Exec(
+		ctx,
+		`INSERT INTO user_mfa
+			(user_id, secret, enabled, last_step)
+		VALUES
+			($1, $2, false, 0)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = EXCLUDED.secret,
+			last_step = 0,
+			created_at = now()
+		WHERE NOT user_mfa.enabled;`,
+		mfa.UserID, mfa.Secret,
+	)
+	if err != nil {
+		slog.Error("error saving mfa to db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrAlreadyExists, "two-factor authentication is already enabled")
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`DELETE FROM recovery_code
+		WHERE user_id = $1;`,
+		mfa.UserID,
+	)
+	if err != nil {
+		slog.Error("error deleting recovery codes from db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO recovery_code
+			(user_id, hash)
+		SELECT $1, unnest($2::varchar[]);`,
+		mfa.UserID, recoveryCodes,
+	)
+	if err != nil {
+		slog.Error("error adding recovery codes to db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		slog.Error("error commiting transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+func (ms *mfaStorage) Enable(ctx context.Context, userID int64) error {
+	c, err := ms.client.Exec(
+		ctx,
+		`UPDATE user_mfa
+		SET enabled = true
+		WHERE user_id = $1;`,
+		userID,
+	)
+	if err != nil {
+		slog.Error("error enabling mfa",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
+
+// Delete removes the enrollment. The recovery codes go with it.
+func (ms *mfaStorage) Delete(ctx context.Context, userID int64) error {
+	tx, err := ms.client.Begin(ctx)
+	if err != nil {
+		slog.Error("error beginnig transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback(ctx)
+
+	c, err := tx.Exec(
+		ctx,
+		`DELETE FROM user_mfa
+		WHERE user_id = $1;`,
+		userID,
+	)
+	if err != nil {
+		slog.Error("error deleting mfa from db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`DELETE FROM recovery_code
+		WHERE user_id = $1;`,
+		userID,
+	)
+	if err != nil {
+		slog.Error("error deleting recovery codes from db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		slog.Error("error commiting transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+// UseStep advances the last used step in a single update, so that a code
+// sent twice at once is accepted only once.
+func (ms *mfaStorage) UseStep(ctx context.Context, userID, step int64) error {
+	c, err := ms.client.Exec(
+		ctx,
+		`UPDATE user_mfa
+		SET last_step = $2
+		WHERE user_id = $1 AND last_step < $2;`,
+		userID, step,
+	)
+	if err != nil {
+		slog.Error("error updating mfa step",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
+
+func (ms *mfaStorage) UseRecoveryCode(ctx context.Context, userID int64, hash string, usedAt time.Time) error {
+	c, err := ms.client.Exec(
+		ctx,
+		`UPDATE recovery_code
+		SET used_at = $3
+		WHERE user_id = $1 AND hash = $2 AND used_at IS NULL;`,
+		userID, hash, usedAt,
+	)
+	if err != nil {
+		slog.Error("error using recovery code",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mfaStorage(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"recovery_code", "user_mfa", "session", "user",
+	)
+	userStorage := NewUserStorage(client)
+	user, err := userStorage.Create(
+		context.Background(),
+		entity.User{Login: "login1", Password: "password1"},
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	mfaStorage := NewMFAStorage(client)
+
+	_, err = mfaStorage.Get(ctx, user.ID)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	require.NoError(t, mfaStorage.Save(ctx, entity.MFA{UserID: user.ID, Secret: []byte("secret1")}, []string{"hash1"}))
+	// A pending enrollment is replaced, along with its codes.
+	require.NoError(t, mfaStorage.Save(ctx, entity.MFA{UserID: user.ID, Secret: []byte("secret2")}, []string{"hash2", "hash3"}))
+
+	mfa, err := mfaStorage.Get(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.MFA{UserID: user.ID, Secret: []byte("secret2")}, mfa)
+
+	err = mfaStorage.UseRecoveryCode(ctx, user.ID, "hash1", time.Now())
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	require.NoError(t, mfaStorage.Enable(ctx, user.ID))
+	err = mfaStorage.Save(ctx, entity.MFA{UserID: user.ID, Secret: []byte("secret3")}, nil)
+	require.Equal(t, errors.ErrAlreadyExists, errors.Code(err))
+
+	require.NoError(t, mfaStorage.UseStep(ctx, user.ID, 100))
+	err = mfaStorage.UseStep(ctx, user.ID, 100)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+	err = mfaStorage.UseStep(ctx, user.ID, 99)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	require.NoError(t, mfaStorage.UseRecoveryCode(ctx, user.ID, "hash2", time.Now()))
+	err = mfaStorage.UseRecoveryCode(ctx, user.ID, "hash2", time.Now())
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	mfa, err = mfaStorage.Get(ctx, user.ID)
+	require.NoError(t, err)
+	require.True(t, mfa.Enabled)
+	require.Equal(t, int64(100), mfa.LastStep)
+
+	require.NoError(t, mfaStorage.Delete(ctx, user.ID))
+	_, err = mfaStorage.Get(ctx, user.ID)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+	err = mfaStorage.UseRecoveryCode(ctx, user.ID, "hash3", time.Now())
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+}
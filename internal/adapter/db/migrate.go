@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"embed"
+	stdErrors "errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/migration"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	"github.com/jackc/pgx/v5"
+)
+
+// Migrations are the schema migrations, NNNNNN_name.up.sql and
+// NNNNNN_name.down.sql pairs in the migration directory.
+//
+//go:embed migration/*.sql
+var Migrations embed.FS
+
+// migrationLockKey is the advisory lock that keeps replicas migrating at the
+// same time from applying a migration twice.
+const migrationLockKey int64 = 0x636174616c6f67
+
+// NewMigrator reads the migrations of fsys, laid out like Migrations.
+func NewMigrator(client postgresql.Client, fsys fs.FS) (*migration.Migrator, error) {
+	return migration.NewMigrator(migrationDriver{client: client}, fsys)
+}
+
+// migrationDriver runs migrations on Postgres under an advisory lock.
+type migrationDriver struct {
+	client postgresql.Client
+}
+
+func (d migrationDriver) Version(ctx context.Context) (int64, error) {
+	var exists bool
+	err := d.client.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL;`).Scan(&exists)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+	return schemaVersion(ctx, d.client)
+}
+
+func (d migrationDriver) Begin(ctx context.Context) (migration.Tx, error) {
+	tx, err := d.client.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1);`, migrationLockKey)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("taking migration lock: %w", err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			"version" bigint NOT NULL PRIMARY KEY,
+			"dirty" boolean NOT NULL
+		);`,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	return migrationTx{tx: tx}, nil
+}
+
+type migrationTx struct {
+	tx pgx.Tx
+}
+
+func (t migrationTx) Version(ctx context.Context) (int64, error) {
+	return schemaVersion(ctx, t.tx)
+}
+
+func (t migrationTx) Exec(ctx context.Context, sql string) error {
+	_, err := t.tx.Exec(ctx, sql)
+	return err
+}
+
+func (t migrationTx) SetVersion(ctx context.Context, version int64) error {
+	_, err := t.tx.Exec(ctx, `DELETE FROM schema_migrations;`)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	_, err = t.tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false);`, version)
+	return err
+}
+
+func (t migrationTx) Commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+func (t migrationTx) Rollback(ctx context.Context) error {
+	return t.tx.Rollback(ctx)
+}
+
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// schemaVersion reads the version of schema_migrations, 0 if it is empty. A
+// schema left dirty by a failed migrate/migrate run has to be fixed by hand.
+func schemaVersion(ctx context.Context, q queryRower) (int64, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := q.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1;`).Scan(&version, &dirty)
+	if err != nil {
+		if stdErrors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("schema is dirty at version %d, fix it and clear schema_migrations.dirty", version)
+	}
+	return version, nil
+}
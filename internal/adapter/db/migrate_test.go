@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/migration"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Migrations(t *testing.T) {
+	migrations, err := migration.Load(Migrations)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		require.Equal(t, int64(i+1), m.Version, "migration versions should have no gaps")
+	}
+}
+
+func Test_migrator(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+
+	m, err := NewMigrator(client, Migrations)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, m.Up(ctx))
+	})
+
+	require.NoError(t, m.Goto(ctx, 0))
+	current, statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), current)
+	for _, s := range statuses {
+		require.False(t, s.Applied)
+	}
+
+	require.NoError(t, m.Up(ctx))
+	current, statuses, err = m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, m.Latest(), current)
+	for _, s := range statuses {
+		require.True(t, s.Applied)
+	}
+
+	require.NoError(t, m.Down(ctx, 2))
+	current, _, err = m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, m.Latest()-2, current)
+
+	require.NoError(t, m.Up(ctx))
+	require.Error(t, m.Goto(ctx, m.Latest()+1))
+}
+
+func Test_migrator_constraints(t *testing.T) {
+	client := getTestClient(t)
+	ctx := context.Background()
+
+	m, err := NewMigrator(client, Migrations)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, m.Up(ctx))
+	})
+
+	require.NoError(t, m.Goto(ctx, 13))
+	cleanTables(t, client, "product", "category", "product_category", "user", "session")
+
+	_, err = client.Exec(
+		ctx,
+		`INSERT INTO product (id, name) VALUES (1, 'Phone'), (2, 'phone'), (3, NULL), (4, ' ');
+		INSERT INTO category (id, name, parent_id) VALUES (1, 'Phones', NULL), (2, 'PHONES', 2);
+		INSERT INTO product_category (product_id, category_id) VALUES (1, 1), (2, 2);
+		INSERT INTO "user" (id, login, password) VALUES (1, 'bob', 'hash'), (2, 'Bob', NULL);
+		INSERT INTO session (token, user_id, expiry) VALUES ('t1', 1, now()), (NULL, 1, now()), ('t3', 2, NULL);`,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Goto(ctx, 14))
+
+	names := func(query string) []string {
+		rows, err := client.Query(ctx, query)
+		require.NoError(t, err)
+		got, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		require.NoError(t, err)
+		return got
+	}
+	require.Equal(t,
+		[]string{"Phone", "phone (2)", "product 3", "product 4"},
+		names(`SELECT name FROM product ORDER BY id;`),
+	)
+	require.Equal(t,
+		[]string{"Phones", "PHONES (2)"},
+		names(`SELECT name FROM category ORDER BY id;`),
+	)
+	require.Equal(t,
+		[]string{"bob", "Bob (2)"},
+		names(`SELECT login FROM "user" ORDER BY id;`),
+	)
+	require.Equal(t,
+		[]string{"t1"},
+		names(`SELECT token FROM session;`),
+	)
+
+	_, err = client.Exec(ctx, `INSERT INTO category (id, name) VALUES (3, 'phones');`)
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "category_name_lower_key", pgErr.ConstraintName)
+
+	_, err = client.Exec(ctx, `DELETE FROM "user" WHERE id = 1;`)
+	require.NoError(t, err)
+	require.Empty(t, names(`SELECT token FROM session;`))
+
+	require.NoError(t, m.Goto(ctx, 13))
+	require.NoError(t, m.Goto(ctx, 14))
+}
@@ -0,0 +1 @@
+ALTER TABLE "category" DROP COLUMN IF EXISTS "parent_id";
@@ -0,0 +1,5 @@
+ALTER TABLE "category" ADD COLUMN "parent_id" bigint;
+
+ALTER TABLE "category" ADD FOREIGN KEY ("parent_id") REFERENCES "category" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "category" ("parent_id");
@@ -0,0 +1,11 @@
+ALTER TABLE "product"
+    DROP COLUMN IF EXISTS "description",
+    DROP COLUMN IF EXISTS "price",
+    DROP COLUMN IF EXISTS "discount_percentage",
+    DROP COLUMN IF EXISTS "rating",
+    DROP COLUMN IF EXISTS "stock",
+    DROP COLUMN IF EXISTS "brand",
+    DROP COLUMN IF EXISTS "sku",
+    DROP COLUMN IF EXISTS "thumbnail",
+    DROP COLUMN IF EXISTS "images",
+    DROP COLUMN IF EXISTS "attributes";
@@ -0,0 +1,11 @@
+ALTER TABLE "product"
+    ADD COLUMN "description" text NOT NULL DEFAULT '',
+    ADD COLUMN "price" numeric(12,2) NOT NULL DEFAULT 0 CHECK ("price" >= 0),
+    ADD COLUMN "discount_percentage" numeric(5,2) NOT NULL DEFAULT 0 CHECK ("discount_percentage" BETWEEN 0 AND 100),
+    ADD COLUMN "rating" numeric(3,2) NOT NULL DEFAULT 0 CHECK ("rating" BETWEEN 0 AND 5),
+    ADD COLUMN "stock" integer NOT NULL DEFAULT 0 CHECK ("stock" >= 0),
+    ADD COLUMN "brand" varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN "sku" varchar(64) UNIQUE,
+    ADD COLUMN "thumbnail" text NOT NULL DEFAULT '',
+    ADD COLUMN "images" text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN "attributes" jsonb NOT NULL DEFAULT '{}';
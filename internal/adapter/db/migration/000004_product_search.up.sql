@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE "product" ADD COLUMN "search_vector" tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce("name", '')), 'A') ||
+        setweight(to_tsvector('english', coalesce("description", '')), 'B')
+    ) STORED;
+
+CREATE INDEX "product_search_vector_idx" ON "product" USING GIN ("search_vector");
+
+CREATE INDEX "product_name_trgm_idx" ON "product" USING GIN ("name" gin_trgm_ops);
@@ -0,0 +1,6 @@
+CREATE TABLE "sync_state" (
+    "source" varchar(64) PRIMARY KEY,
+    "offset" integer NOT NULL DEFAULT 0,
+    "total" integer NOT NULL DEFAULT 0,
+    "updated_at" timestamp NOT NULL DEFAULT now()
+);
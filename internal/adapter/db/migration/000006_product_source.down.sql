@@ -0,0 +1,5 @@
+ALTER TABLE "product"
+    DROP CONSTRAINT IF EXISTS "product_source_external_id_check",
+    DROP CONSTRAINT IF EXISTS "product_source_external_id_key",
+    DROP COLUMN IF EXISTS "external_id",
+    DROP COLUMN IF EXISTS "source";
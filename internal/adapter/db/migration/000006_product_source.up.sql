@@ -0,0 +1,5 @@
+ALTER TABLE "product"
+    ADD COLUMN "source" varchar(64),
+    ADD COLUMN "external_id" varchar(255),
+    ADD CONSTRAINT "product_source_external_id_key" UNIQUE ("source", "external_id"),
+    ADD CONSTRAINT "product_source_external_id_check" CHECK (("source" IS NULL) = ("external_id" IS NULL));
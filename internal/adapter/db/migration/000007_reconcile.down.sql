@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reconcile_report";
+DROP INDEX IF EXISTS "product_source_idx";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "deleted_at";
@@ -0,0 +1,13 @@
+ALTER TABLE "product" ADD COLUMN "deleted_at" timestamp;
+
+CREATE INDEX "product_source_idx" ON "product" ("source") WHERE "source" IS NOT NULL;
+
+CREATE TABLE "reconcile_report" (
+    "id" bigserial PRIMARY KEY,
+    "source" varchar(64) NOT NULL,
+    "started_at" timestamp NOT NULL,
+    "finished_at" timestamp NOT NULL,
+    "report" jsonb NOT NULL
+);
+
+CREATE INDEX "reconcile_report_source_idx" ON "reconcile_report" ("source", "id" DESC);
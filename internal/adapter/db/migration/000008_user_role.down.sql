@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "user_admin_idx";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,5 @@
+ALTER TABLE "user"
+    ADD COLUMN "role" varchar(16) NOT NULL DEFAULT 'viewer'
+    CHECK ("role" IN ('viewer', 'editor', 'admin'));
+
+CREATE INDEX "user_admin_idx" ON "user" ("id") WHERE "role" = 'admin';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "session_user_id_idx";
+ALTER TABLE "session"
+    DROP COLUMN IF EXISTS "created_at",
+    DROP COLUMN IF EXISTS "last_used_at",
+    DROP COLUMN IF EXISTS "ip",
+    DROP COLUMN IF EXISTS "user_agent";
@@ -0,0 +1,7 @@
+ALTER TABLE "session"
+    ADD COLUMN "created_at" timestamp NOT NULL DEFAULT now(),
+    ADD COLUMN "last_used_at" timestamp NOT NULL DEFAULT now(),
+    ADD COLUMN "ip" varchar(64) NOT NULL DEFAULT '',
+    ADD COLUMN "user_agent" varchar(512) NOT NULL DEFAULT '';
+
+CREATE INDEX "session_user_id_idx" ON "session" ("user_id");
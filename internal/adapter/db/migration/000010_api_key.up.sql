@@ -0,0 +1,12 @@
+CREATE TABLE "api_key" (
+    "id" bigserial PRIMARY KEY,
+    "name" varchar(255) NOT NULL,
+    "prefix" varchar(16) NOT NULL,
+    "hash" varchar(64) NOT NULL UNIQUE,
+    "scopes" varchar(32)[] NOT NULL DEFAULT '{}',
+    "created_by" bigint REFERENCES "user" ("id") ON DELETE SET NULL,
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "expires_at" timestamp,
+    "revoked_at" timestamp,
+    "last_used_at" timestamp
+);
@@ -0,0 +1,6 @@
+CREATE TABLE "login_attempt" (
+    "key" varchar(320) PRIMARY KEY,
+    "failures" integer NOT NULL,
+    "last_failure" timestamp NOT NULL,
+    "locked_until" timestamp
+);
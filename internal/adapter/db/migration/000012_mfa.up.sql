@@ -0,0 +1,15 @@
+CREATE TABLE "user_mfa" (
+    "user_id" bigint PRIMARY KEY REFERENCES "user" ("id") ON DELETE CASCADE,
+    "secret" bytea NOT NULL,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "last_step" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE "recovery_code" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL REFERENCES "user" ("id") ON DELETE CASCADE,
+    "hash" varchar(64) NOT NULL,
+    "used_at" timestamp,
+    UNIQUE ("user_id", "hash")
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "password_reset";
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "email";
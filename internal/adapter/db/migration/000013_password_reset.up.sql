@@ -0,0 +1,11 @@
+ALTER TABLE "user" ADD COLUMN "email" varchar(320);
+
+CREATE TABLE "password_reset" (
+    "hash" varchar(64) PRIMARY KEY,
+    "user_id" bigint NOT NULL REFERENCES "user" ("id") ON DELETE CASCADE,
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "expires_at" timestamp NOT NULL,
+    "used_at" timestamp
+);
+
+CREATE INDEX "password_reset_user_id_idx" ON "password_reset" ("user_id");
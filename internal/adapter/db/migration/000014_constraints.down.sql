@@ -0,0 +1,61 @@
+-- The names and logins renamed by the up migration keep their new values.
+DROP TRIGGER IF EXISTS "user_updated_at" ON "user";
+DROP TRIGGER IF EXISTS "category_updated_at" ON "category";
+DROP TRIGGER IF EXISTS "product_updated_at" ON "product";
+DROP FUNCTION IF EXISTS "set_updated_at"();
+
+ALTER TABLE "user"
+    DROP COLUMN IF EXISTS "created_at",
+    DROP COLUMN IF EXISTS "updated_at";
+
+ALTER TABLE "category"
+    DROP COLUMN IF EXISTS "created_at",
+    DROP COLUMN IF EXISTS "updated_at";
+
+ALTER TABLE "product"
+    DROP COLUMN IF EXISTS "created_at",
+    DROP COLUMN IF EXISTS "updated_at";
+
+DROP INDEX IF EXISTS "user_login_lower_key";
+
+ALTER TABLE "user"
+    ADD CONSTRAINT "user_login_key" UNIQUE ("login"),
+    DROP CONSTRAINT IF EXISTS "user_login_check",
+    ALTER COLUMN "password" DROP NOT NULL,
+    ALTER COLUMN "login" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "category_name_lower_key";
+
+ALTER TABLE "category"
+    ADD CONSTRAINT "category_name_key" UNIQUE ("name"),
+    DROP CONSTRAINT IF EXISTS "category_parent_id_check",
+    DROP CONSTRAINT IF EXISTS "category_name_check",
+    ALTER COLUMN "name" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "product_name_id_idx";
+DROP INDEX IF EXISTS "product_name_lower_key";
+
+ALTER TABLE "product"
+    ADD CONSTRAINT "product_name_key" UNIQUE ("name"),
+    DROP CONSTRAINT IF EXISTS "product_name_check",
+    ALTER COLUMN "name" DROP NOT NULL;
+
+ALTER TABLE "session"
+    ALTER COLUMN "expiry" DROP NOT NULL,
+    ALTER COLUMN "token" DROP NOT NULL,
+    DROP CONSTRAINT "session_user_id_fkey",
+    ADD CONSTRAINT "session_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "user" ("id");
+
+DROP INDEX IF EXISTS "product_category_category_id_idx";
+
+CREATE SEQUENCE "session_user_id_seq" OWNED BY "session"."user_id";
+CREATE SEQUENCE "product_category_category_id_seq" OWNED BY "product_category"."category_id";
+CREATE SEQUENCE "product_category_product_id_seq" OWNED BY "product_category"."product_id";
+
+ALTER TABLE "session" ALTER COLUMN "user_id" SET DEFAULT nextval('session_user_id_seq');
+
+ALTER TABLE "product_category"
+    DROP CONSTRAINT "product_category_pkey",
+    ADD CONSTRAINT "product_category_product_id_category_id_key" UNIQUE ("product_id", "category_id"),
+    ALTER COLUMN "category_id" SET DEFAULT nextval('product_category_category_id_seq'),
+    ALTER COLUMN "product_id" SET DEFAULT nextval('product_category_product_id_seq');
@@ -0,0 +1,114 @@
+-- Existing rows are fixed up first so that the constraints below hold:
+-- missing and blank names get a placeholder, names that only differ in case
+-- get the id of the row appended, except for the oldest one, and sessions
+-- without a token or an expiry are dropped. Users without a password keep an
+-- empty one, which no password matches, until they reset it.
+UPDATE "product" SET "name" = 'product ' || "id"
+WHERE "name" IS NULL OR btrim("name") = '';
+
+UPDATE "product" p SET "name" = left(p."name", 230) || ' (' || p."id" || ')'
+WHERE EXISTS (
+    SELECT 1 FROM "product" o
+    WHERE lower(o."name") = lower(p."name") AND o."id" < p."id"
+);
+
+UPDATE "category" SET "name" = 'category ' || "id"
+WHERE "name" IS NULL OR btrim("name") = '';
+
+UPDATE "category" c SET "name" = left(c."name", 230) || ' (' || c."id" || ')'
+WHERE EXISTS (
+    SELECT 1 FROM "category" o
+    WHERE lower(o."name") = lower(c."name") AND o."id" < c."id"
+);
+
+UPDATE "category" SET "parent_id" = NULL WHERE "parent_id" = "id";
+
+UPDATE "user" SET "login" = 'user ' || "id"
+WHERE "login" IS NULL OR btrim("login") = '';
+
+UPDATE "user" u SET "login" = left(u."login", 230) || ' (' || u."id" || ')'
+WHERE EXISTS (
+    SELECT 1 FROM "user" o
+    WHERE lower(o."login") = lower(u."login") AND o."id" < u."id"
+);
+
+UPDATE "user" SET "password" = '' WHERE "password" IS NULL;
+
+DELETE FROM "session" WHERE "token" IS NULL OR "expiry" IS NULL;
+
+-- The foreign keys were declared bigserial, which gave each a sequence
+-- nothing uses.
+ALTER TABLE "product_category"
+    ALTER COLUMN "product_id" DROP DEFAULT,
+    ALTER COLUMN "category_id" DROP DEFAULT,
+    DROP CONSTRAINT "product_category_product_id_category_id_key",
+    ADD PRIMARY KEY ("product_id", "category_id");
+
+ALTER TABLE "session" ALTER COLUMN "user_id" DROP DEFAULT;
+
+DROP SEQUENCE "product_category_product_id_seq";
+DROP SEQUENCE "product_category_category_id_seq";
+DROP SEQUENCE "session_user_id_seq";
+
+CREATE INDEX "product_category_category_id_idx" ON "product_category" ("category_id");
+
+ALTER TABLE "session"
+    DROP CONSTRAINT "session_user_id_fkey",
+    ADD CONSTRAINT "session_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE,
+    ALTER COLUMN "token" SET NOT NULL,
+    ALTER COLUMN "expiry" SET NOT NULL;
+
+-- Names are unique regardless of case. The keyset pagination by name keeps
+-- an index of its own.
+ALTER TABLE "product"
+    ALTER COLUMN "name" SET NOT NULL,
+    ADD CONSTRAINT "product_name_check" CHECK (btrim("name") <> ''),
+    DROP CONSTRAINT "product_name_key";
+
+CREATE UNIQUE INDEX "product_name_lower_key" ON "product" (lower("name"));
+CREATE INDEX "product_name_id_idx" ON "product" ("name", "id");
+
+ALTER TABLE "category"
+    ALTER COLUMN "name" SET NOT NULL,
+    ADD CONSTRAINT "category_name_check" CHECK (btrim("name") <> ''),
+    ADD CONSTRAINT "category_parent_id_check" CHECK ("parent_id" <> "id"),
+    DROP CONSTRAINT "category_name_key";
+
+CREATE UNIQUE INDEX "category_name_lower_key" ON "category" (lower("name"));
+
+ALTER TABLE "user"
+    ALTER COLUMN "login" SET NOT NULL,
+    ALTER COLUMN "password" SET NOT NULL,
+    ADD CONSTRAINT "user_login_check" CHECK (btrim("login") <> ''),
+    DROP CONSTRAINT "user_login_key";
+
+CREATE UNIQUE INDEX "user_login_lower_key" ON "user" (lower("login"));
+
+-- updated_at is kept by a trigger so that every UPDATE sets it.
+CREATE FUNCTION "set_updated_at"() RETURNS trigger AS $$
+BEGIN
+    NEW."updated_at" = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE "product"
+    ADD COLUMN "created_at" timestamp NOT NULL DEFAULT now(),
+    ADD COLUMN "updated_at" timestamp NOT NULL DEFAULT now();
+
+ALTER TABLE "category"
+    ADD COLUMN "created_at" timestamp NOT NULL DEFAULT now(),
+    ADD COLUMN "updated_at" timestamp NOT NULL DEFAULT now();
+
+ALTER TABLE "user"
+    ADD COLUMN "created_at" timestamp NOT NULL DEFAULT now(),
+    ADD COLUMN "updated_at" timestamp NOT NULL DEFAULT now();
+
+CREATE TRIGGER "product_updated_at" BEFORE UPDATE ON "product"
+    FOR EACH ROW EXECUTE FUNCTION "set_updated_at"();
+
+CREATE TRIGGER "category_updated_at" BEFORE UPDATE ON "category"
+    FOR EACH ROW EXECUTE FUNCTION "set_updated_at"();
+
+CREATE TRIGGER "user_updated_at" BEFORE UPDATE ON "user"
+    FOR EACH ROW EXECUTE FUNCTION "set_updated_at"();
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	"github.com/jackc/pgx/v5"
+)
+
+var _ service.PasswordResetStorage = new(passwordResetStorage)
+
+type passwordResetStorage struct {
+	client postgresql.Client
+}
+
+func NewPasswordResetStorage(client postgresql.Client) *passwordResetStorage {
+	return &passwordResetStorage{
+		client: client,
+	}
+}
+
+// Create stores a reset token. The other tokens of the user are deleted, so
+// that only the latest link works, and so are the expired ones.
+func (rs *passwordResetStorage) Create(ctx context.Context, reset entity.PasswordReset) error {
+	tx, err := rs.client.Begin(ctx)
+	if err != nil {
+		slog.Error("error beginnig transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(
+		ctx,
+		`DELETE FROM password_reset
+		WHERE user_id = $1 OR expires_at < $2;`,
+		reset.UserID, reset.CreatedAt,
+	)
+	if err != nil {
+		slog.Error("error deleting password resets",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO password_reset
+			(hash, user_id, created_at, expires_at)
+		VALUES
+			($1, $2, $3, $4);`,
+		reset.Hash, reset.UserID, reset.CreatedAt, reset.ExpiresAt,
+	)
+	if err != nil {
+		slog.Error("error adding password reset to db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		slog.Error("error commiting transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+func (rs *passwordResetStorage) Get(ctx context.Context, hash string, now time.Time) (entity.PasswordReset, error) {
+	row := rs.client.QueryRow(
+		ctx,
+		`SELECT hash, user_id, created_at, expires_at FROM password_reset
+		WHERE hash = $1 AND used_at IS NULL AND expires_at > $2;`,
+		hash, now,
+	)
+
+	var reset entity.PasswordReset
+	err := row.Scan(&reset.Hash, &reset.UserID, &reset.CreatedAt, &reset.ExpiresAt)
+	if err != nil {
+		if stdErrors.Is(err, pgx.ErrNoRows) {
+			return entity.PasswordReset{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting password reset from db",
+			"error", err,
+		)
+		return entity.PasswordReset{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return reset, nil
+}
+
+func (rs *passwordResetStorage) Use(ctx context.Context, hash string, now time.Time) error {
+	c, err := rs.client.Exec(
+		ctx,
+		`UPDATE password_reset
+		SET used_at = $2
+		WHERE hash = $1 AND used_at IS NULL AND expires_at > $2;`,
+		hash, now,
+	)
+	if err != nil {
+		slog.Error("error using password reset",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
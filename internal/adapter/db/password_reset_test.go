@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_passwordResetStorage(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"password_reset", "session", "user",
+	)
+	userStorage := NewUserStorage(client)
+	user, err := userStorage.Create(
+		context.Background(),
+		entity.User{Login: "login1", Password: "password1", Email: "user@example.com"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "user@example.com", user.Email)
+
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	resetStorage := NewPasswordResetStorage(client)
+
+	require.NoError(t, resetStorage.Create(ctx, entity.PasswordReset{
+		Hash: "hash1", UserID: user.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	}))
+	// A new token replaces the previous one.
+	require.NoError(t, resetStorage.Create(ctx, entity.PasswordReset{
+		Hash: "hash2", UserID: user.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	}))
+
+	_, err = resetStorage.Get(ctx, "hash1", now)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	reset, err := resetStorage.Get(ctx, "hash2", now)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, reset.UserID)
+
+	_, err = resetStorage.Get(ctx, "hash2", now.Add(2*time.Hour))
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+	err = resetStorage.Use(ctx, "hash2", now.Add(2*time.Hour))
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	require.NoError(t, resetStorage.Use(ctx, "hash2", now))
+	err = resetStorage.Use(ctx, "hash2", now)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+	_, err = resetStorage.Get(ctx, "hash2", now)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+}
@@ -1,11 +1,11 @@
 package db
 
 import (
-	"bytes"
 	"context"
 	stdErrors "errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
 
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
@@ -29,6 +29,15 @@ func NewProductStorage(client postgresql.Client) *productStorage {
 	}
 }
 
+// AddOrUpdateProduct upserts a batch of products, creating their categories
+// as needed. A product from a source is matched by its source reference, any
+// other product by its name, ignoring case. The batch is copied into a staging
+// table and merged with a few set-based statements, so its size isn't bounded
+// by the number of query parameters. If a product repeats, the details of its
+// first occurrence and the category of its last one are kept, and so are the
+// stored attributes if it has none. A product whose name or SKU is taken by
+// another product is skipped and logged rather than taking it over or failing
+// the batch. Products and categories with blank names are skipped as well.
 func (ps *productStorage) AddOrUpdateProduct(ctx context.Context, products ...entity.AddOrUpdateProductDTO) error {
 	if len(products) == 0 {
 		slog.Error("products slice is emty")
@@ -45,148 +54,162 @@ func (ps *productStorage) AddOrUpdateProduct(ctx context.Context, products ...en
 	}
 	defer tx.Rollback(ctx)
 
-	productBuffer := bytes.Buffer{}
-	categoryBuffer := bytes.Buffer{}
-
-	productMap := make(map[string]entity.Product, len(products))
-	categoryMap := make(map[string]int64, 0)
-	for _, product := range products {
-		_, err := productBuffer.WriteString(fmt.Sprintf("('%s'),", product.ProductName))
-		if err != nil {
-			slog.Error("error writing string to buffer",
-				"error", err,
-			)
-			return errors.NewDomainError(errors.ErrDB, "")
-		}
-		productMap[product.ProductName] = entity.Product{
-			Name: product.ProductName,
-			Category: entity.Category{
-				Name: product.CategoryName,
-			},
-		}
-		if _, ok := categoryMap[product.CategoryName]; !ok {
-			categoryMap[product.CategoryName] = 0
-			_, err := categoryBuffer.WriteString(fmt.Sprintf("('%s'),", product.CategoryName))
-			if err != nil {
-				slog.Error("error writing string to buffer",
-					"error", err,
-				)
-				return errors.NewDomainError(errors.ErrDB, "")
-			}
-		}
-	}
-
-	productNames := strings.TrimSuffix(productBuffer.String(), ",")
-	categoryNames := strings.TrimSuffix(categoryBuffer.String(), ",")
-
-	query := fmt.Sprintf(`
-		INSERT INTO
-			product ("name")
-		VALUES %s
-		ON CONFLICT(name)
-		DO UPDATE SET
-			name=EXCLUDED.name
-		RETURNING
-			id, name;
-	`, productNames)
-
-	rows, err := tx.Query(
+	_, err = tx.Exec(
 		ctx,
-		query,
+		`CREATE TEMP TABLE product_staging (
+			"ord" integer NOT NULL,
+			"name" varchar(255) NOT NULL,
+			"category_name" varchar(255) NOT NULL,
+			"description" text NOT NULL,
+			"price" numeric(12,2) NOT NULL,
+			"discount_percentage" numeric(5,2) NOT NULL,
+			"rating" numeric(3,2) NOT NULL,
+			"stock" integer NOT NULL,
+			"brand" varchar(255) NOT NULL,
+			"sku" varchar(64),
+			"thumbnail" text NOT NULL,
+			"images" text[] NOT NULL,
+			"attributes" jsonb NOT NULL,
+			"source" varchar(64),
+			"external_id" varchar(255)
+		) ON COMMIT DROP;`,
 	)
 	if err != nil {
-		slog.Error("error inserting products",
+		slog.Error("error creating product staging table",
 			"error", err,
 		)
 		return errors.NewDomainError(errors.ErrDB, "")
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var id int64
-		var name string
-		err := rows.Scan(&id, &name)
-		if err != nil {
-			slog.Error("error scanning from row",
-				"error", err,
-			)
-			return errors.NewDomainError(errors.ErrDB, "")
-		}
-		p := productMap[name]
-		p.ID = id
-		productMap[name] = p
-	}
-	if err := rows.Err(); err != nil {
-		slog.Error("error scanning from row",
+	_, err = tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"product_staging"},
+		productStagingColumns,
+		pgx.CopyFromSlice(len(products), func(i int) ([]interface{}, error) {
+			p := products[i]
+			row := make([]interface{}, 0, len(productStagingColumns))
+			row = append(row, i, p.ProductName, p.CategoryName)
+			row = append(row, productDetailsArgs(p.ProductDetails)...)
+			return append(row, productSourceArgs(p.ProductSourceRef)...), nil
+		}),
+	)
+	if err != nil {
+		slog.Error("error copying products to staging table",
 			"error", err,
 		)
 		return errors.NewDomainError(errors.ErrDB, "")
 	}
 
-	query = fmt.Sprintf(`
-		INSERT INTO
-			category ("name")
-		VALUES %s
-		ON CONFLICT(name)
-		DO UPDATE SET
-			name=EXCLUDED.name
-		RETURNING
-			id, name;
-	`, categoryNames)
-
-	rows, err = tx.Query(
+	// Keep the first occurrence of every product, moved to the category of
+	// its last one.
+	_, err = tx.Exec(
 		ctx,
-		query,
+		`DELETE FROM product_staging WHERE btrim(name) = '';
+
+		UPDATE product_staging s SET category_name = l.category_name
+		FROM (
+			SELECT ord,
+				row_number() OVER (PARTITION BY `+stagingProductKey+` ORDER BY ord) AS n,
+				first_value(category_name) OVER (PARTITION BY `+stagingProductKey+` ORDER BY ord DESC) AS category_name
+			FROM product_staging
+		) l
+		WHERE s.ord = l.ord AND l.n = 1;
+
+		DELETE FROM product_staging
+		WHERE ord IN (
+			SELECT ord FROM (
+				SELECT ord, row_number() OVER (PARTITION BY `+stagingProductKey+` ORDER BY ord) AS n
+				FROM product_staging
+			) d
+			WHERE n > 1
+		);`,
 	)
 	if err != nil {
-		slog.Error("error inserting category",
+		slog.Error("error collapsing repeated products",
 			"error", err,
 		)
 		return errors.NewDomainError(errors.ErrDB, "")
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var id int64
-		var name string
-		err := rows.Scan(&id, &name)
-		if err != nil {
-			slog.Error("error scanning from row",
-				"error", err,
-			)
-			return errors.NewDomainError(errors.ErrDB, "")
-		}
-		categoryMap[name] = id
+	conflicts, err := skipStagedClashes(ctx, tx, "lower(%s.name)", "product_name_lower_key")
+	if err != nil {
+		slog.Error("error skipping name clashes",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
 	}
 
-	productCategoryBuffer := bytes.Buffer{}
+	skuConflicts, err := skipStagedClashes(ctx, tx, "%s.sku", "product_sku_key")
+	if err != nil {
+		slog.Error("error skipping SKU clashes",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	conflicts = append(conflicts, skuConflicts...)
 
-	for _, product := range productMap {
-		_, err := productCategoryBuffer.WriteString(
-			fmt.Sprintf("(%d,%d),", product.ID, categoryMap[product.Category.Name]),
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO
+			category ("name")
+		SELECT DISTINCT category_name
+		FROM product_staging
+		WHERE btrim(category_name) <> ''
+		ON CONFLICT(lower(name)) DO NOTHING;`,
+	)
+	if err != nil {
+		slog.Error("error inserting category",
+			"error", err,
 		)
-		if err != nil {
-			slog.Error("error writing to buffer",
-				"error", err,
-			)
-			return errors.NewDomainError(errors.ErrDB, "")
-		}
+		return errors.NewDomainError(errors.ErrDB, "")
 	}
 
-	productCategoryString := strings.TrimSuffix(productCategoryBuffer.String(), ",")
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO
+			product ("name", `+productDetailsColumns+`, "source", "external_id")
+		SELECT
+			"name", `+productDetailsColumns+`, "source", "external_id"
+		FROM product_staging
+		WHERE source IS NOT NULL
+		ORDER BY lower(name)
+		ON CONFLICT(source, external_id)
+		DO UPDATE SET `+productUpsertSet+`;
 
-	query = fmt.Sprintf(`
 		INSERT INTO
-			product_category ("product_id", "category_id")
-		VALUES %s
-		ON CONFLICT DO NOTHING;
-	`, productCategoryString)
-
-	slog.Debug(query)
+			product ("name", `+productDetailsColumns+`)
+		SELECT
+			"name", `+productDetailsColumns+`
+		FROM product_staging
+		WHERE source IS NULL
+		ORDER BY lower(name)
+		ON CONFLICT(lower(name))
+		DO UPDATE SET `+productUpsertSet+`;`,
+	)
+	if err != nil {
+		slog.Error("error inserting products",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
 
 	_, err = tx.Exec(
 		ctx,
-		query,
+		`INSERT INTO
+			product_category ("product_id", "category_id")
+		SELECT p.id, c.id
+		FROM (
+			SELECT p.id, s.category_name
+			FROM product_staging s
+			JOIN product p ON p.source = s.source AND p.external_id = s.external_id
+			UNION ALL
+			SELECT p.id, s.category_name
+			FROM product_staging s
+			JOIN product p ON lower(p.name) = lower(s.name) AND p.source IS NULL
+			WHERE s.source IS NULL
+		) p
+		JOIN category c ON lower(c.name) = lower(p.category_name)
+		ON CONFLICT DO NOTHING;`,
 	)
 	if err != nil {
 		slog.Error("error inserting into product_category",
@@ -194,7 +217,6 @@ func (ps *productStorage) AddOrUpdateProduct(ctx context.Context, products ...en
 		)
 		return errors.NewDomainError(errors.ErrDB, "")
 	}
-	defer rows.Close()
 
 	err = tx.Commit(ctx)
 	if err != nil {
@@ -204,10 +226,55 @@ func (ps *productStorage) AddOrUpdateProduct(ctx context.Context, products ...en
 		return errors.NewDomainError(errors.ErrDB, "")
 	}
 
+	if len(conflicts) > 0 {
+		slog.Warn("skipped products that clash with other products",
+			"conflicts", conflicts,
+		)
+	}
+
 	return nil
 
 }
 
+// skipStagedClashes removes from product_staging the products whose key,
+// formatted with a table alias, is taken by an earlier staged product or by a
+// stored product that isn't the same one, and reports them as conflicts on
+// constraint. Products with a NULL key never clash.
+func skipStagedClashes(ctx context.Context, tx pgx.Tx, key, constraint string) ([]entity.ProductConflict, error) {
+	rows, err := tx.Query(
+		ctx,
+		fmt.Sprintf(
+			`DELETE FROM product_staging
+			WHERE ord IN (
+				SELECT s.ord FROM (
+					SELECT *, row_number() OVER (PARTITION BY %[1]s ORDER BY ord) AS n
+					FROM product_staging s
+				) s
+				WHERE %[1]s IS NOT NULL AND (s.n > 1 OR EXISTS (
+					SELECT 1 FROM product p
+					WHERE %[2]s = %[1]s AND NOT (
+						CASE WHEN s.source IS NULL
+						THEN p.source IS NULL AND lower(p.name) = lower(s.name)
+						ELSE p.source IS NOT DISTINCT FROM s.source AND p.external_id IS NOT DISTINCT FROM s.external_id
+						END
+					)
+				))
+			)
+			RETURNING COALESCE(external_id, ''), name;`,
+			fmt.Sprintf(key, "s"), fmt.Sprintf(key, "p"),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.ProductConflict, error) {
+		c := entity.ProductConflict{Reason: fmt.Sprintf("violates %s", constraint)}
+		err := row.Scan(&c.ExternalID, &c.Name)
+		return c, err
+	})
+}
+
 func (ps *productStorage) Add(ctx context.Context, product entity.AddProductDTO) error {
 	tx, err := ps.client.Begin(ctx)
 	if err != nil {
@@ -231,13 +298,16 @@ func (ps *productStorage) Add(ctx context.Context, product entity.AddProductDTO)
 
 	row := tx.QueryRow(
 		ctx,
-		`INSERT INTO product
-			("name")
-		VALUES
-			($1)
-		ON CONFLICT DO NOTHING
-		RETURNING id;`,
-		product.ProductName,
+		fmt.Sprintf(
+			`INSERT INTO product
+				("name", %s)
+			VALUES
+				%s
+			ON CONFLICT DO NOTHING
+			RETURNING id;`,
+			productDetailsColumns, placeholders(1, productColumnsCount),
+		),
+		append([]interface{}{product.ProductName}, productDetailsArgs(product.ProductDetails)...)...,
 	)
 	var id int64
 	err = row.Scan(&id)
@@ -278,50 +348,138 @@ func (ps *productStorage) Add(ctx context.Context, product entity.AddProductDTO)
 
 }
 
-func (ps *productStorage) GetByCategory(ctx context.Context, categoryID int64) ([]entity.ProductCategoryListItem, error) {
+func (ps *productStorage) GetByID(ctx context.Context, ID int64) (entity.ProductView, error) {
 	tx, err := ps.client.Begin(ctx)
 	if err != nil {
 		slog.Error("error beginnig transaction",
 			"error", err,
 		)
-		return nil, errors.NewDomainError(errors.ErrDB, "")
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
 	}
 	defer tx.Rollback(ctx)
 
-	exists, err := ps.categoryExists(ctx, categoryID, tx)
+	var p entity.ProductView
+	row := tx.QueryRow(
+		ctx,
+		`SELECT id, name, description, price, discount_percentage, rating, stock,
+			brand, COALESCE(sku, ''), thumbnail, images, attributes,
+			COALESCE(source, ''), COALESCE(external_id, '')
+		FROM product
+		WHERE id = $1 AND deleted_at IS NULL;`,
+		ID,
+	)
+	err = row.Scan(
+		&p.ID, &p.Name, &p.Description, &p.Price, &p.DiscountPercentage, &p.Rating, &p.Stock,
+		&p.Brand, &p.SKU, &p.Thumbnail, &p.Images, &p.Attributes,
+		&p.Source, &p.ExternalID,
+	)
 	if err != nil {
-		slog.Error("error chekcing if category exists",
+		if stdErrors.Is(err, pgx.ErrNoRows) {
+			return entity.ProductView{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error selecting from product table",
 			"error", err,
 		)
-		return nil, errors.NewDomainError(errors.ErrDB, "")
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
 	}
-	if !exists {
-		return nil, errors.NewDomainError(errors.ErrCategoryNotFound, "")
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT c.id, c.name, c.parent_id
+		FROM category c
+		JOIN product_category pc ON pc.category_id = c.id
+		WHERE pc.product_id = $1
+		ORDER BY c.id;`,
+		ID,
+	)
+	if err != nil {
+		slog.Error("error selecting product categories",
+			"error", err,
+		)
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
 	}
 
-	productIDs, err := ps.getProductIDsByCategory(ctx, categoryID, tx)
+	p.Categories, err = pgx.CollectRows[entity.Category](rows, scanCategory)
 	if err != nil {
-		slog.Error("error product id by category",
+		slog.Error("error scanning rows",
 			"error", err,
 		)
-		return nil, errors.NewDomainError(errors.ErrDB, "")
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
 	}
 
-	query := fmt.Sprintf(
-		`SELECT * FROM product
-		WHERE id IN (%s);`,
-		strings.Join(productIDs, ","),
+	err = tx.Commit(ctx)
+	if err != nil {
+		slog.Error("error commiting transaction",
+			"error", err,
+		)
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return p, nil
+
+}
+
+func (ps *productStorage) GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error) {
+	tx, err := ps.client.Begin(ctx)
+	if err != nil {
+		slog.Error("error beginnig transaction",
+			"error", err,
+		)
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback(ctx)
+
+	exists, err := ps.categoryExists(ctx, query.CategoryID, tx)
+	if err != nil {
+		slog.Error("error chekcing if category exists",
+			"error", err,
+		)
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	if !exists {
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrCategoryNotFound, "")
+	}
+
+	keyset, orderBy, args := productKeyset(query, 4)
+	args = append([]interface{}{query.CategoryID, query.NamePrefix, query.IncludeDescendants}, args...)
+	args = append(args, query.Limit+1)
+
+	// The recursive part of the CTE only runs when descendants are requested,
+	// EXISTS keeps products that sit in several subcategories from repeating.
+	sql := fmt.Sprintf(
+		`WITH RECURSIVE tree AS (
+			SELECT id FROM category
+			WHERE id = $1
+			UNION ALL
+			SELECT c.id FROM category c
+			JOIN tree ON c.parent_id = tree.id
+			WHERE $3::boolean
+		)
+		SELECT p.id, p.name
+		FROM product p
+		WHERE EXISTS (
+				SELECT 1 FROM product_category pc
+				JOIN tree ON pc.category_id = tree.id
+				WHERE pc.product_id = p.id
+			)
+			AND starts_with(lower(p.name), lower($2))
+			AND p.deleted_at IS NULL
+			AND %s
+		ORDER BY %s
+		LIMIT $%d;`,
+		keyset, orderBy, len(args),
 	)
 
 	rows, err := tx.Query(
 		ctx,
-		query,
+		sql,
+		args...,
 	)
 	if err != nil {
 		slog.Error("error selecting from product table",
 			"error", err,
 		)
-		return nil, errors.NewDomainError(errors.ErrDB, "")
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
 	}
 
 	list, err := pgx.CollectRows[entity.ProductCategoryListItem](
@@ -335,7 +493,7 @@ func (ps *productStorage) GetByCategory(ctx context.Context, categoryID int64) (
 		slog.Error("error scanning rows",
 			"error", err,
 		)
-		return nil, errors.NewDomainError(errors.ErrDB, "")
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
 	}
 
 	err = tx.Commit(ctx)
@@ -343,10 +501,21 @@ func (ps *productStorage) GetByCategory(ctx context.Context, categoryID int64) (
 		slog.Error("error commiting transaction",
 			"error", err,
 		)
-		return nil, errors.NewDomainError(errors.ErrDB, "")
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	page := entity.ProductPage{Products: list}
+	if len(list) > query.Limit {
+		page.Products = list[:query.Limit]
+		last := page.Products[query.Limit-1]
+		page.NextCursor = entity.ProductCursor{
+			Sort: query.Sort,
+			ID:   last.ID,
+			Name: last.Name,
+		}.Encode()
 	}
 
-	return list, nil
+	return page, nil
 
 }
 
@@ -378,6 +547,41 @@ func (ps *productStorage) UpdateName(ctx context.Context, product entity.UpdateP
 	return nil
 }
 
+func (ps *productStorage) UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error {
+	c, err := ps.client.Exec(
+		ctx,
+		`UPDATE product
+		SET
+			description = $2,
+			price = $3,
+			discount_percentage = $4,
+			rating = $5,
+			stock = $6,
+			brand = $7,
+			sku = $8,
+			thumbnail = $9,
+			images = $10,
+			attributes = $11
+		WHERE id = $1;`,
+		append([]interface{}{product.ProductID}, productDetailsArgs(product.ProductDetails)...)...,
+	)
+	if err != nil {
+		slog.Error("error updating product details",
+			"error", err,
+		)
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		slog.Error("no rows affected, product id not found")
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return nil
+}
+
 func (ps *productStorage) UpdateCategory(ctx context.Context, product entity.UpdateProductCategoryDTO) error {
 	tx, err := ps.client.Begin(ctx)
 	if err != nil {
@@ -449,35 +653,6 @@ func (ps *productStorage) Delete(ctx context.Context, ID int64) error {
 	return nil
 }
 
-func (ps *productStorage) getProductIDsByCategory(ctx context.Context, categoryID int64, tx pgx.Tx) ([]string, error) {
-	rows, err := tx.Query(
-		ctx,
-		`SELECT
-			product_id
-		FROM
-			product_category
-		WHERE
-			category_id = $1;`,
-		categoryID,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	productIDs, err := pgx.CollectRows[string](rows, func(row pgx.CollectableRow) (string, error) {
-		var id string
-		err := row.Scan(&id)
-		return id, err
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return productIDs, nil
-
-}
-
 func (ps *productStorage) categoryExists(ctx context.Context, categoryID int64, tx pgx.Tx) (bool, error) {
 	row := tx.QueryRow(
 		ctx,
@@ -497,3 +672,106 @@ func (ps *productStorage) categoryExists(ctx context.Context, categoryID int64,
 	}
 	return exists, nil
 }
+
+// productKeyset returns the keyset condition and the ORDER BY clause for
+// query, numbering its placeholders from firstArg. Only constant SQL
+// fragments are interpolated, cursor values are passed as arguments.
+func productKeyset(query entity.ProductQuery, firstArg int) (string, string, []interface{}) {
+	switch query.Sort {
+	case entity.SortByName, entity.SortByNameDesc:
+		op, dir := ">", "ASC"
+		if query.Sort == entity.SortByNameDesc {
+			op, dir = "<", "DESC"
+		}
+		orderBy := fmt.Sprintf("p.name %s, p.id %s", dir, dir)
+		if query.After == nil {
+			return "TRUE", orderBy, nil
+		}
+		return fmt.Sprintf("(p.name, p.id) %s ($%d, $%d)", op, firstArg, firstArg+1),
+			orderBy,
+			[]interface{}{query.After.Name, query.After.ID}
+	default:
+		if query.After == nil {
+			return "TRUE", "p.id ASC", nil
+		}
+		return fmt.Sprintf("p.id > $%d", firstArg), "p.id ASC", []interface{}{query.After.ID}
+	}
+}
+
+const (
+	productDetailsColumns = `"description", "price", "discount_percentage", "rating", "stock",
+		"brand", "sku", "thumbnail", "images", "attributes"`
+	// productColumnsCount is the name column plus the details columns.
+	productColumnsCount = 11
+)
+
+// stagingProductKey identifies a staged product: by its source reference
+// if it has one, by its name otherwise.
+const stagingProductKey = `source, external_id, CASE WHEN source IS NULL THEN lower(name) END`
+
+// productUpsertSet updates a product from the sync batch and restores it if
+// it was deleted. Attributes the batch doesn't have are kept, they may have
+// been set through the API.
+const productUpsertSet = `name=EXCLUDED.name,
+	deleted_at=NULL,
+	description=EXCLUDED.description,
+	price=EXCLUDED.price,
+	discount_percentage=EXCLUDED.discount_percentage,
+	rating=EXCLUDED.rating,
+	stock=EXCLUDED.stock,
+	brand=EXCLUDED.brand,
+	sku=EXCLUDED.sku,
+	thumbnail=EXCLUDED.thumbnail,
+	images=EXCLUDED.images,
+	attributes=COALESCE(NULLIF(EXCLUDED.attributes, '{}'::jsonb), product.attributes)`
+
+// productStagingColumns are the columns AddOrUpdateProduct copies a batch
+// into: its position in the batch, the name and category, the details and the
+// source reference.
+var productStagingColumns = []string{
+	"ord", "name", "category_name",
+	"description", "price", "discount_percentage", "rating", "stock",
+	"brand", "sku", "thumbnail", "images", "attributes",
+	"source", "external_id",
+}
+
+// productDetailsArgs returns query arguments in productDetailsColumns order.
+// An empty SKU is stored as NULL so that it doesn't collide with other
+// products without one.
+func productDetailsArgs(d entity.ProductDetails) []interface{} {
+	var sku *string
+	if d.SKU != "" {
+		sku = &d.SKU
+	}
+	images := d.Images
+	if images == nil {
+		images = []string{}
+	}
+	attributes := d.Attributes
+	if attributes == nil {
+		attributes = entity.ProductAttributes{}
+	}
+
+	return []interface{}{
+		d.Description, d.Price, d.DiscountPercentage, d.Rating, d.Stock,
+		d.Brand, sku, d.Thumbnail, images, attributes,
+	}
+}
+
+// productSourceArgs returns the "source" and "external_id" query arguments,
+// NULL for the products not imported from an upstream source.
+func productSourceArgs(ref entity.ProductSourceRef) []interface{} {
+	if ref.Source == "" {
+		return []interface{}{nil, nil}
+	}
+	return []interface{}{ref.Source, ref.ExternalID}
+}
+
+// placeholders returns a "($n, $n+1, ...)" tuple of count placeholders.
+func placeholders(first, count int) string {
+	p := make([]string, count)
+	for i := range p {
+		p[i] = "$" + strconv.Itoa(first+i)
+	}
+	return "(" + strings.Join(p, ", ") + ")"
+}
@@ -0,0 +1,308 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/jackc/pgx/v5"
+)
+
+var _ service.ProductFacetCounter = new(productStorage)
+
+// facet names used to leave a facet's own filter out of its counts
+const (
+	facetCategory = "category"
+	facetBrand    = "brand"
+	facetPrice    = "price"
+	facetAttr     = "attribute"
+)
+
+// facetArgs collects query arguments and returns their placeholders.
+type facetArgs []interface{}
+
+func (a *facetArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// facetConditions returns the WHERE clause for filter over product p,
+// omitting the filter of the facet named except.
+func facetConditions(filter entity.FacetFilter, except string, args *facetArgs) string {
+	conds := []string{"p.deleted_at IS NULL"}
+
+	if filter.Text != "" {
+		t := args.add(filter.Text)
+		conds = append(conds, fmt.Sprintf(
+			"(p.search_vector @@ websearch_to_tsquery('english', %s) OR p.name %% %s::text)", t, t,
+		))
+	}
+	if len(filter.CategoryIDs) > 0 && except != facetCategory {
+		conds = append(conds, fmt.Sprintf(
+			`EXISTS (
+				SELECT 1 FROM product_category fpc
+				WHERE fpc.product_id = p.id AND fpc.category_id = ANY(%s)
+			)`, args.add(filter.CategoryIDs),
+		))
+	}
+	if len(filter.Brands) > 0 && except != facetBrand {
+		conds = append(conds, fmt.Sprintf("p.brand = ANY(%s)", args.add(filter.Brands)))
+	}
+	if len(filter.PriceBuckets) > 0 && except != facetPrice {
+		conds = append(conds, fmt.Sprintf(
+			"%s = ANY(%s::int[])", priceBucketSQL(args), args.add(filter.PriceBuckets),
+		))
+	}
+	if except != facetAttr {
+		for _, name := range sortedKeys(filter.Attributes) {
+			conds = append(conds, fmt.Sprintf(
+				"p.attributes->>%s::text = ANY(%s)", args.add(name), args.add(filter.Attributes[name]),
+			))
+		}
+	}
+
+	return strings.Join(conds, "\n\t\t\tAND ")
+}
+
+func priceBucketSQL(args *facetArgs) string {
+	return fmt.Sprintf("width_bucket(p.price, %s::numeric[])", args.add(entity.PriceBucketBounds))
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Facets returns a page of products matching query together with facet
+// counts computed by GROUP BY over product_category, brand, price bucket and
+// the attribute pairs.
+func (ps *productStorage) Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error) {
+	tx, err := ps.client.Begin(ctx)
+	if err != nil {
+		slog.Error("error beginnig transaction",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback(ctx)
+
+	page := entity.FacetPage{
+		Facets: entity.Facets{Attributes: make(map[string][]entity.FacetCount)},
+	}
+
+	page.Products, page.NextCursor, err = ps.facetProducts(ctx, tx, query)
+	if err != nil {
+		slog.Error("error selecting faceted products",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	var args facetArgs
+	err = tx.QueryRow(
+		ctx,
+		`SELECT count(*) FROM product p
+		WHERE `+facetConditions(query.FacetFilter, "", &args)+`;`,
+		args...,
+	).Scan(&page.Total)
+	if err != nil {
+		slog.Error("error counting faceted products",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	args = nil
+	rows, err := tx.Query(
+		ctx,
+		`SELECT c.id, c.name, count(*)
+		FROM product p
+		JOIN product_category pc ON pc.product_id = p.id
+		JOIN category c ON c.id = pc.category_id
+		WHERE `+facetConditions(query.FacetFilter, facetCategory, &args)+`
+		GROUP BY c.id, c.name
+		ORDER BY count(*) DESC, c.id;`,
+		args...,
+	)
+	if err == nil {
+		page.Facets.Categories, err = pgx.CollectRows[entity.CategoryFacetCount](
+			rows, func(row pgx.CollectableRow) (entity.CategoryFacetCount, error) {
+				var f entity.CategoryFacetCount
+				err := row.Scan(&f.ID, &f.Name, &f.Count)
+				return f, err
+			},
+		)
+	}
+	if err != nil {
+		slog.Error("error counting category facet",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	args = nil
+	rows, err = tx.Query(
+		ctx,
+		`SELECT p.brand, count(*)
+		FROM product p
+		WHERE p.brand <> ''
+			AND `+facetConditions(query.FacetFilter, facetBrand, &args)+`
+		GROUP BY p.brand
+		ORDER BY count(*) DESC, p.brand;`,
+		args...,
+	)
+	if err == nil {
+		page.Facets.Brands, err = pgx.CollectRows[entity.FacetCount](rows, scanFacetCount)
+	}
+	if err != nil {
+		slog.Error("error counting brand facet",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	args = nil
+	bucket := priceBucketSQL(&args)
+	rows, err = tx.Query(
+		ctx,
+		`SELECT `+bucket+`, count(*)
+		FROM product p
+		WHERE `+facetConditions(query.FacetFilter, facetPrice, &args)+`
+		GROUP BY 1
+		ORDER BY 1;`,
+		args...,
+	)
+	if err == nil {
+		page.Facets.Prices, err = pgx.CollectRows[entity.PriceFacetCount](
+			rows, func(row pgx.CollectableRow) (entity.PriceFacetCount, error) {
+				var bucket int
+				var count int64
+				err := row.Scan(&bucket, &count)
+				return entity.NewPriceFacetCount(bucket, count), err
+			},
+		)
+	}
+	if err != nil {
+		slog.Error("error counting price facet",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	// Each attribute is counted with the filters on all other attributes, so
+	// the filter of the row's own key is switched off per row.
+	args = nil
+	conds := []string{facetConditions(query.FacetFilter, facetAttr, &args)}
+	for _, name := range sortedKeys(query.FacetFilter.Attributes) {
+		n := args.add(name)
+		conds = append(conds, fmt.Sprintf(
+			"(kv.key = %s::text OR p.attributes->>%s::text = ANY(%s))", n, n, args.add(query.FacetFilter.Attributes[name]),
+		))
+	}
+	rows, err = tx.Query(
+		ctx,
+		`SELECT kv.key, kv.value, count(*)
+		FROM product p, jsonb_each_text(p.attributes) kv
+		WHERE `+strings.Join(conds, "\n\t\t\tAND ")+`
+		GROUP BY kv.key, kv.value
+		ORDER BY kv.key, count(*) DESC, kv.value;`,
+		args...,
+	)
+	if err != nil {
+		slog.Error("error counting attribute facets",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var f entity.FacetCount
+		err := rows.Scan(&name, &f.Value, &f.Count)
+		if err != nil {
+			slog.Error("error scanning from row",
+				"error", err,
+			)
+			return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+		}
+		page.Facets.Attributes[name] = append(page.Facets.Attributes[name], f)
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("error scanning from row",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		slog.Error("error commiting transaction",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return page, nil
+}
+
+func (ps *productStorage) facetProducts(ctx context.Context, tx pgx.Tx, query entity.FacetQuery) ([]entity.ProductCategoryListItem, string, error) {
+	var args facetArgs
+	conds := facetConditions(query.FacetFilter, "", &args)
+
+	keyset, orderBy, keysetArgs := productKeyset(
+		entity.ProductQuery{Sort: query.Sort, After: query.After}, len(args)+1,
+	)
+	args = append(args, keysetArgs...)
+	limit := args.add(query.Limit + 1)
+
+	rows, err := tx.Query(
+		ctx,
+		fmt.Sprintf(
+			`SELECT p.id, p.name FROM product p
+			WHERE %s
+				AND %s
+			ORDER BY %s
+			LIMIT %s;`,
+			conds, keyset, orderBy, limit,
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	list, err := pgx.CollectRows[entity.ProductCategoryListItem](
+		rows, func(row pgx.CollectableRow) (entity.ProductCategoryListItem, error) {
+			var product entity.ProductCategoryListItem
+			err := row.Scan(&product.ID, &product.Name)
+			return product, err
+		},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(list) <= query.Limit {
+		return list, "", nil
+	}
+	list = list[:query.Limit]
+	last := list[query.Limit-1]
+	return list, entity.ProductCursor{Sort: query.Sort, ID: last.ID, Name: last.Name}.Encode(), nil
+}
+
+func scanFacetCount(row pgx.CollectableRow) (entity.FacetCount, error) {
+	var f entity.FacetCount
+	err := row.Scan(&f.Value, &f.Count)
+	return f, err
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_productStorage_Facets(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+
+	_, err := client.Exec(
+		context.Background(),
+		`INSERT INTO product
+			("id", "name", "brand", "price", "attributes")
+		VALUES
+			(1,'iphone 15','Apple',999,'{"color": "black"}'),
+			(2,'iphone 14','Apple',699,'{"color": "white"}'),
+			(3,'galaxy s24','Samsung',899,'{"color": "black"}'),
+			(4,'macbook air','Apple',1299,'{"color": "silver"}');
+		INSERT INTO category
+			("id", "name")
+		VALUES
+			(1,'phone'),
+			(2,'laptop');
+		INSERT INTO product_category
+			("product_id", "category_id")
+		VALUES
+			(1,1),
+			(2,1),
+			(3,1),
+			(4,2);`,
+	)
+	require.NoError(t, err)
+	storage := NewProductStorage(client)
+
+	t.Run("no filters", func(t *testing.T) {
+		page, err := storage.Facets(context.Background(), entity.FacetQuery{Limit: 10, Sort: entity.SortByID})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(4), page.Total)
+		assert.Len(t, page.Products, 4)
+		assert.Equal(t, []entity.CategoryFacetCount{
+			{ID: 1, Name: "phone", Count: 3},
+			{ID: 2, Name: "laptop", Count: 1},
+		}, page.Facets.Categories)
+		assert.Equal(t, []entity.FacetCount{
+			{Value: "Apple", Count: 3},
+			{Value: "Samsung", Count: 1},
+		}, page.Facets.Brands)
+		assert.Equal(t, []entity.PriceFacetCount{
+			entity.NewPriceFacetCount(3, 3),
+			entity.NewPriceFacetCount(4, 1),
+		}, page.Facets.Prices)
+		assert.Equal(t, []entity.FacetCount{
+			{Value: "black", Count: 2},
+			{Value: "silver", Count: 1},
+			{Value: "white", Count: 1},
+		}, page.Facets.Attributes["color"])
+	})
+
+	t.Run("selected facets keep alternatives", func(t *testing.T) {
+		page, err := storage.Facets(context.Background(), entity.FacetQuery{
+			FacetFilter: entity.FacetFilter{
+				CategoryIDs: []int64{1},
+				Brands:      []string{"Apple"},
+				Attributes:  map[string][]string{"color": {"black"}},
+			},
+			Limit: 10,
+			Sort:  entity.SortByID,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), page.Total)
+		assert.Equal(t, []entity.ProductCategoryListItem{{ID: 1, Name: "iphone 15"}}, page.Products)
+		assert.Equal(t, []entity.FacetCount{
+			{Value: "Apple", Count: 1},
+			{Value: "Samsung", Count: 1},
+		}, page.Facets.Brands)
+		assert.Equal(t, []entity.FacetCount{
+			{Value: "black", Count: 1},
+			{Value: "white", Count: 1},
+		}, page.Facets.Attributes["color"])
+		assert.Equal(t, []entity.CategoryFacetCount{
+			{ID: 1, Name: "phone", Count: 1},
+		}, page.Facets.Categories)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		query := entity.FacetQuery{
+			FacetFilter: entity.FacetFilter{Brands: []string{"Apple"}},
+			Limit:       2,
+			Sort:        entity.SortByName,
+		}
+		page, err := storage.Facets(context.Background(), query)
+		require.NoError(t, err)
+		require.NotEmpty(t, page.NextCursor)
+
+		cursor, err := entity.DecodeProductCursor(page.NextCursor)
+		require.NoError(t, err)
+		query.After = &cursor
+
+		page, err = storage.Facets(context.Background(), query)
+		require.NoError(t, err)
+		assert.Equal(t, []entity.ProductCategoryListItem{{ID: 4, Name: "macbook air"}}, page.Products)
+		assert.Empty(t, page.NextCursor)
+	})
+}
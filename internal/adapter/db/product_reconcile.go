@@ -0,0 +1,277 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (ps *productStorage) GetSourceProducts(ctx context.Context, source string) ([]entity.SourceProduct, error) {
+	rows, err := ps.client.Query(
+		ctx,
+		`SELECT p.id, p.external_id, p.name, p.deleted_at IS NOT NULL,
+			COALESCE(array_agg(c.name ORDER BY c.name) FILTER (WHERE c.id IS NOT NULL), '{}')
+		FROM product p
+		LEFT JOIN product_category pc ON pc.product_id = p.id
+		LEFT JOIN category c ON c.id = pc.category_id
+		WHERE p.source = $1
+		GROUP BY p.id
+		ORDER BY p.id;`,
+		source,
+	)
+	if err != nil {
+		slog.Error("error selecting source products",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	products, err := pgx.CollectRows[entity.SourceProduct](
+		rows, func(row pgx.CollectableRow) (entity.SourceProduct, error) {
+			var p entity.SourceProduct
+			err := row.Scan(&p.ID, &p.ExternalID, &p.Name, &p.Deleted, &p.Categories)
+			return p, err
+		},
+	)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return products, nil
+}
+
+// ApplyReconcilePlan writes a reconciliation plan in one transaction. Every
+// product is written under its own savepoint: a product whose name or SKU is
+// taken by another product is reported as a conflict instead of failing the run.
+// The category of a source product replaces all the categories it had, and a
+// product without attributes keeps its stored ones.
+func (ps *productStorage) ApplyReconcilePlan(ctx context.Context, plan entity.ReconcilePlan) ([]entity.ProductConflict, error) {
+	tx, err := ps.client.Begin(ctx)
+	if err != nil {
+		slog.Error("error beginnig transaction",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback(ctx)
+
+	var conflicts []entity.ProductConflict
+	categoryIDs := make(map[string]int64)
+	for _, p := range plan.Upserts {
+		categoryID, err := reconcileProduct(ctx, tx, p, categoryIDs)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if stdErrors.As(err, &pgErr) &&
+				(pgErr.Code == pgerrcode.UniqueViolation || pgErr.Code == pgerrcode.CheckViolation) {
+				conflicts = append(conflicts, entity.ProductConflict{
+					ExternalID: p.ExternalID,
+					Name:       p.ProductName,
+					Reason:     fmt.Sprintf("violates %s", pgErr.ConstraintName),
+				})
+				continue
+			}
+			slog.Error("error reconciling product",
+				"error", err,
+				"external_id", p.ExternalID,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+		categoryIDs[p.CategoryName] = categoryID
+	}
+
+	if len(plan.Deletes) > 0 {
+		query := `UPDATE product SET deleted_at = now()
+			WHERE source = $1 AND external_id = ANY($2) AND deleted_at IS NULL;`
+		if plan.Mode == entity.DeleteModeDelete {
+			query = `DELETE FROM product
+				WHERE source = $1 AND external_id = ANY($2);`
+		}
+		_, err = tx.Exec(ctx, query, plan.Source, plan.Deletes)
+		if err != nil {
+			slog.Error("error deleting source products",
+				"error", err,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		slog.Error("error commiting transaction",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return conflicts, nil
+}
+
+// reconcileProduct upserts one product under a savepoint and returns the ID
+// of its category. categoryIDs caches the categories written by the run.
+func reconcileProduct(ctx context.Context, tx pgx.Tx, p entity.AddOrUpdateProductDTO, categoryIDs map[string]int64) (int64, error) {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer sp.Rollback(ctx)
+
+	var productID int64
+	err = sp.QueryRow(
+		ctx,
+		fmt.Sprintf(`
+			INSERT INTO
+				product ("name", %s, "source", "external_id")
+			VALUES %s
+			ON CONFLICT(source, external_id)
+			DO UPDATE SET
+				name=EXCLUDED.name,
+				description=EXCLUDED.description,
+				price=EXCLUDED.price,
+				discount_percentage=EXCLUDED.discount_percentage,
+				rating=EXCLUDED.rating,
+				stock=EXCLUDED.stock,
+				brand=EXCLUDED.brand,
+				sku=EXCLUDED.sku,
+				thumbnail=EXCLUDED.thumbnail,
+				images=EXCLUDED.images,
+				attributes=COALESCE(NULLIF(EXCLUDED.attributes, '{}'::jsonb), product.attributes),
+				deleted_at=NULL
+			RETURNING id;`,
+			productDetailsColumns, placeholders(1, productColumnsCount+2),
+		),
+		append(append([]interface{}{p.ProductName}, productDetailsArgs(p.ProductDetails)...),
+			productSourceArgs(p.ProductSourceRef)...)...,
+	).Scan(&productID)
+	if err != nil {
+		return 0, err
+	}
+
+	categoryID, ok := categoryIDs[p.CategoryName]
+	if !ok {
+		err = sp.QueryRow(
+			ctx,
+			`INSERT INTO category ("name") VALUES ($1)
+			ON CONFLICT(lower(name)) DO UPDATE SET name=category.name
+			RETURNING id;`,
+			p.CategoryName,
+		).Scan(&categoryID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = sp.Exec(
+		ctx,
+		`DELETE FROM product_category
+		WHERE product_id = $1 AND category_id <> $2;`,
+		productID, categoryID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = sp.Exec(
+		ctx,
+		`INSERT INTO product_category ("product_id", "category_id")
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING;`,
+		productID, categoryID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return categoryID, sp.Commit(ctx)
+}
+
+var _ service.ReconcileReportStorage = new(reconcileReportStorage)
+
+type reconcileReportStorage struct {
+	client postgresql.Client
+}
+
+func NewReconcileReportStorage(client postgresql.Client) *reconcileReportStorage {
+	return &reconcileReportStorage{
+		client: client,
+	}
+}
+
+func (s *reconcileReportStorage) Save(ctx context.Context, report entity.ReconcileReport) (entity.ReconcileReport, error) {
+	report.ID = 0
+	b, err := json.Marshal(report)
+	if err != nil {
+		slog.Error("error marshalling reconcile report",
+			"error", err,
+		)
+		return entity.ReconcileReport{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = s.client.QueryRow(
+		ctx,
+		`INSERT INTO reconcile_report
+			(source, started_at, finished_at, report)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING id;`,
+		report.Source, report.StartedAt, report.FinishedAt, b,
+	).Scan(&report.ID)
+	if err != nil {
+		slog.Error("error saving reconcile report",
+			"error", err,
+		)
+		return entity.ReconcileReport{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return report, nil
+}
+
+// List returns the latest reports first, of all sources if source is empty.
+func (s *reconcileReportStorage) List(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error) {
+	rows, err := s.client.Query(
+		ctx,
+		`SELECT id, report FROM reconcile_report
+		WHERE $1::text = '' OR source = $1
+		ORDER BY id DESC
+		LIMIT $2;`,
+		source, limit,
+	)
+	if err != nil {
+		slog.Error("error selecting reconcile reports",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	reports, err := pgx.CollectRows[entity.ReconcileReport](
+		rows, func(row pgx.CollectableRow) (entity.ReconcileReport, error) {
+			var (
+				id     int64
+				report entity.ReconcileReport
+			)
+			err := row.Scan(&id, &report)
+			report.ID = id
+			return report, err
+		},
+	)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_productStorage_ApplyReconcilePlan(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+	storage := NewProductStorage(client)
+
+	product := func(id, name, category string) entity.AddOrUpdateProductDTO {
+		return entity.AddOrUpdateProductDTO{
+			ProductName:      name,
+			CategoryName:     category,
+			ProductSourceRef: entity.ProductSourceRef{Source: "dummyjson", ExternalID: id},
+		}
+	}
+
+	err := storage.AddOrUpdateProduct(
+		context.Background(),
+		product("1", "redmi", "phones"),
+		product("2", "iphone", "phones"),
+		product("3", "nokia", "phones"),
+		entity.AddOrUpdateProductDTO{ProductName: "manual", CategoryName: "phones"},
+	)
+	require.NoError(t, err)
+
+	current, err := storage.GetSourceProducts(context.Background(), "dummyjson")
+	require.NoError(t, err)
+	require.Len(t, current, 3)
+	require.Equal(t, []string{"phones"}, current[0].Categories)
+
+	tests := []struct {
+		name          string
+		plan          entity.ReconcilePlan
+		wantConflicts []entity.ProductConflict
+		want          []entity.SourceProduct
+	}{
+		{
+			name: "rename, move, tombstone and conflict",
+			plan: entity.ReconcilePlan{
+				Source: "dummyjson",
+				Mode:   entity.DeleteModeTombstone,
+				Upserts: []entity.AddOrUpdateProductDTO{
+					product("1", "redmi note", "phones"),
+					product("2", "iphone", "tablets"),
+					product("4", "manual", "phones"),
+				},
+				Deletes: []string{"3"},
+			},
+			wantConflicts: []entity.ProductConflict{
+				{ExternalID: "4", Name: "manual", Reason: "violates product_name_key"},
+			},
+			want: []entity.SourceProduct{
+				{ExternalID: "1", Name: "redmi note", Categories: []string{"phones"}},
+				{ExternalID: "2", Name: "iphone", Categories: []string{"tablets"}},
+				{ExternalID: "3", Name: "nokia", Categories: []string{"phones"}, Deleted: true},
+			},
+		},
+		{
+			name: "restore and delete",
+			plan: entity.ReconcilePlan{
+				Source: "dummyjson",
+				Mode:   entity.DeleteModeDelete,
+				Upserts: []entity.AddOrUpdateProductDTO{
+					product("3", "nokia", "phones"),
+				},
+				Deletes: []string{"1", "2"},
+			},
+			want: []entity.SourceProduct{
+				{ExternalID: "3", Name: "nokia", Categories: []string{"phones"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts, err := storage.ApplyReconcilePlan(context.Background(), tt.plan)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantConflicts, conflicts)
+
+			got, err := storage.GetSourceProducts(context.Background(), "dummyjson")
+			require.NoError(t, err)
+			for i := range got {
+				got[i].ID = 0
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	var manual int
+	err = client.QueryRow(
+		context.Background(),
+		`SELECT count(*) FROM product WHERE name = 'manual' AND source IS NULL AND deleted_at IS NULL;`,
+	).Scan(&manual)
+	require.NoError(t, err)
+	require.Equal(t, 1, manual)
+}
+
+func Test_reconcileReportStorage(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(t, client, "reconcile_report")
+	storage := NewReconcileReportStorage(client)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	for _, source := range []string{"dummyjson", "warehouse", "dummyjson"} {
+		_, err := storage.Save(context.Background(), entity.ReconcileReport{
+			Source:     source,
+			Mode:       entity.DeleteModeTombstone,
+			StartedAt:  now,
+			FinishedAt: now,
+			Added:      []entity.ReconciledProduct{{ExternalID: "1", Name: "redmi"}},
+		})
+		require.NoError(t, err)
+	}
+
+	reports, err := storage.List(context.Background(), "dummyjson", 10)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Greater(t, reports[0].ID, reports[1].ID)
+	require.Equal(t, []entity.ReconciledProduct{{ExternalID: "1", Name: "redmi"}}, reports[0].Added)
+
+	reports, err = storage.List(context.Background(), "", 1)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, "dummyjson", reports[0].Source)
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	"github.com/jackc/pgx/v5"
+)
+
+var _ service.ProductSearcher = new(productSearcher)
+
+type productSearcher struct {
+	client postgresql.Client
+}
+
+func NewProductSearcher(client postgresql.Client) *productSearcher {
+	return &productSearcher{
+		client: client,
+	}
+}
+
+// Search matches products by full-text search over name and description and
+// by trigram similarity of the name, so that typos still find the product.
+// Rank adds both scores.
+func (ps *productSearcher) Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error) {
+	keyset, orderBy, args := searchKeyset(query, 5)
+	args = append([]interface{}{query.Text, query.CategoryID, query.IncludeDescendants, query.NamePrefix}, args...)
+	args = append(args, query.Limit+1)
+
+	sql := fmt.Sprintf(
+		`WITH RECURSIVE tree AS (
+			SELECT id FROM category
+			WHERE id = $2
+			UNION ALL
+			SELECT c.id FROM category c
+			JOIN tree ON c.parent_id = tree.id
+			WHERE $3::boolean
+		),
+		q AS (
+			SELECT websearch_to_tsquery('english', $1) AS tsq, $1::text AS raw
+		),
+		hits AS (
+			SELECT
+				p.id, p.name, p.description,
+				(ts_rank_cd(p.search_vector, q.tsq) + similarity(p.name, q.raw))::real AS rank
+			FROM product p, q
+			WHERE (p.search_vector @@ q.tsq OR p.name %% q.raw)
+				AND ($2 = 0 OR EXISTS (
+					SELECT 1 FROM product_category pc
+					JOIN tree ON pc.category_id = tree.id
+					WHERE pc.product_id = p.id
+				))
+				AND starts_with(lower(p.name), lower($4))
+				AND p.deleted_at IS NULL
+		)
+		SELECT
+			p.id, p.name, p.rank,
+			ts_headline('english', `+htmlEscapeSQL("p.name || ' ' || p.description")+`, q.tsq,
+				'StartSel=<b>, StopSel=</b>, MaxFragments=2, MinWords=3, MaxWords=15')
+		FROM (
+			SELECT * FROM hits p
+			WHERE %s
+			ORDER BY %s
+			LIMIT $%d
+		) p, q
+		ORDER BY %s;`,
+		keyset, orderBy, len(args), orderBy,
+	)
+
+	rows, err := ps.client.Query(
+		ctx,
+		sql,
+		args...,
+	)
+	if err != nil {
+		slog.Error("error searching products",
+			"error", err,
+		)
+		return entity.ProductSearchPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	hits, err := pgx.CollectRows[entity.ProductSearchHit](
+		rows, func(row pgx.CollectableRow) (entity.ProductSearchHit, error) {
+			var hit entity.ProductSearchHit
+			err := row.Scan(&hit.ID, &hit.Name, &hit.Rank, &hit.Highlight)
+			return hit, err
+		},
+	)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return entity.ProductSearchPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	page := entity.ProductSearchPage{Products: hits}
+	if len(hits) > query.Limit {
+		page.Products = hits[:query.Limit]
+		last := page.Products[query.Limit-1]
+		page.NextCursor = entity.ProductCursor{
+			Sort: query.Sort,
+			ID:   last.ID,
+			Name: last.Name,
+			Rank: last.Rank,
+		}.Encode()
+	}
+
+	return page, nil
+}
+
+// searchKeyset extends productKeyset with ordering by relevance, where the
+// rank descends and ID breaks ties.
+func searchKeyset(query entity.ProductSearchQuery, firstArg int) (string, string, []interface{}) {
+	if query.Sort != entity.SortByRelevance {
+		return productKeyset(query.ProductQuery, firstArg)
+	}
+
+	orderBy := "p.rank DESC, p.id ASC"
+	if query.After == nil {
+		return "TRUE", orderBy, nil
+	}
+	return fmt.Sprintf("(p.rank < $%d::real OR (p.rank = $%d::real AND p.id > $%d))", firstArg, firstArg, firstArg+1),
+		orderBy,
+		[]interface{}{query.After.Rank, query.After.ID}
+}
+
+// htmlEscapeSQL escapes the text of the SQL expression expr for HTML like
+// html.EscapeString does, so that the only markup of a headline is its <b></b>.
+func htmlEscapeSQL(expr string) string {
+	return `replace(replace(replace(replace(replace(` + expr +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+}
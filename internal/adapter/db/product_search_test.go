@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_productSearcher_Search(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+
+	_, err := client.Exec(
+		context.Background(),
+		`INSERT INTO product
+			("id", "name", "description")
+		VALUES
+			(1,'iphone 15','Apple smartphone with a titanium frame'),
+			(2,'redmi note','Budget smartphone'),
+			(3,'dyson v11','Cordless vacuum cleaner'),
+			(4,'macbook air','Laptop by Apple'),
+			(5,'usb charger','<img src=x onerror=alert(1)> fast charger');
+		INSERT INTO category
+			("id", "name")
+		VALUES
+			(1,'phone'),
+			(2,'laptop');
+		INSERT INTO product_category
+			("product_id", "category_id")
+		VALUES
+			(1,1),
+			(2,1),
+			(4,2);`,
+	)
+	require.NoError(t, err)
+	searcher := NewProductSearcher(client)
+
+	ids := func(hits []entity.ProductSearchHit) []int64 {
+		res := make([]int64, 0, len(hits))
+		for _, h := range hits {
+			res = append(res, h.ID)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name  string
+		query entity.ProductSearchQuery
+		want  []int64
+	}{
+		{
+			name: "full text over description",
+			query: entity.ProductSearchQuery{
+				Text:         "smartphone",
+				ProductQuery: entity.ProductQuery{Limit: 10, Sort: entity.SortByID},
+			},
+			want: []int64{1, 2},
+		},
+		{
+			name: "typo in name",
+			query: entity.ProductSearchQuery{
+				Text:         "iphnoe 15",
+				ProductQuery: entity.ProductQuery{Limit: 10, Sort: entity.SortByRelevance},
+			},
+			want: []int64{1},
+		},
+		{
+			name: "category scope",
+			query: entity.ProductSearchQuery{
+				Text:         "apple",
+				ProductQuery: entity.ProductQuery{CategoryID: 2, Limit: 10, Sort: entity.SortByRelevance},
+			},
+			want: []int64{4},
+		},
+		{
+			name: "nothing found",
+			query: entity.ProductSearchQuery{
+				Text:         "refrigerator",
+				ProductQuery: entity.ProductQuery{Limit: 10, Sort: entity.SortByRelevance},
+			},
+			want: []int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := searcher.Search(context.Background(), tt.query)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, tt.want, ids(page.Products))
+		})
+	}
+
+	t.Run("escaped highlight", func(t *testing.T) {
+		page, err := searcher.Search(context.Background(), entity.ProductSearchQuery{
+			Text:         "charger",
+			ProductQuery: entity.ProductQuery{Limit: 10, Sort: entity.SortByRelevance},
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Products, 1)
+
+		assert.NotContains(t, page.Products[0].Highlight, "<img")
+		assert.Contains(t, page.Products[0].Highlight, "&lt;img")
+		assert.Contains(t, page.Products[0].Highlight, "<b>charger</b>")
+	})
+
+	t.Run("relevance pagination and highlight", func(t *testing.T) {
+		query := entity.ProductSearchQuery{
+			Text:         "apple",
+			ProductQuery: entity.ProductQuery{Limit: 1, Sort: entity.SortByRelevance},
+		}
+
+		seen := make([]int64, 0)
+		for {
+			page, err := searcher.Search(context.Background(), query)
+			require.NoError(t, err)
+			for _, hit := range page.Products {
+				assert.Contains(t, hit.Highlight, "<b>Apple</b>")
+			}
+			seen = append(seen, ids(page.Products)...)
+			if page.NextCursor == "" {
+				break
+			}
+			cursor, err := entity.DecodeProductCursor(page.NextCursor)
+			require.NoError(t, err)
+			query.After = &cursor
+		}
+
+		assert.ElementsMatch(t, []int64{1, 4}, seen)
+	})
+}
@@ -14,7 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func getTestClient(t *testing.T) postgresql.Client {
+func getTestClient(t testing.TB) postgresql.Client {
 	ctx := context.Background()
 	client, err := postgresql.NewClient(ctx, config.Database{
 		Host:     "localhost",
@@ -28,7 +28,7 @@ func getTestClient(t *testing.T) postgresql.Client {
 
 }
 
-func cleanTables(t *testing.T, client postgresql.Client, tableNames ...string) {
+func cleanTables(t testing.TB, client postgresql.Client, tableNames ...string) {
 	for _, name := range tableNames {
 		query := fmt.Sprintf("TRUNCATE TABLE \"%s\" CASCADE", name)
 		_, err := client.Exec(
@@ -86,6 +86,24 @@ func Test_productStorage_AddOrUpdateProduct(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "quotes in names",
+			products: []entity.AddOrUpdateProductDTO{
+				{ProductName: "Women's bag", CategoryName: "women's bags"},
+				{ProductName: `'); DROP TABLE product; --`, CategoryName: "laptop"},
+			},
+			result: []entity.AddOrUpdateProductDTO{
+				{ProductName: "redmi", CategoryName: "phone"},
+				{ProductName: "redmi", CategoryName: "tablet"},
+				{ProductName: "lenovo", CategoryName: "laptop"},
+				{ProductName: "iphone", CategoryName: "phone"},
+				{ProductName: "dyson", CategoryName: "vacuum cleaner"},
+				{ProductName: "maibenben", CategoryName: "laptop"},
+				{ProductName: "Women's bag", CategoryName: "women's bags"},
+				{ProductName: `'); DROP TABLE product; --`, CategoryName: "laptop"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -120,6 +138,140 @@ func Test_productStorage_AddOrUpdateProduct(t *testing.T) {
 	}
 }
 
+func Test_productStorage_AddOrUpdateProduct_largeBatch(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+	storage := NewProductStorage(client)
+
+	// More rows than a multi-row VALUES insert could bind parameters for.
+	products := bulkProducts(20000)
+	products = append(products, entity.AddOrUpdateProductDTO{
+		ProductName:      products[0].ProductName,
+		CategoryName:     "last category",
+		ProductSourceRef: products[0].ProductSourceRef,
+		ProductDetails:   entity.ProductDetails{Price: 999},
+	})
+
+	err := storage.AddOrUpdateProduct(context.Background(), products...)
+	require.NoError(t, err)
+
+	var count int
+	err = client.QueryRow(context.Background(), `SELECT count(*) FROM product;`).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 20000, count)
+
+	var (
+		price    float64
+		category string
+	)
+	err = client.QueryRow(
+		context.Background(),
+		`SELECT p.price, c.name
+		FROM product p
+		JOIN product_category pc ON pc.product_id = p.id
+		JOIN category c ON c.id = pc.category_id
+		WHERE p.name = $1;`,
+		products[0].ProductName,
+	).Scan(&price, &category)
+	require.NoError(t, err)
+	require.Equal(t, products[0].Price, price)
+	require.Equal(t, "last category", category)
+
+	err = storage.AddOrUpdateProduct(context.Background(), products...)
+	require.NoError(t, err)
+}
+
+func Benchmark_productStorage_AddOrUpdateProduct(b *testing.B) {
+	client := getTestClient(b)
+	storage := NewProductStorage(client)
+
+	for _, size := range []int{1000, 10000, 50000} {
+		products := bulkProducts(size)
+
+		b.Run(fmt.Sprintf("insert %d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				cleanTables(b, client, "product_category", "product", "category")
+				b.StartTimer()
+
+				err := storage.AddOrUpdateProduct(context.Background(), products...)
+				require.NoError(b, err)
+			}
+			b.ReportMetric(float64(size*b.N)/b.Elapsed().Seconds(), "rows/s")
+		})
+
+		b.Run(fmt.Sprintf("update %d", size), func(b *testing.B) {
+			err := storage.AddOrUpdateProduct(context.Background(), products...)
+			require.NoError(b, err)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				err := storage.AddOrUpdateProduct(context.Background(), products...)
+				require.NoError(b, err)
+			}
+			b.ReportMetric(float64(size*b.N)/b.Elapsed().Seconds(), "rows/s")
+		})
+	}
+}
+
+// bulkProducts returns n products spread over 100 categories, with quotes
+// in their names as upstream titles often have.
+func bulkProducts(n int) []entity.AddOrUpdateProductDTO {
+	products := make([]entity.AddOrUpdateProductDTO, n)
+	for i := range products {
+		products[i] = entity.AddOrUpdateProductDTO{
+			ProductName:      fmt.Sprintf("Kid's toy %d", i),
+			CategoryName:     fmt.Sprintf("Toys for 'age' %d", i%100),
+			ProductSourceRef: entity.ProductSourceRef{Source: "bench", ExternalID: fmt.Sprint(i)},
+			ProductDetails: entity.ProductDetails{
+				Description: "A toy",
+				Price:       float64(i%1000) + 0.99,
+				Stock:       int32(i % 50),
+				Brand:       "O'Toys",
+				Images:      []string{"https://example.com/toy.png"},
+				Attributes:  entity.ProductAttributes{"color": "red"},
+			},
+		}
+	}
+	return products
+}
+
+func Test_productStorage_AddOrUpdateProduct_source(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+	storage := NewProductStorage(client)
+
+	err := storage.AddOrUpdateProduct(
+		context.Background(),
+		entity.AddOrUpdateProductDTO{
+			ProductName:      "redmi",
+			CategoryName:     "phone",
+			ProductSourceRef: entity.ProductSourceRef{Source: "dummyjson", ExternalID: "1"},
+		},
+		entity.AddOrUpdateProductDTO{ProductName: "iphone", CategoryName: "phone"},
+	)
+	require.NoError(t, err)
+
+	var id int64
+	err = client.QueryRow(context.Background(), `SELECT id FROM product WHERE name = 'redmi';`).Scan(&id)
+	require.NoError(t, err)
+	product, err := storage.GetByID(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, entity.ProductSourceRef{Source: "dummyjson", ExternalID: "1"}, product.ProductSourceRef)
+
+	err = client.QueryRow(context.Background(), `SELECT id FROM product WHERE name = 'iphone';`).Scan(&id)
+	require.NoError(t, err)
+	product, err = storage.GetByID(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, entity.ProductSourceRef{}, product.ProductSourceRef)
+}
+
 func Test_productStorage_Add(t *testing.T) {
 	client := getTestClient(t)
 	cleanTables(
@@ -207,6 +359,100 @@ func Test_productStorage_Add(t *testing.T) {
 	}
 }
 
+func Test_productStorage_GetByID(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+
+	_, err := client.Exec(
+		context.Background(),
+		`INSERT INTO product
+			("id", "name", "price", "brand", "images", "attributes")
+		VALUES
+			(1,'iphone',999.5,'Apple','{"https://cdn.example.com/1.png"}','{"color": "silver"}'),
+			(2,'redmi',199,'Xiaomi','{}','{}');
+		INSERT INTO category
+			("id", "name")
+		VALUES
+			(1,'phone'),
+			(2,'apple');
+		INSERT INTO product_category
+			("product_id", "category_id")
+		VALUES
+			(1,1),
+			(1,2);`,
+	)
+	require.NoError(t, err)
+	storage := NewProductStorage(client)
+
+	tests := []struct {
+		name      string
+		ID        int64
+		want      entity.ProductView
+		wantErr   bool
+		errorCode errors.ErrorCode
+	}{
+		{
+			name: "product in several categories",
+			ID:   1,
+			want: entity.ProductView{
+				ID:   1,
+				Name: "iphone",
+				Categories: []entity.Category{
+					{ID: 1, Name: "phone"},
+					{ID: 2, Name: "apple"},
+				},
+				ProductDetails: entity.ProductDetails{
+					Price:      999.5,
+					Brand:      "Apple",
+					Images:     []string{"https://cdn.example.com/1.png"},
+					Attributes: entity.ProductAttributes{"color": "silver"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "product without categories",
+			ID:   2,
+			want: entity.ProductView{
+				ID:         2,
+				Name:       "redmi",
+				Categories: []entity.Category{},
+				ProductDetails: entity.ProductDetails{
+					Price:      199,
+					Brand:      "Xiaomi",
+					Images:     []string{},
+					Attributes: entity.ProductAttributes{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "product doesn't exist",
+			ID:        3,
+			wantErr:   true,
+			errorCode: errors.ErrNoDataFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			product, err := storage.GetByID(context.Background(), tt.ID)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, product)
+
+		})
+	}
+}
+
 func Test_productStorage_GetByCategory(t *testing.T) {
 	client := getTestClient(t)
 	cleanTables(
@@ -235,6 +481,26 @@ func Test_productStorage_GetByCategory(t *testing.T) {
 	err = row.Scan(&phoneCategoryID)
 	require.NoError(t, err)
 
+	var electronicsCategoryID int64
+	row = client.QueryRow(
+		context.Background(),
+		`INSERT INTO category
+			("name")
+		VALUES
+			('electronics')
+		RETURNING id;`,
+	)
+	err = row.Scan(&electronicsCategoryID)
+	require.NoError(t, err)
+	_, err = client.Exec(
+		context.Background(),
+		`UPDATE category
+		SET parent_id = $1
+		WHERE id = $2;`,
+		electronicsCategoryID, phoneCategoryID,
+	)
+	require.NoError(t, err)
+
 	var iphoneID int64
 	row = client.QueryRow(
 		context.Background(),
@@ -254,15 +520,16 @@ func Test_productStorage_GetByCategory(t *testing.T) {
 	require.NoError(t, err)
 
 	tests := []struct {
-		name       string
-		categoryID int64
-		result     []entity.ProductCategoryListItem
-		wantErr    bool
-		errorCode  errors.ErrorCode
+		name      string
+		query     entity.ProductQuery
+		result    []entity.ProductCategoryListItem
+		wantNext  bool
+		wantErr   bool
+		errorCode errors.ErrorCode
 	}{
 		{
-			name:       "existing category",
-			categoryID: phoneCategoryID,
+			name:  "existing category",
+			query: entity.ProductQuery{CategoryID: phoneCategoryID, Limit: 10, Sort: entity.SortByID},
 			result: []entity.ProductCategoryListItem{
 				{ID: redmiID, Name: "redmi"},
 				{ID: iphoneID, Name: "iphone"},
@@ -270,24 +537,84 @@ func Test_productStorage_GetByCategory(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:       "non existing category",
-			categoryID: 0,
-			wantErr:    true,
-			errorCode:  errors.ErrCategoryNotFound,
+			name:  "sort by name, first page",
+			query: entity.ProductQuery{CategoryID: phoneCategoryID, Limit: 1, Sort: entity.SortByName},
+			result: []entity.ProductCategoryListItem{
+				{ID: iphoneID, Name: "iphone"},
+			},
+			wantNext: true,
+			wantErr:  false,
+		},
+		{
+			name: "sort by name, second page",
+			query: entity.ProductQuery{
+				CategoryID: phoneCategoryID,
+				Limit:      1,
+				Sort:       entity.SortByName,
+				After:      &entity.ProductCursor{Sort: entity.SortByName, ID: iphoneID, Name: "iphone"},
+			},
+			result: []entity.ProductCategoryListItem{
+				{ID: redmiID, Name: "redmi"},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "sort by name desc",
+			query: entity.ProductQuery{CategoryID: phoneCategoryID, Limit: 1, Sort: entity.SortByNameDesc},
+			result: []entity.ProductCategoryListItem{
+				{ID: redmiID, Name: "redmi"},
+			},
+			wantNext: true,
+			wantErr:  false,
+		},
+		{
+			name:  "name prefix",
+			query: entity.ProductQuery{CategoryID: phoneCategoryID, Limit: 10, Sort: entity.SortByID, NamePrefix: "IPH"},
+			result: []entity.ProductCategoryListItem{
+				{ID: iphoneID, Name: "iphone"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "include descendants",
+			query: entity.ProductQuery{
+				CategoryID:         electronicsCategoryID,
+				IncludeDescendants: true,
+				Limit:              10,
+				Sort:               entity.SortByID,
+			},
+			result: []entity.ProductCategoryListItem{
+				{ID: redmiID, Name: "redmi"},
+				{ID: iphoneID, Name: "iphone"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "descendants not requested",
+			query:   entity.ProductQuery{CategoryID: electronicsCategoryID, Limit: 10, Sort: entity.SortByID},
+			result:  []entity.ProductCategoryListItem{},
+			wantErr: false,
+		},
+		{
+			name:      "non existing category",
+			query:     entity.ProductQuery{CategoryID: 0, Limit: 10, Sort: entity.SortByID},
+			wantErr:   true,
+			errorCode: errors.ErrCategoryNotFound,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			products, err := storage.GetByCategory(context.Background(), tt.categoryID)
+			page, err := storage.GetByCategory(context.Background(), tt.query)
 			if tt.wantErr {
 				require.Equal(t, tt.errorCode, errors.Code(err))
 				return
 			}
 			require.NoError(t, err)
 
-			assert.ElementsMatch(t, products, tt.result)
+			assert.ElementsMatch(t, page.Products, tt.result)
+			assert.Equal(t, tt.wantNext, page.NextCursor != "")
 
 		})
 	}
@@ -372,6 +699,100 @@ func Test_productStorage_UpdateName(t *testing.T) {
 
 }
 
+func Test_productStorage_UpdateDetails(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"product_category", "product", "category",
+	)
+
+	_, err := client.Exec(
+		context.Background(),
+		`INSERT INTO product
+			("id", "name", "sku")
+		VALUES
+			(1,'iphone',NULL),
+			(2,'redmi','XM-1');`,
+	)
+	require.NoError(t, err)
+	storage := NewProductStorage(client)
+
+	tests := []struct {
+		name      string
+		dto       entity.UpdateProductDetailsDTO
+		wantErr   bool
+		errorCode errors.ErrorCode
+	}{
+		{
+			name: "success",
+			dto: entity.UpdateProductDetailsDTO{
+				ProductID: 1,
+				ProductDetails: entity.ProductDetails{
+					Description:        "flagship",
+					Price:              999.99,
+					DiscountPercentage: 10,
+					Rating:             4.5,
+					Stock:              3,
+					Brand:              "Apple",
+					SKU:                "AP-15",
+					Thumbnail:          "https://cdn.example.com/thumb.png",
+					Images:             []string{"https://cdn.example.com/1.png"},
+					Attributes:         entity.ProductAttributes{"color": "silver", "weight": 0.17, "esim": true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "product id doesn't exist",
+			dto: entity.UpdateProductDetailsDTO{
+				ProductID: 0,
+			},
+			wantErr:   true,
+			errorCode: errors.ErrNoDataFound,
+		},
+		{
+			name: "sku not unique",
+			dto: entity.UpdateProductDetailsDTO{
+				ProductID:      1,
+				ProductDetails: entity.ProductDetails{SKU: "XM-1"},
+			},
+			wantErr:   true,
+			errorCode: errors.ErrAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := storage.UpdateDetails(context.Background(), tt.dto)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			var details entity.ProductDetails
+			row := client.QueryRow(
+				context.Background(),
+				`SELECT description, price, discount_percentage, rating, stock,
+					brand, sku, thumbnail, images, attributes
+				FROM product
+				WHERE id = $1;`,
+				tt.dto.ProductID,
+			)
+			err = row.Scan(
+				&details.Description, &details.Price, &details.DiscountPercentage, &details.Rating, &details.Stock,
+				&details.Brand, &details.SKU, &details.Thumbnail, &details.Images, &details.Attributes,
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.dto.ProductDetails, details)
+
+		})
+	}
+
+}
+
 func Test_productStorage_UpdateCategory(t *testing.T) {
 	client := getTestClient(t)
 	cleanTables(
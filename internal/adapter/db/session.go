@@ -27,24 +27,34 @@ func NewSessionStorage(client postgresql.Client) *sessionStorage {
 	}
 }
 
-func (ss *sessionStorage) GetByToken(ctx context.Context, token string) (entity.Session, error) {
+const sessionColumns = `id, token, user_id, expiry, created_at, last_used_at, ip, user_agent`
+
+func scanSession(row pgx.Row) (entity.Session, error) {
+	var session entity.Session
+	err := row.Scan(
+		&session.ID, &session.Token, &session.UserID, &session.Expiry,
+		&session.CreatedAt, &session.LastUsedAt, &session.IP, &session.UserAgent,
+	)
+	return session, err
+}
+
+func (ss *sessionStorage) GetByID(ctx context.Context, ID int64) (entity.Session, error) {
 
 	row := ss.client.QueryRow(
 		ctx,
-		`SELECT * FROM session
-		WHERE token = $1;`,
-		token,
+		`SELECT `+sessionColumns+` FROM session
+		WHERE id = $1;`,
+		ID,
 	)
 
-	var session entity.Session
-	err := row.Scan(&session.ID, &session.Token, &session.UserID, &session.Expiry)
+	session, err := scanSession(row)
 	if err != nil {
-		slog.Error("error getting session from db",
-			"error", err,
-		)
 		if stdErrors.Is(err, pgx.ErrNoRows) {
 			return entity.Session{}, errors.NewDomainError(errors.ErrNoDataFound, "")
 		}
+		slog.Error("error getting session from db",
+			"error", err,
+		)
 		return entity.Session{}, errors.NewDomainError(errors.ErrDB, "")
 	}
 
@@ -52,32 +62,36 @@ func (ss *sessionStorage) GetByToken(ctx context.Context, token string) (entity.
 
 }
 
-func (ss *sessionStorage) Create(ctx context.Context, session entity.Session) error {
+func (ss *sessionStorage) Create(ctx context.Context, session entity.Session) (entity.Session, error) {
 
-	_, err := ss.client.Exec(
+	err := ss.client.QueryRow(
 		ctx,
 		`INSERT INTO session
-			("token", "user_id", "expiry")
+			("token", "user_id", "expiry", "created_at", "last_used_at", "ip", "user_agent")
 		VALUES
-			($1,$2,$3);`,
+			($1,$2,$3,$4,$5,$6,$7)
+		RETURNING id;`,
 		session.Token,
 		session.UserID,
 		session.Expiry,
-	)
+		session.CreatedAt,
+		session.LastUsedAt,
+		session.IP,
+		session.UserAgent,
+	).Scan(&session.ID)
 
 	if err != nil {
-		slog.Error(err.Error())
 		var pgErr *pgconn.PgError
 		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
-			return errors.NewDomainError(errors.ErrAlreadyExists, "")
+			return entity.Session{}, errors.NewDomainError(errors.ErrAlreadyExists, "")
 		}
 		slog.Error("error adding new session to db",
 			"error", err,
 		)
-		return errors.NewDomainError(errors.ErrDB, "")
+		return entity.Session{}, errors.NewDomainError(errors.ErrDB, "")
 	}
 
-	return nil
+	return session, nil
 }
 
 func (ss *sessionStorage) Delete(ctx context.Context, token string) error {
@@ -100,6 +114,116 @@ func (ss *sessionStorage) Delete(ctx context.Context, token string) error {
 	return nil
 }
 
+// GetByUser returns the unexpired sessions of a user, most recently used first.
+func (ss *sessionStorage) GetByUser(ctx context.Context, userID int64) ([]entity.Session, error) {
+	rows, err := ss.client.Query(
+		ctx,
+		`SELECT `+sessionColumns+` FROM session
+		WHERE user_id = $1 AND expiry >= $2
+		ORDER BY last_used_at DESC, id DESC;`,
+		userID, time.Now(),
+	)
+	if err != nil {
+		slog.Error("error getting user sessions from db",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	sessions, err := pgx.CollectRows[entity.Session](
+		rows, func(row pgx.CollectableRow) (entity.Session, error) {
+			return scanSession(row)
+		},
+	)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return sessions, nil
+}
+
+// Rotate replaces the token of a session if it is still oldToken. A token
+// that was rotated meanwhile is reported as not found.
+func (ss *sessionStorage) Rotate(ctx context.Context, ID int64, oldToken, newToken string, lastUsedAt time.Time) error {
+	c, err := ss.client.Exec(
+		ctx,
+		`UPDATE session SET token = $3, last_used_at = $4
+		WHERE id = $1 AND token = $2;`,
+		ID, oldToken, newToken, lastUsedAt,
+	)
+	if err != nil {
+		slog.Error("error rotating session token",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	return nil
+}
+
+// DeleteByID deletes a session of a user. Sessions of other users are
+// reported as not found.
+func (ss *sessionStorage) DeleteByID(ctx context.Context, userID, ID int64) error {
+	c, err := ss.client.Exec(
+		ctx,
+		`DELETE FROM session
+		WHERE id = $1 AND user_id = $2;`,
+		ID, userID,
+	)
+	if err != nil {
+		slog.Error("error deleting session by id",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	return nil
+}
+
+func (ss *sessionStorage) DeleteByUser(ctx context.Context, userID int64) error {
+	_, err := ss.client.Exec(
+		ctx,
+		`DELETE FROM session
+		WHERE user_id = $1;`,
+		userID,
+	)
+	if err != nil {
+		slog.Error("error deleting user sessions",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+// DeleteOthers deletes the sessions of a user but the one with ID keepID.
+func (ss *sessionStorage) DeleteOthers(ctx context.Context, userID, keepID int64) error {
+	_, err := ss.client.Exec(
+		ctx,
+		`DELETE FROM session
+		WHERE user_id = $1 AND id <> $2;`,
+		userID, keepID,
+	)
+	if err != nil {
+		slog.Error("error deleting user sessions",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
 func (ss *sessionStorage) DeleteExpired(ctx context.Context) error {
 	_, err := ss.client.Exec(
 		ctx,
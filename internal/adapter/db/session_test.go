@@ -27,7 +27,7 @@ func Test_sessionStorage_Delete(t *testing.T) {
 	require.NoError(t, err)
 
 	sessionStorage := NewSessionStorage(client)
-	err = sessionStorage.Create(context.Background(), entity.Session{Token: "1", UserID: user.ID, Expiry: time.Now()})
+	_, err = sessionStorage.Create(context.Background(), entity.Session{Token: "1", UserID: user.ID, Expiry: time.Now()})
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -107,3 +107,88 @@ func Test_sessionStorage_DeleteExpired(t *testing.T) {
 	require.Empty(t, tokens)
 
 }
+
+func Test_sessionStorage_GetByUser(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"session", "user",
+	)
+	userStorage := NewUserStorage(client)
+	user1, err := userStorage.Create(
+		context.Background(),
+		entity.User{Login: "login1", Password: "password1"},
+	)
+	require.NoError(t, err)
+	user2, err := userStorage.Create(
+		context.Background(),
+		entity.User{Login: "login2", Password: "password2"},
+	)
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Millisecond)
+	sessionStorage := NewSessionStorage(client)
+	created := make(map[string]entity.Session)
+	for _, s := range []entity.Session{
+		{Token: "1", UserID: user1.ID, Expiry: now.Add(time.Hour), CreatedAt: now, LastUsedAt: now,
+			ClientInfo: entity.ClientInfo{IP: "10.0.0.1", UserAgent: "firefox"}},
+		{Token: "2", UserID: user1.ID, Expiry: now.Add(time.Hour), CreatedAt: now, LastUsedAt: now.Add(time.Minute),
+			ClientInfo: entity.ClientInfo{IP: "10.0.0.2", UserAgent: "curl"}},
+		{Token: "3", UserID: user1.ID, Expiry: now.Add(-time.Hour), CreatedAt: now, LastUsedAt: now},
+		{Token: "4", UserID: user2.ID, Expiry: now.Add(time.Hour), CreatedAt: now, LastUsedAt: now},
+	} {
+		session, err := sessionStorage.Create(context.Background(), s)
+		require.NoError(t, err)
+		require.NotZero(t, session.ID)
+		created[s.Token] = session
+	}
+
+	sessions, err := sessionStorage.GetByUser(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, "2", sessions[0].Token)
+	require.Equal(t, entity.ClientInfo{IP: "10.0.0.2", UserAgent: "curl"}, sessions[0].ClientInfo)
+	require.Equal(t, "1", sessions[1].Token)
+
+	err = sessionStorage.Rotate(context.Background(), sessions[1].ID, "1", "5", now.Add(time.Hour))
+	require.NoError(t, err)
+	err = sessionStorage.Rotate(context.Background(), sessions[1].ID, "1", "6", now.Add(time.Hour))
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+	got, err := sessionStorage.GetByID(context.Background(), sessions[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, "5", got.Token)
+	require.True(t, got.LastUsedAt.Equal(now.Add(time.Hour)))
+
+	_, err = sessionStorage.GetByID(context.Background(), 0)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	err = sessionStorage.DeleteByID(context.Background(), user1.ID, created["4"].ID)
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	err = sessionStorage.DeleteByID(context.Background(), user1.ID, got.ID)
+	require.NoError(t, err)
+	sessions, err = sessionStorage.GetByUser(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	_, err = sessionStorage.Create(context.Background(), entity.Session{
+		Token: "7", UserID: user1.ID, Expiry: now.Add(time.Hour), CreatedAt: now, LastUsedAt: now,
+	})
+	require.NoError(t, err)
+	err = sessionStorage.DeleteOthers(context.Background(), user1.ID, created["2"].ID)
+	require.NoError(t, err)
+	sessions, err = sessionStorage.GetByUser(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, created["2"].ID, sessions[0].ID)
+
+	err = sessionStorage.DeleteByUser(context.Background(), user1.ID)
+	require.NoError(t, err)
+	sessions, err = sessionStorage.GetByUser(context.Background(), user1.ID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	sessions, err = sessionStorage.GetByUser(context.Background(), user2.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	stdErrors "errors"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/client/postgresql"
+	"github.com/jackc/pgx/v5"
+)
+
+var _ service.SyncStateStorage = new(syncStateStorage)
+
+type syncStateStorage struct {
+	client postgresql.Client
+}
+
+func NewSyncStateStorage(client postgresql.Client) *syncStateStorage {
+	return &syncStateStorage{
+		client: client,
+	}
+}
+
+func (s *syncStateStorage) Get(ctx context.Context, source string) (entity.SyncState, error) {
+	row := s.client.QueryRow(
+		ctx,
+		`SELECT source, "offset", total, updated_at FROM sync_state
+		WHERE source = $1;`,
+		source,
+	)
+
+	var state entity.SyncState
+	err := row.Scan(&state.Source, &state.Offset, &state.Total, &state.UpdatedAt)
+	if err != nil {
+		if stdErrors.Is(err, pgx.ErrNoRows) {
+			return entity.SyncState{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting sync state from db",
+			"error", err,
+		)
+		return entity.SyncState{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return state, nil
+}
+
+func (s *syncStateStorage) Save(ctx context.Context, state entity.SyncState) error {
+	_, err := s.client.Exec(
+		ctx,
+		`INSERT INTO sync_state
+			(source, "offset", total, updated_at)
+		VALUES
+			($1, $2, $3, now())
+		ON CONFLICT (source)
+		DO UPDATE SET
+			"offset" = EXCLUDED."offset",
+			total = EXCLUDED.total,
+			updated_at = EXCLUDED.updated_at;`,
+		state.Source,
+		state.Offset,
+		state.Total,
+	)
+	if err != nil {
+		slog.Error("error saving sync state",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
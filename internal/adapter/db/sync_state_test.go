@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_syncStateStorage(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(t, client, "sync_state")
+
+	storage := NewSyncStateStorage(client)
+
+	_, err := storage.Get(context.Background(), "dummyjson")
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	err = storage.Save(context.Background(), entity.SyncState{Source: "dummyjson", Offset: 10, Total: 100})
+	require.NoError(t, err)
+
+	err = storage.Save(context.Background(), entity.SyncState{Source: "dummyjson", Offset: 20, Total: 120})
+	require.NoError(t, err)
+
+	state, err := storage.Get(context.Background(), "dummyjson")
+	require.NoError(t, err)
+	require.Equal(t, "dummyjson", state.Source)
+	require.Equal(t, 20, state.Offset)
+	require.Equal(t, 120, state.Total)
+	require.False(t, state.UpdatedAt.IsZero())
+}
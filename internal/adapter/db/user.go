@@ -26,19 +26,22 @@ func NewUserStorage(client postgresql.Client) *userStorage {
 }
 
 func (us *userStorage) Create(ctx context.Context, user entity.User) (entity.User, error) {
+	if user.Role == "" {
+		user.Role = entity.RoleViewer
+	}
 
 	row := us.client.QueryRow(
 		ctx,
 		`INSERT INTO "user"
-			(login, password)
+			(login, password, role, email)
 		VALUES
-			($1,$2)
+			($1,$2,$3,NULLIF($4, ''))
 		ON CONFLICT DO NOTHING
-		RETURNING *;`,
-		user.Login, user.Password,
+		RETURNING id, login, password, role, COALESCE(email, '');`,
+		user.Login, user.Password, user.Role, user.Email,
 	)
 
-	err := row.Scan(&user.ID, &user.Login, &user.Password)
+	err := row.Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.Email)
 	if err != nil {
 		slog.Error("error adding user to db",
 			"error", err,
@@ -56,13 +59,13 @@ func (us *userStorage) GetByLogin(ctx context.Context, login string) (entity.Use
 
 	row := us.client.QueryRow(
 		ctx,
-		`SELECT * FROM "user"
-		WHERE login = $1;`,
+		`SELECT id, login, password, role, COALESCE(email, '') FROM "user"
+		WHERE lower(login) = lower($1);`,
 		login,
 	)
 
 	var user entity.User
-	err := row.Scan(&user.ID, &user.Login, &user.Password)
+	err := row.Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.Email)
 	if err != nil {
 		slog.Error("error getting user from db",
 			"error", err,
@@ -81,13 +84,13 @@ func (us *userStorage) GetByID(ctx context.Context, ID int64) (entity.User, erro
 
 	row := us.client.QueryRow(
 		ctx,
-		`SELECT * FROM "user"
+		`SELECT id, login, password, role, COALESCE(email, '') FROM "user"
 		WHERE id = $1;`,
 		ID,
 	)
 
 	var user entity.User
-	err := row.Scan(&user.ID, &user.Login, &user.Password)
+	err := row.Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.Email)
 	if err != nil {
 		slog.Error("error getting user from db",
 			"error", err,
@@ -101,3 +104,110 @@ func (us *userStorage) GetByID(ctx context.Context, ID int64) (entity.User, erro
 	return user, nil
 
 }
+
+// SetRole changes the role of a user. The table is locked for the update, so
+// that two concurrent demotions can't leave the catalog without an admin.
+func (us *userStorage) SetRole(ctx context.Context, dto entity.SetRoleDTO) (entity.User, error) {
+	tx, err := us.client.Begin(ctx)
+	if err != nil {
+		slog.Error("error beginnig transaction",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `LOCK TABLE "user" IN SHARE ROW EXCLUSIVE MODE;`)
+	if err != nil {
+		slog.Error("error locking user table",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	var (
+		user   entity.User
+		admins int
+	)
+	err = tx.QueryRow(
+		ctx,
+		`SELECT id, login, password, role, COALESCE(email, ''),
+			(SELECT count(*) FROM "user" WHERE role = 'admin')
+		FROM "user"
+		WHERE lower(login) = lower($1);`,
+		dto.Login,
+	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.Email, &admins)
+	if err != nil {
+		if stdErrors.Is(err, pgx.ErrNoRows) {
+			return entity.User{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting user from db",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	if user.Role == entity.RoleAdmin && dto.Role != entity.RoleAdmin && admins <= 1 {
+		return entity.User{}, errors.NewDomainError(errors.ErrLastAdmin, "")
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE "user" SET role = $2
+		WHERE id = $1;`,
+		user.ID, dto.Role,
+	)
+	if err != nil {
+		slog.Error("error updating user role",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		slog.Error("error commiting transaction",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	user.Role = dto.Role
+	return user, nil
+}
+
+func (us *userStorage) HasAdmin(ctx context.Context) (bool, error) {
+	var exists bool
+	err := us.client.QueryRow(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM "user" WHERE role = 'admin');`,
+	).Scan(&exists)
+	if err != nil {
+		slog.Error("error checking for admin",
+			"error", err,
+		)
+		return false, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return exists, nil
+}
+
+func (us *userStorage) SetPassword(ctx context.Context, ID int64, password string) error {
+	c, err := us.client.Exec(
+		ctx,
+		`UPDATE "user" SET password = $2
+		WHERE id = $1;`,
+		ID, password,
+	)
+	if err != nil {
+		slog.Error("error updating user password",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if c.RowsAffected() == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
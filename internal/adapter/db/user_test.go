@@ -34,6 +34,12 @@ func Test_userStorage_Create(t *testing.T) {
 			wantErr:   true,
 			errorCode: errors.ErrAlreadyExists,
 		},
+		{
+			name:      "login exists in another case",
+			user:      entity.User{Login: "LOGIN1", Password: "passsword1"},
+			wantErr:   true,
+			errorCode: errors.ErrAlreadyExists,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -139,3 +145,91 @@ func Test_userStorage_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_userStorage_SetRole(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"user",
+	)
+	userStorage := NewUserStorage(client)
+	user, err := userStorage.Create(
+		context.Background(),
+		entity.User{Login: "login1", Password: "passsword1"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, entity.RoleViewer, user.Role)
+
+	hasAdmin, err := userStorage.HasAdmin(context.Background())
+	require.NoError(t, err)
+	require.False(t, hasAdmin)
+
+	tests := []struct {
+		name      string
+		dto       entity.SetRoleDTO
+		wantErr   bool
+		errorCode errors.ErrorCode
+	}{
+		{
+			name:    "grant admin",
+			dto:     entity.SetRoleDTO{Login: "login1", Role: entity.RoleAdmin},
+			wantErr: false,
+		},
+		{
+			name:      "demote last admin",
+			dto:       entity.SetRoleDTO{Login: "login1", Role: entity.RoleEditor},
+			wantErr:   true,
+			errorCode: errors.ErrLastAdmin,
+		},
+		{
+			name:      "user doesn't exist",
+			dto:       entity.SetRoleDTO{Login: "nouser", Role: entity.RoleEditor},
+			wantErr:   true,
+			errorCode: errors.ErrNoDataFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := userStorage.SetRole(context.Background(), tt.dto)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+
+			require.Equal(t, tt.dto.Role, got.Role)
+
+			stored, err := userStorage.GetByLogin(context.Background(), tt.dto.Login)
+			require.NoError(t, err)
+			require.Equal(t, tt.dto.Role, stored.Role)
+		})
+	}
+
+	hasAdmin, err = userStorage.HasAdmin(context.Background())
+	require.NoError(t, err)
+	require.True(t, hasAdmin)
+}
+
+func Test_userStorage_SetPassword(t *testing.T) {
+	client := getTestClient(t)
+	cleanTables(
+		t, client,
+		"session", "user",
+	)
+	userStorage := NewUserStorage(client)
+	user, err := userStorage.Create(
+		context.Background(),
+		entity.User{Login: "login1", Password: "password1"},
+	)
+	require.NoError(t, err)
+	require.Empty(t, user.Email)
+
+	err = userStorage.SetPassword(context.Background(), user.ID, "password2")
+	require.NoError(t, err)
+
+	stored, err := userStorage.GetByID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "password2", stored.Password)
+
+	err = userStorage.SetPassword(context.Background(), 0, "password3")
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+}
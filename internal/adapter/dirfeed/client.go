@@ -0,0 +1,111 @@
+// Package dirfeed reads products from the JSON files of a local directory
+// with a configurable field mapping.
+package dirfeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/fieldmap"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+type Config struct {
+	Dir string
+	// ItemsPath is the path of the records list in every file, empty if a
+	// file holds the list itself or a single record.
+	ItemsPath string
+	Fields    fieldmap.Mapping
+}
+
+type productClient struct {
+	name   string
+	config Config
+}
+
+func NewProductClient(name string, config Config) (*productClient, error) {
+	info, err := os.Stat(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", config.Dir)
+	}
+	err = config.Fields.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &productClient{
+		name:   name,
+		config: config,
+	}, nil
+}
+
+func (c *productClient) Source() string {
+	return c.name
+}
+
+// GetNewProducts pages through the records of all *.json files of the
+// directory, taken in file name order. New files should sort after the
+// imported ones for the sync checkpoint to stay valid.
+func (c *productClient) GetNewProducts(ctx context.Context, offset, limit int) (entity.ProductFeedPage, error) {
+	records, err := c.records()
+	if err != nil {
+		return entity.ProductFeedPage{}, err
+	}
+
+	page := entity.ProductFeedPage{Total: len(records)}
+	if offset >= len(records) {
+		return page, nil
+	}
+	end := offset + limit
+	if end > len(records) {
+		end = len(records)
+	}
+
+	page.Products = c.config.Fields.Products(c.name, records[offset:end])
+	page.Fetched = end - offset
+
+	return page, nil
+}
+
+func (c *productClient) records() ([]interface{}, error) {
+	files, err := filepath.Glob(filepath.Join(c.config.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []interface{}
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var doc interface{}
+		err = json.Unmarshal(b, &doc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		v, ok := fieldmap.Lookup(doc, c.config.ItemsPath)
+		if !ok {
+			return nil, fmt.Errorf("%s: no records at %q", file, c.config.ItemsPath)
+		}
+		if list, isList := v.([]interface{}); isList {
+			records = append(records, list...)
+			continue
+		}
+		if _, isObject := v.(map[string]interface{}); isObject && c.config.ItemsPath == "" {
+			records = append(records, v)
+			continue
+		}
+		return nil, fmt.Errorf("%s: records at %q should be a list", file, c.config.ItemsPath)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,77 @@
+package dirfeed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/fieldmap"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+func TestProductClient_GetNewProducts(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "01.json", `{"items": [
+		{"id": 1, "title": "hammer", "category": "tools"},
+		{"id": 2, "title": "saw", "category": "tools"}
+	]}`)
+	writeFile(t, dir, "02.json", `{"items": [
+		{"id": 3, "title": "drill", "category": "tools", "price": -5},
+		{"id": 4, "title": "nail", "category": "parts"}
+	]}`)
+	writeFile(t, dir, "notes.txt", `not a feed`)
+
+	client, err := NewProductClient("warehouse", Config{
+		Dir:       dir,
+		ItemsPath: "items",
+		Fields:    fieldmap.Mapping{fieldmap.ExternalID: "id", fieldmap.Name: "title"},
+	})
+	require.NoError(t, err)
+
+	page, err := client.GetNewProducts(context.Background(), 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 4, page.Total)
+	require.Equal(t, 2, page.Fetched)
+	require.Len(t, page.Products, 1, "product with a negative price is skipped")
+	require.Equal(t, "saw", page.Products[0].ProductName)
+	require.Equal(t, "warehouse", page.Products[0].Source)
+	require.Equal(t, "2", page.Products[0].ExternalID)
+
+	page, err = client.GetNewProducts(context.Background(), 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, page.Fetched)
+	require.Equal(t, "nail", page.Products[0].ProductName)
+
+	page, err = client.GetNewProducts(context.Background(), 4, 2)
+	require.NoError(t, err)
+	require.Zero(t, page.Fetched)
+	require.Empty(t, page.Products)
+}
+
+func TestProductClient_singleRecordFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.json", `{"id": "a", "name": "hammer", "category": "tools"}`)
+	writeFile(t, dir, "b.json", `[{"id": "b", "name": "saw", "category": "tools"}]`)
+
+	client, err := NewProductClient("warehouse", Config{Dir: dir})
+	require.NoError(t, err)
+
+	page, err := client.GetNewProducts(context.Background(), 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, page.Total)
+	require.Len(t, page.Products, 2)
+
+	writeFile(t, dir, "c.json", `{"broken": `)
+	_, err = client.GetNewProducts(context.Background(), 0, 10)
+	require.Error(t, err)
+}
+
+func TestNewProductClient(t *testing.T) {
+	_, err := NewProductClient("warehouse", Config{Dir: filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+}
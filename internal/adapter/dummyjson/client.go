@@ -12,48 +12,110 @@ import (
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 )
 
+// product is a product in the dummyjson schema.
+type product struct {
+	ID                 int64    `json:"id"`
+	Title              string   `json:"title"`
+	Category           string   `json:"category"`
+	Description        string   `json:"description"`
+	Price              float64  `json:"price"`
+	DiscountPercentage float64  `json:"discountPercentage"`
+	Rating             float64  `json:"rating"`
+	Stock              int32    `json:"stock"`
+	Brand              string   `json:"brand"`
+	SKU                string   `json:"sku"`
+	Thumbnail          string   `json:"thumbnail"`
+	Images             []string `json:"images"`
+}
+
+func (p product) toDTO(source string) entity.AddOrUpdateProductDTO {
+	return entity.AddOrUpdateProductDTO{
+		ProductName:  p.Title,
+		CategoryName: p.Category,
+		ProductSourceRef: entity.ProductSourceRef{
+			Source:     source,
+			ExternalID: strconv.FormatInt(p.ID, 10),
+		},
+		ProductDetails: entity.ProductDetails{
+			Description:        p.Description,
+			Price:              p.Price,
+			DiscountPercentage: p.DiscountPercentage,
+			Rating:             p.Rating,
+			Stock:              p.Stock,
+			Brand:              p.Brand,
+			SKU:                p.SKU,
+			Thumbnail:          p.Thumbnail,
+			Images:             p.Images,
+		},
+	}
+}
+
 type productClient struct {
+	name   string
 	url    string
 	client http.Client
 }
 
-func NewProductClient(url string) *productClient {
+// NewProductClient creates a client of the dummyjson API at url. name is the
+// source the products are recorded with.
+func NewProductClient(name, url string) *productClient {
 	return &productClient{
+		name:   name,
 		url:    url,
 		client: *http.DefaultClient,
 	}
 }
 
-func (c *productClient) GetNewProducts(ctx context.Context, offset int) ([]entity.AddOrUpdateProductDTO, error) {
+func (c *productClient) Source() string {
+	return c.name
+}
+
+func (c *productClient) GetNewProducts(ctx context.Context, offset, limit int) (entity.ProductFeedPage, error) {
 
-	path := "/products?limit=10&skip=" + strconv.FormatInt(int64(offset), 10) + "&select=title,category"
-	req, err := http.NewRequest("GET", c.url+path, nil)
+	path := "/products?limit=" + strconv.Itoa(limit) + "&skip=" + strconv.Itoa(offset) +
+		"&select=id,title,category,description,price,discountPercentage,rating,stock,brand,sku,thumbnail,images"
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url+path, nil)
 	if err != nil {
-		return nil, err
+		return entity.ProductFeedPage{}, err
 	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		return entity.ProductFeedPage{}, err
 	}
+	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return entity.ProductFeedPage{}, err
 	}
 	slog.Debug(string(body))
 
+	if resp.StatusCode != http.StatusOK {
+		return entity.ProductFeedPage{}, fmt.Errorf("dummyjson responded with status %d", resp.StatusCode)
+	}
+
 	var responseStruct struct {
-		Products []entity.AddOrUpdateProductDTO
+		Products []product `json:"products"`
+		Total    int       `json:"total"`
 	}
 
 	err = json.Unmarshal(body, &responseStruct)
 	if err != nil {
-		return nil, err
+		return entity.ProductFeedPage{}, err
 	}
 
 	slog.Debug(fmt.Sprint(responseStruct.Products))
 
-	return responseStruct.Products, nil
+	page := entity.ProductFeedPage{
+		Products: make([]entity.AddOrUpdateProductDTO, 0, len(responseStruct.Products)),
+		Fetched:  len(responseStruct.Products),
+		Total:    responseStruct.Total,
+	}
+	for _, p := range responseStruct.Products {
+		page.Products = append(page.Products, p.toDTO(c.name))
+	}
+
+	return page, nil
 
 }
@@ -4,12 +4,36 @@ import (
 	"context"
 	"testing"
 
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/stretchr/testify/require"
 )
 
 func Test_productClient_GetNewProducts(t *testing.T) {
-	client := NewProductClient("https://dummyjson.com")
-	dto, err := client.GetNewProducts(context.Background(), 10)
+	client := NewProductClient("dummyjson", "https://dummyjson.com")
+	page, err := client.GetNewProducts(context.Background(), 10, 10)
 	require.NoError(t, err)
-	require.NotEqual(t, len(dto), 0)
+	require.NotEqual(t, len(page.Products), 0)
+	require.Greater(t, page.Total, 10)
+}
+
+func Test_productClient_GetNewProducts_fake(t *testing.T) {
+	fake := newFakeDummyJSON(t, 25)
+	client := NewProductClient("dummyjson", fake.URL)
+
+	page, err := client.GetNewProducts(context.Background(), 20, 10)
+	require.NoError(t, err)
+	require.Equal(t, 25, page.Total)
+	require.Len(t, page.Products, 5)
+	require.Equal(t, "product 21", page.Products[0].ProductName)
+	require.Equal(t, "smartphones", page.Products[0].CategoryName)
+	require.Equal(t, entity.ProductSourceRef{Source: "dummyjson", ExternalID: "21"}, page.Products[0].ProductSourceRef)
+	require.Equal(t, 9.99, page.Products[0].Price)
+
+	page, err = client.GetNewProducts(context.Background(), 30, 10)
+	require.NoError(t, err)
+	require.Empty(t, page.Products)
+
+	fake.setFailing(true)
+	_, err = client.GetNewProducts(context.Background(), 0, 10)
+	require.Error(t, err)
 }
@@ -0,0 +1,70 @@
+package dummyjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// fakeDummyJSON serves /products the way dummyjson does. While failing is
+// set it responds with 502.
+type fakeDummyJSON struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	total    int
+	failing  bool
+	requests int
+}
+
+func newFakeDummyJSON(t *testing.T, total int) *fakeDummyJSON {
+	f := &fakeDummyJSON{total: total}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveProducts))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeDummyJSON) setFailing(failing bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failing = failing
+}
+
+func (f *fakeDummyJSON) serveProducts(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+
+	if r.URL.Path != "/products" {
+		http.NotFound(w, r)
+		return
+	}
+	if f.failing {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+
+	products := make([]map[string]interface{}, 0)
+	for i := skip; i < skip+limit && i < f.total; i++ {
+		products = append(products, map[string]interface{}{
+			"id":       i + 1,
+			"title":    fmt.Sprintf("product %d", i+1),
+			"category": "smartphones",
+			"price":    9.99,
+		})
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"products": products,
+		"total":    f.total,
+		"skip":     skip,
+		"limit":    limit,
+	})
+}
@@ -0,0 +1,111 @@
+package dummyjson
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/The-Gleb/product_catalog/pkg/backoff"
+	"github.com/The-Gleb/product_catalog/pkg/breaker"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_syncWorker_upstreamOutage runs the sync worker against a fake
+// dummyjson that is down at first: the worker must stay alive, report itself
+// degraded and import the whole feed once the upstream recovers.
+func Test_syncWorker_upstreamOutage(t *testing.T) {
+	fake := newFakeDummyJSON(t, 23)
+	fake.setFailing(true)
+
+	ctrl := gomock.NewController(t)
+	productStorage := mocks.NewMockProductStorage(ctrl)
+	syncStateStorage := mocks.NewMockSyncStateStorage(ctrl)
+
+	var (
+		mu       sync.Mutex
+		imported = make(map[string]bool)
+		state    *entity.SyncState
+	)
+	productStorage.EXPECT().AddOrUpdateProduct(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, products ...entity.AddOrUpdateProductDTO) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, p := range products {
+				imported[p.ProductName] = true
+			}
+			return nil
+		}).AnyTimes()
+	syncStateStorage.EXPECT().Get(gomock.Any(), "dummyjson").
+		DoAndReturn(func(context.Context, string) (entity.SyncState, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if state == nil {
+				return entity.SyncState{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+			}
+			return *state, nil
+		}).AnyTimes()
+	syncStateStorage.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s entity.SyncState) error {
+			mu.Lock()
+			defer mu.Unlock()
+			state = &s
+			return nil
+		}).AnyTimes()
+
+	syncBreaker := breaker.New(2, 20*time.Millisecond)
+	client := service.NewCircuitBreakerClient(NewProductClient("dummyjson", fake.URL), syncBreaker)
+	productService := service.NewProductService(productStorage, []service.ProductClient{client}, syncStateStorage, nil, 10, entity.DeleteModeTombstone)
+	worker := service.NewSyncWorker(productService, map[string]*breaker.Breaker{"dummyjson": syncBreaker}, service.SyncWorkerConfig{
+		Interval: time.Hour,
+		Backoff:  backoff.Backoff{Initial: time.Millisecond, Max: 5 * time.Millisecond},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		health := worker.Health()
+		return health.Status == entity.HealthDegraded &&
+			health.Breakers["dummyjson"] == "open" &&
+			health.ConsecutiveFailures >= 3
+	}, time.Second, time.Millisecond)
+
+	fake.mu.Lock()
+	requests := fake.requests
+	fake.mu.Unlock()
+	require.Less(t, requests, worker.Health().ConsecutiveFailures, "open breaker must not call upstream")
+
+	fake.setFailing(false)
+
+	require.Eventually(t, func() bool {
+		return worker.Health().Status == entity.HealthOK
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	require.Len(t, imported, 23)
+	require.Equal(t, entity.SyncState{Source: "dummyjson", Offset: 23, Total: 23}, *state)
+	mu.Unlock()
+
+	health := worker.Health()
+	require.Equal(t, map[string]string{"dummyjson": "closed"}, health.Breakers)
+	require.Zero(t, health.ConsecutiveFailures)
+	require.NotNil(t, health.LastSuccess)
+	require.NotNil(t, health.LastFailure)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker didn't stop after cancellation")
+	}
+}
@@ -0,0 +1,234 @@
+// Package fieldmap maps upstream JSON records to products using a
+// configurable mapping from product fields to JSON paths.
+package fieldmap
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+const (
+	ExternalID         = "external_id"
+	Name               = "name"
+	Category           = "category"
+	Description        = "description"
+	Price              = "price"
+	DiscountPercentage = "discount_percentage"
+	Rating             = "rating"
+	Stock              = "stock"
+	Brand              = "brand"
+	SKU                = "sku"
+	Thumbnail          = "thumbnail"
+	Images             = "images"
+	Attributes         = "attributes"
+)
+
+var fields = []string{
+	ExternalID, Name, Category, Description, Price, DiscountPercentage,
+	Rating, Stock, Brand, SKU, Thumbnail, Images, Attributes,
+}
+
+// Mapping maps product fields to dot separated paths in an upstream record,
+// e.g. {"name": "title", "price": "pricing.amount"}. A field missing from the
+// mapping is read from the key of the same name, except the external ID that
+// is read from "id".
+type Mapping map[string]string
+
+func (m Mapping) Validate() error {
+	for field := range m {
+		if !isField(field) {
+			return fmt.Errorf("unknown product field %q in mapping", field)
+		}
+	}
+	return nil
+}
+
+func isField(field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Mapping) path(field string) string {
+	if p, ok := m[field]; ok {
+		return p
+	}
+	if field == ExternalID {
+		return "id"
+	}
+	return field
+}
+
+// Product maps a decoded JSON record to a product of the given source. The
+// external ID, name and category are required, the other fields are optional.
+func (m Mapping) Product(source string, record interface{}) (entity.AddOrUpdateProductDTO, error) {
+	p := entity.AddOrUpdateProductDTO{
+		ProductSourceRef: entity.ProductSourceRef{Source: source},
+	}
+
+	var err error
+	required := []struct {
+		field string
+		dst   *string
+	}{
+		{ExternalID, &p.ExternalID},
+		{Name, &p.ProductName},
+		{Category, &p.CategoryName},
+	}
+	for _, r := range required {
+		*r.dst, err = m.str(record, r.field)
+		if err != nil {
+			return entity.AddOrUpdateProductDTO{}, err
+		}
+		if *r.dst == "" {
+			return entity.AddOrUpdateProductDTO{}, fmt.Errorf("field %q at %q is missing", r.field, m.path(r.field))
+		}
+	}
+
+	strs := []struct {
+		field string
+		dst   *string
+	}{
+		{Description, &p.Description},
+		{Brand, &p.Brand},
+		{SKU, &p.SKU},
+		{Thumbnail, &p.Thumbnail},
+	}
+	for _, s := range strs {
+		*s.dst, err = m.str(record, s.field)
+		if err != nil {
+			return entity.AddOrUpdateProductDTO{}, err
+		}
+	}
+
+	nums := []struct {
+		field string
+		dst   *float64
+	}{
+		{Price, &p.Price},
+		{DiscountPercentage, &p.DiscountPercentage},
+		{Rating, &p.Rating},
+	}
+	for _, n := range nums {
+		*n.dst, err = m.num(record, n.field)
+		if err != nil {
+			return entity.AddOrUpdateProductDTO{}, err
+		}
+	}
+
+	stock, err := m.num(record, Stock)
+	if err != nil {
+		return entity.AddOrUpdateProductDTO{}, err
+	}
+	p.Stock = int32(stock)
+
+	if v, ok := Lookup(record, m.path(Images)); ok && v != nil {
+		list, ok := v.([]interface{})
+		if !ok {
+			return entity.AddOrUpdateProductDTO{}, fmt.Errorf("field %q should be a list", Images)
+		}
+		for _, item := range list {
+			s, ok := item.(string)
+			if !ok {
+				return entity.AddOrUpdateProductDTO{}, fmt.Errorf("field %q should be a list of strings", Images)
+			}
+			p.Images = append(p.Images, s)
+		}
+	}
+
+	if v, ok := Lookup(record, m.path(Attributes)); ok && v != nil {
+		attributes, ok := v.(map[string]interface{})
+		if !ok {
+			return entity.AddOrUpdateProductDTO{}, fmt.Errorf("field %q should be an object", Attributes)
+		}
+		p.Attributes = entity.ProductAttributes(attributes)
+	}
+
+	err = p.ProductDetails.Validate()
+	if err != nil {
+		return entity.AddOrUpdateProductDTO{}, err
+	}
+
+	return p, nil
+}
+
+// Products maps a list of upstream records. Records that can't be mapped are
+// logged and skipped, so one bad record doesn't block the whole feed.
+func (m Mapping) Products(source string, records []interface{}) []entity.AddOrUpdateProductDTO {
+	products := make([]entity.AddOrUpdateProductDTO, 0, len(records))
+	for i, record := range records {
+		p, err := m.Product(source, record)
+		if err != nil {
+			slog.Warn("skipping upstream product",
+				"source", source,
+				"record", i,
+				"error", err,
+			)
+			continue
+		}
+		products = append(products, p)
+	}
+	return products
+}
+
+// str reads a string field. Numbers are accepted too, as upstream IDs often are.
+func (m Mapping) str(record interface{}, field string) (string, error) {
+	v, ok := Lookup(record, m.path(field))
+	if !ok || v == nil {
+		return "", nil
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("field %q should be a string", field)
+	}
+}
+
+// num reads a number field. Numeric strings are accepted too.
+func (m Mapping) num(record interface{}, field string) (float64, error) {
+	v, ok := Lookup(record, m.path(field))
+	if !ok || v == nil {
+		return 0, nil
+	}
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("field %q should be a number", field)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("field %q should be a number", field)
+	}
+}
+
+// Lookup returns the value at a dot separated path in a decoded JSON document.
+// An empty path returns the document itself.
+func Lookup(doc interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return doc, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		doc, ok = obj[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return doc, true
+}
@@ -0,0 +1,118 @@
+package fieldmap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, s string) interface{} {
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestMapping_Product(t *testing.T) {
+	mapping := Mapping{
+		ExternalID: "code",
+		Name:       "title",
+		Category:   "group.name",
+		Price:      "pricing.amount",
+		Stock:      "qty",
+		Images:     "media",
+		Attributes: "props",
+	}
+	require.NoError(t, mapping.Validate())
+
+	tests := []struct {
+		name    string
+		record  string
+		want    entity.AddOrUpdateProductDTO
+		wantErr bool
+	}{
+		{
+			name: "all fields",
+			record: `{"code": 17, "title": "redmi", "group": {"name": "phones"},
+				"pricing": {"amount": "199.90"}, "qty": 4, "brand": "Xiaomi", "sku": "XM-1",
+				"media": ["a.png"], "props": {"color": "black"}}`,
+			want: entity.AddOrUpdateProductDTO{
+				ProductName:      "redmi",
+				CategoryName:     "phones",
+				ProductSourceRef: entity.ProductSourceRef{Source: "feed", ExternalID: "17"},
+				ProductDetails: entity.ProductDetails{
+					Price:      199.9,
+					Stock:      4,
+					Brand:      "Xiaomi",
+					SKU:        "XM-1",
+					Images:     []string{"a.png"},
+					Attributes: entity.ProductAttributes{"color": "black"},
+				},
+			},
+		},
+		{
+			name:   "only required fields",
+			record: `{"code": "a1", "title": "redmi", "group": {"name": "phones"}}`,
+			want: entity.AddOrUpdateProductDTO{
+				ProductName:      "redmi",
+				CategoryName:     "phones",
+				ProductSourceRef: entity.ProductSourceRef{Source: "feed", ExternalID: "a1"},
+			},
+		},
+		{
+			name:    "missing category",
+			record:  `{"code": "a1", "title": "redmi"}`,
+			wantErr: true,
+		},
+		{
+			name:    "price is not a number",
+			record:  `{"code": "a1", "title": "redmi", "group": {"name": "phones"}, "pricing": {"amount": "cheap"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative stock",
+			record:  `{"code": "a1", "title": "redmi", "group": {"name": "phones"}, "qty": -1}`,
+			wantErr: true,
+		},
+		{
+			name:    "nested attribute",
+			record:  `{"code": "a1", "title": "redmi", "group": {"name": "phones"}, "props": {"size": [1, 2]}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mapping.Product("feed", decode(t, tt.record))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMapping_Validate(t *testing.T) {
+	require.NoError(t, Mapping{}.Validate())
+	require.Error(t, Mapping{"title": "name"}.Validate())
+}
+
+func TestLookup(t *testing.T) {
+	doc := decode(t, `{"data": {"items": [1, 2]}, "total": 2}`)
+
+	v, ok := Lookup(doc, "data.items")
+	require.True(t, ok)
+	require.Len(t, v, 2)
+
+	_, ok = Lookup(doc, "data.total")
+	require.False(t, ok)
+
+	_, ok = Lookup(doc, "total.value")
+	require.False(t, ok)
+
+	v, ok = Lookup(doc, "")
+	require.True(t, ok)
+	require.Equal(t, doc, v)
+}
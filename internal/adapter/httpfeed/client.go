@@ -0,0 +1,117 @@
+// Package httpfeed reads products from a paginated JSON-over-HTTP feed with a
+// configurable field mapping.
+package httpfeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/fieldmap"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+type Config struct {
+	URL string
+	// ItemsPath is the path of the records list in the response, empty if the
+	// response is the list itself.
+	ItemsPath string
+	// TotalPath is the path of the feed size in the response. Without it the
+	// feed ends at the first page shorter than the limit.
+	TotalPath   string
+	OffsetParam string
+	LimitParam  string
+	Fields      fieldmap.Mapping
+}
+
+type productClient struct {
+	name   string
+	url    *url.URL
+	config Config
+	client http.Client
+}
+
+func NewProductClient(name string, config Config) (*productClient, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid feed url %q", config.URL)
+	}
+	err = config.Fields.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if config.OffsetParam == "" {
+		config.OffsetParam = "offset"
+	}
+	if config.LimitParam == "" {
+		config.LimitParam = "limit"
+	}
+
+	return &productClient{
+		name:   name,
+		url:    u,
+		config: config,
+		client: *http.DefaultClient,
+	}, nil
+}
+
+func (c *productClient) Source() string {
+	return c.name
+}
+
+func (c *productClient) GetNewProducts(ctx context.Context, offset, limit int) (entity.ProductFeedPage, error) {
+	u := *c.url
+	q := u.Query()
+	q.Set(c.config.OffsetParam, strconv.Itoa(offset))
+	q.Set(c.config.LimitParam, strconv.Itoa(limit))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return entity.ProductFeedPage{}, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return entity.ProductFeedPage{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return entity.ProductFeedPage{}, fmt.Errorf("feed responded with status %d", resp.StatusCode)
+	}
+
+	var body interface{}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return entity.ProductFeedPage{}, err
+	}
+
+	v, _ := fieldmap.Lookup(body, c.config.ItemsPath)
+	records, ok := v.([]interface{})
+	if !ok {
+		return entity.ProductFeedPage{}, fmt.Errorf("no records list at %q in the feed response", c.config.ItemsPath)
+	}
+
+	page := entity.ProductFeedPage{
+		Products: c.config.Fields.Products(c.name, records),
+		Fetched:  len(records),
+	}
+
+	total, ok := fieldmap.Lookup(body, c.config.TotalPath)
+	switch n, isNumber := total.(float64); {
+	case c.config.TotalPath != "" && ok && isNumber:
+		page.Total = int(n)
+	case c.config.TotalPath != "":
+		return entity.ProductFeedPage{}, fmt.Errorf("no total number at %q in the feed response", c.config.TotalPath)
+	case len(records) < limit:
+		page.Total = offset + len(records)
+	default:
+		page.Total = offset + len(records) + 1
+	}
+
+	return page, nil
+}
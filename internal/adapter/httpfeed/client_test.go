@@ -0,0 +1,125 @@
+package httpfeed
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/fieldmap"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func newFeed(t *testing.T, total int, withTotal bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token") != "secret" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		from, _ := strconv.Atoi(r.URL.Query().Get("from"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+		items := make([]map[string]interface{}, 0)
+		for i := from; i < from+size && i < total; i++ {
+			item := map[string]interface{}{
+				"sku":   "SKU-" + strconv.Itoa(i),
+				"label": "item " + strconv.Itoa(i),
+				"dept":  map[string]interface{}{"title": "tools"},
+				"cost":  10.5,
+			}
+			if i == 1 {
+				delete(item, "label")
+			}
+			items = append(items, item)
+		}
+
+		resp := map[string]interface{}{"data": map[string]interface{}{"items": items}}
+		if withTotal {
+			resp["meta"] = map[string]interface{}{"count": total}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProductClient_GetNewProducts(t *testing.T) {
+	config := func(url string) Config {
+		return Config{
+			URL:         url + "/export?token=secret",
+			ItemsPath:   "data.items",
+			TotalPath:   "meta.count",
+			OffsetParam: "from",
+			LimitParam:  "size",
+			Fields: fieldmap.Mapping{
+				fieldmap.ExternalID: "sku",
+				fieldmap.Name:       "label",
+				fieldmap.Category:   "dept.title",
+				fieldmap.Price:      "cost",
+				fieldmap.SKU:        "sku",
+			},
+		}
+	}
+
+	t.Run("with total", func(t *testing.T) {
+		client, err := NewProductClient("tools", config(newFeed(t, 5, true).URL))
+		require.NoError(t, err)
+
+		page, err := client.GetNewProducts(context.Background(), 0, 3)
+		require.NoError(t, err)
+		require.Equal(t, 5, page.Total)
+		require.Equal(t, 3, page.Fetched)
+		require.Len(t, page.Products, 2, "record without a name is skipped")
+		require.Equal(t, entity.AddOrUpdateProductDTO{
+			ProductName:      "item 0",
+			CategoryName:     "tools",
+			ProductSourceRef: entity.ProductSourceRef{Source: "tools", ExternalID: "SKU-0"},
+			ProductDetails:   entity.ProductDetails{Price: 10.5, SKU: "SKU-0"},
+		}, page.Products[0])
+	})
+
+	t.Run("without total", func(t *testing.T) {
+		c := config(newFeed(t, 5, false).URL)
+		c.TotalPath = ""
+		client, err := NewProductClient("tools", c)
+		require.NoError(t, err)
+
+		page, err := client.GetNewProducts(context.Background(), 0, 3)
+		require.NoError(t, err)
+		require.Greater(t, page.Total, 3)
+
+		page, err = client.GetNewProducts(context.Background(), 3, 3)
+		require.NoError(t, err)
+		require.Equal(t, 2, page.Fetched)
+		require.Equal(t, 5, page.Total)
+	})
+
+	t.Run("missing total", func(t *testing.T) {
+		client, err := NewProductClient("tools", config(newFeed(t, 5, false).URL))
+		require.NoError(t, err)
+
+		_, err = client.GetNewProducts(context.Background(), 0, 3)
+		require.Error(t, err)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		c := config(newFeed(t, 5, true).URL)
+		c.URL = c.URL[:len(c.URL)-len("secret")]
+		client, err := NewProductClient("tools", c)
+		require.NoError(t, err)
+
+		_, err = client.GetNewProducts(context.Background(), 0, 3)
+		require.ErrorContains(t, err, "403")
+	})
+}
+
+func TestNewProductClient(t *testing.T) {
+	_, err := NewProductClient("feed", Config{URL: "not a url"})
+	require.Error(t, err)
+
+	_, err = NewProductClient("feed", Config{URL: "http://example.com", Fields: fieldmap.Mapping{"title": "name"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.APIKeyStorage = new(apiKeyStorage)
+
+type apiKeyStorage struct {
+	store *Store
+}
+
+func NewAPIKeyStorage(store *Store) *apiKeyStorage {
+	return &apiKeyStorage{
+		store: store,
+	}
+}
+
+func (ks *apiKeyStorage) Create(ctx context.Context, key entity.APIKey) (entity.APIKey, error) {
+	s := ks.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.apiKeysByHash[key.Hash]; ok {
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrAlreadyExists, "")
+	}
+	if _, ok := s.users[key.CreatedBy]; key.CreatedBy != 0 && !ok {
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	s.lastAPIKeyID++
+	key.ID = s.lastAPIKeyID
+	key.RevokedAt = nil
+	key.LastUsedAt = nil
+	key = copyAPIKey(key)
+	s.apiKeys[key.ID] = key
+	s.apiKeysByHash[key.Hash] = key.ID
+
+	return copyAPIKey(key), nil
+}
+
+func (ks *apiKeyStorage) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	s := ks.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.apiKeysByHash[hash]
+	if !ok {
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return copyAPIKey(s.apiKeys[id]), nil
+}
+
+// GetAll returns all API keys, revoked ones included, newest first.
+func (ks *apiKeyStorage) GetAll(ctx context.Context) ([]entity.APIKey, error) {
+	s := ks.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]entity.APIKey, 0, len(s.apiKeys))
+	for _, key := range s.apiKeys {
+		keys = append(keys, copyAPIKey(key))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID > keys[j].ID
+	})
+
+	return keys, nil
+}
+
+// Revoke marks a key revoked. Keys that don't exist or are already revoked
+// are reported as not found.
+func (ks *apiKeyStorage) Revoke(ctx context.Context, ID int64, revokedAt time.Time) error {
+	s := ks.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.apiKeys[ID]
+	if !ok || key.RevokedAt != nil {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	key.RevokedAt = &revokedAt
+	s.apiKeys[ID] = key
+	return nil
+}
+
+func (ks *apiKeyStorage) Touch(ctx context.Context, ID int64, lastUsedAt time.Time) error {
+	s := ks.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.apiKeys[ID]
+	if !ok {
+		return nil
+	}
+
+	key.LastUsedAt = &lastUsedAt
+	s.apiKeys[ID] = key
+	return nil
+}
+
+func copyAPIKey(key entity.APIKey) entity.APIKey {
+	key.Scopes = append([]entity.Permission{}, key.Scopes...)
+	key.ExpiresAt = cloneTime(key.ExpiresAt)
+	key.RevokedAt = cloneTime(key.RevokedAt)
+	key.LastUsedAt = cloneTime(key.LastUsedAt)
+	return key
+}
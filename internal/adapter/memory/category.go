@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.CategoryStorage = new(categoryStorage)
+
+type categoryStorage struct {
+	store *Store
+}
+
+func NewCategoryStorage(store *Store) *categoryStorage {
+	return &categoryStorage{
+		store: store,
+	}
+}
+
+func (cs *categoryStorage) Add(ctx context.Context, category entity.AddCategoryDTO) error {
+	s := cs.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.TrimSpace(category.Name) == "" {
+		return conflictError("category_name_check")
+	}
+	if _, ok := s.categoriesByName[nameKey(category.Name)]; ok {
+		return errors.NewDomainError(errors.ErrAlreadyExists, "")
+	}
+	if category.ParentID != nil {
+		if _, ok := s.categories[*category.ParentID]; !ok {
+			return errors.NewDomainError(errors.ErrCategoryNotFound, "parent")
+		}
+	}
+
+	s.lastCategoryID++
+	s.putCategory(entity.Category{
+		ID:       s.lastCategoryID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
+	}, nil)
+	return nil
+}
+
+func (cs *categoryStorage) GetAll(ctx context.Context) ([]entity.Category, error) {
+	s := cs.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cats := make([]entity.Category, 0, len(s.categories))
+	for _, c := range s.categories {
+		cats = append(cats, copyCategory(c))
+	}
+	sortCategories(cats)
+	return cats, nil
+}
+
+// GetSubtree returns the category with the given ID followed by all of its
+// descendants.
+func (cs *categoryStorage) GetSubtree(ctx context.Context, ID int64) ([]entity.Category, error) {
+	s := cs.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cats := s.subtree(ID)
+	if len(cats) == 0 {
+		return nil, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	for i, c := range cats {
+		cats[i] = copyCategory(c)
+	}
+	return cats, nil
+}
+
+// GetAncestors returns the path from the root down to the category with the
+// given ID, the category itself included.
+func (cs *categoryStorage) GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error) {
+	s := cs.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var cats []entity.Category
+	for c, ok := s.categories[ID]; ok; {
+		cats = append([]entity.Category{copyCategory(c)}, cats...)
+		if c.ParentID == nil {
+			break
+		}
+		c, ok = s.categories[*c.ParentID]
+	}
+	if len(cats) == 0 {
+		return nil, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return cats, nil
+}
+
+// Move re-parents a category. The store is locked, so that two moves can't
+// build a cycle together.
+func (cs *categoryStorage) Move(ctx context.Context, category entity.MoveCategoryDTO) error {
+	s := cs.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if category.NewParentID != nil {
+		for _, c := range s.subtree(category.CategoryID) {
+			if c.ID == *category.NewParentID {
+				return errors.NewDomainError(errors.ErrCategoryCycle, "")
+			}
+		}
+	}
+
+	c, ok := s.categories[category.CategoryID]
+	if !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	if category.NewParentID != nil {
+		if _, ok := s.categories[*category.NewParentID]; !ok {
+			return errors.NewDomainError(errors.ErrCategoryNotFound, "parent")
+		}
+	}
+
+	c.ParentID = category.NewParentID
+	s.putCategory(c, nil)
+	return nil
+}
+
+func (cs *categoryStorage) UpdateName(ctx context.Context, category entity.UpdateCategoryNameDTO) error {
+	s := cs.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.categories[category.CategoryID]
+	if !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	if strings.TrimSpace(category.NewName) == "" {
+		return conflictError("category_name_check")
+	}
+	if id, ok := s.categoriesByName[nameKey(category.NewName)]; ok && id != c.ID {
+		return errors.NewDomainError(errors.ErrAlreadyExists, "")
+	}
+
+	c.Name = category.NewName
+	s.putCategory(c, nil)
+	return nil
+}
+
+// Delete removes a category together with its subcategories. Their products
+// stay, without these categories.
+func (cs *categoryStorage) Delete(ctx context.Context, ID int64) error {
+	s := cs.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.categories[ID]; !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	s.deleteCategory(ID)
+	return nil
+}
+
+func copyCategory(c entity.Category) entity.Category {
+	c.ParentID = cloneID(c.ParentID)
+	return c
+}
+
+func sortCategories(cats []entity.Category) {
+	sort.Slice(cats, func(i, j int) bool {
+		return cats[i].ID < cats[j].ID
+	})
+}
@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/storagetest"
+)
+
+func Test_conformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storages {
+		store := NewStore()
+		return storagetest.Storages{
+			Product:         NewProductStorage(store),
+			Category:        NewCategoryStorage(store),
+			User:            NewUserStorage(store),
+			Session:         NewSessionStorage(store),
+			SyncState:       NewSyncStateStorage(store),
+			ReconcileReport: NewReconcileReportStorage(store),
+			APIKey:          NewAPIKeyStorage(store),
+			MFA:             NewMFAStorage(store),
+			PasswordReset:   NewPasswordResetStorage(store),
+			LoginAttempt:    NewLoginAttemptStorage(),
+		}
+	})
+}
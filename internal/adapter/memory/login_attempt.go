@@ -0,0 +1,92 @@
+// Package memory implements storages that keep their data in the memory of
+// the process. They don't share it between replicas.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.LoginAttemptStorage = new(loginAttemptStorage)
+
+// pruneEvery is the number of recorded failures between the removals of
+// counters that can't lock anything anymore.
+const pruneEvery = 1024
+
+type loginAttemptStorage struct {
+	mu       sync.Mutex
+	attempts map[string]entity.LoginAttempts
+	writes   int
+}
+
+func NewLoginAttemptStorage() *loginAttemptStorage {
+	return &loginAttemptStorage{
+		attempts: make(map[string]entity.LoginAttempts),
+	}
+}
+
+func (ls *loginAttemptStorage) Get(ctx context.Context, key string) (entity.LoginAttempts, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	attempts, ok := ls.attempts[key]
+	if !ok {
+		return entity.LoginAttempts{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return attempts, nil
+}
+
+func (ls *loginAttemptStorage) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (entity.LoginAttempts, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.writes++
+	if ls.writes%pruneEvery == 0 {
+		ls.prune(now, window)
+	}
+
+	attempts, ok := ls.attempts[key]
+	if !ok || attempts.LastFailure.Before(now.Add(-window)) {
+		attempts.Key = key
+		attempts.Failures = 0
+	}
+	attempts.Failures++
+	attempts.LastFailure = now
+	ls.attempts[key] = attempts
+
+	return attempts, nil
+}
+
+func (ls *loginAttemptStorage) Lock(ctx context.Context, key string, until time.Time) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	attempts, ok := ls.attempts[key]
+	if ok && until.After(attempts.LockedUntil) {
+		attempts.LockedUntil = until
+		ls.attempts[key] = attempts
+	}
+	return nil
+}
+
+func (ls *loginAttemptStorage) Reset(ctx context.Context, key string) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	delete(ls.attempts, key)
+	return nil
+}
+
+// prune drops the counters that are out of the window and not locked.
+func (ls *loginAttemptStorage) prune(now time.Time, window time.Duration) {
+	for key, attempts := range ls.attempts {
+		if attempts.LastFailure.Before(now.Add(-window)) && !attempts.IsLocked(now) {
+			delete(ls.attempts, key)
+		}
+	}
+}
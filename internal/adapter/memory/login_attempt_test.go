@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loginAttemptStorage_prune(t *testing.T) {
+	storage := NewLoginAttemptStorage()
+	ctx := context.Background()
+	now := time.Now()
+
+	_, err := storage.RecordFailure(ctx, "ip:10.0.0.1", now.Add(-time.Hour), time.Minute)
+	require.NoError(t, err)
+	_, err = storage.RecordFailure(ctx, "ip:10.0.0.2", now.Add(-time.Hour), time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, storage.Lock(ctx, "ip:10.0.0.2", now.Add(time.Hour)))
+
+	for i := 0; i < pruneEvery; i++ {
+		_, err = storage.RecordFailure(ctx, "login:login1", now, time.Minute)
+		require.NoError(t, err)
+	}
+
+	_, err = storage.Get(ctx, "ip:10.0.0.1")
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+	_, err = storage.Get(ctx, "ip:10.0.0.2")
+	require.NoError(t, err)
+}
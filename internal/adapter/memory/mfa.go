@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.MFAStorage = new(mfaStorage)
+
+type mfaStorage struct {
+	store *Store
+}
+
+func NewMFAStorage(store *Store) *mfaStorage {
+	return &mfaStorage{
+		store: store,
+	}
+}
+
+func (ms *mfaStorage) Get(ctx context.Context, userID int64) (entity.MFA, error) {
+	s := ms.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mfa, ok := s.mfa[userID]
+	if !ok {
+		return entity.MFA{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	mfa.Secret = append([]byte{}, mfa.Secret...)
+	return mfa, nil
+}
+
+// Save stores a pending enrollment. An enabled one is never replaced, that
+// is reported as ErrAlreadyExists.
+func (ms *mfaStorage) Save(ctx context.Context, mfa entity.MFA, recoveryCodes []string) error {
+	s := ms.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.mfa[mfa.UserID]; ok && old.Enabled {
+		return errors.NewDomainError(errors.ErrAlreadyExists, "two-factor authentication is already enabled")
+	}
+	if _, ok := s.users[mfa.UserID]; !ok {
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	s.mfa[mfa.UserID] = entity.MFA{
+		UserID: mfa.UserID,
+		Secret: append([]byte{}, mfa.Secret...),
+	}
+	codes := make(map[string]bool, len(recoveryCodes))
+	for _, hash := range recoveryCodes {
+		codes[hash] = false
+	}
+	s.recoveryCodes[mfa.UserID] = codes
+
+	return nil
+}
+
+func (ms *mfaStorage) Enable(ctx context.Context, userID int64) error {
+	s := ms.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mfa, ok := s.mfa[userID]
+	if !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	mfa.Enabled = true
+	s.mfa[userID] = mfa
+	return nil
+}
+
+// Delete removes the enrollment. The recovery codes go with it.
+func (ms *mfaStorage) Delete(ctx context.Context, userID int64) error {
+	s := ms.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.mfa[userID]; !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	delete(s.mfa, userID)
+	delete(s.recoveryCodes, userID)
+	return nil
+}
+
+// UseStep advances the last used step under the lock of the store, so that
+// a code sent twice at once is accepted only once.
+func (ms *mfaStorage) UseStep(ctx context.Context, userID, step int64) error {
+	s := ms.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mfa, ok := s.mfa[userID]
+	if !ok || mfa.LastStep >= step {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	mfa.LastStep = step
+	s.mfa[userID] = mfa
+	return nil
+}
+
+func (ms *mfaStorage) UseRecoveryCode(ctx context.Context, userID int64, hash string, usedAt time.Time) error {
+	s := ms.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	used, ok := s.recoveryCodes[userID][hash]
+	if !ok || used {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	s.recoveryCodes[userID][hash] = true
+	return nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.PasswordResetStorage = new(passwordResetStorage)
+
+type passwordResetStorage struct {
+	store *Store
+}
+
+func NewPasswordResetStorage(store *Store) *passwordResetStorage {
+	return &passwordResetStorage{
+		store: store,
+	}
+}
+
+// Create stores a reset token. The other tokens of the user are deleted, so
+// that only the latest link works, and so are the expired ones.
+func (rs *passwordResetStorage) Create(ctx context.Context, reset entity.PasswordReset) error {
+	s := rs.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[reset.UserID]; !ok {
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	for hash, r := range s.passwordResets {
+		if r.UserID == reset.UserID || r.ExpiresAt.Before(reset.CreatedAt) {
+			delete(s.passwordResets, hash)
+		}
+	}
+	if _, ok := s.passwordResets[reset.Hash]; ok {
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	s.passwordResets[reset.Hash] = passwordReset{PasswordReset: reset}
+	return nil
+}
+
+func (rs *passwordResetStorage) Get(ctx context.Context, hash string, now time.Time) (entity.PasswordReset, error) {
+	s := rs.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.passwordResets[hash]
+	if !ok || r.used || !r.ExpiresAt.After(now) {
+		return entity.PasswordReset{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return r.PasswordReset, nil
+}
+
+func (rs *passwordResetStorage) Use(ctx context.Context, hash string, now time.Time) error {
+	s := rs.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.passwordResets[hash]
+	if !ok || r.used || !r.ExpiresAt.After(now) {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	r.used = true
+	s.passwordResets[hash] = r
+	return nil
+}
@@ -0,0 +1,352 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.ProductStorage = new(productStorage)
+
+type productStorage struct {
+	store *Store
+}
+
+func NewProductStorage(store *Store) *productStorage {
+	return &productStorage{
+		store: store,
+	}
+}
+
+// AddOrUpdateProduct upserts a batch of products, creating their categories
+// as needed. A product from a source is matched by its source reference, any
+// other product by its name, ignoring case. If a product repeats, the details
+// of its first occurrence and the category of its last one are kept, and so
+// are the stored attributes if it has none. A product whose name or SKU is
+// taken by another product is skipped and logged rather than taking it over
+// or failing the batch. Products and categories with blank names are skipped
+// as well.
+func (ps *productStorage) AddOrUpdateProduct(ctx context.Context, products ...entity.AddOrUpdateProductDTO) error {
+	s := ps.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batch []entity.AddOrUpdateProductDTO
+	positions := make(map[syncKey]int)
+	for _, p := range products {
+		if strings.TrimSpace(p.ProductName) == "" {
+			continue
+		}
+		key := newSyncKey(p)
+		if i, ok := positions[key]; ok {
+			batch[i].CategoryName = p.CategoryName
+			continue
+		}
+		positions[key] = len(batch)
+		batch = append(batch, p)
+	}
+
+	var conflicts []entity.ProductConflict
+	for _, p := range batch {
+		row := product{
+			name:    p.ProductName,
+			details: storedDetails(p.ProductDetails),
+			ref:     p.ProductSourceRef,
+		}
+		id, existed := s.syncedProduct(p)
+		if existed {
+			row.id = id
+			row.details.Attributes = keptAttributes(row.details.Attributes, s.products[id].details.Attributes)
+		}
+
+		if conflict := s.productConflict(row); conflict != "" {
+			conflicts = append(conflicts, entity.ProductConflict{
+				ExternalID: p.ExternalID,
+				Name:       p.ProductName,
+				Reason:     fmt.Sprintf("violates %s", conflict),
+			})
+			continue
+		}
+
+		if !existed {
+			s.lastProductID++
+			row.id = s.lastProductID
+		}
+		s.putProduct(row, nil)
+
+		if strings.TrimSpace(p.CategoryName) == "" {
+			continue
+		}
+		categoryID, ok := s.categoriesByName[nameKey(p.CategoryName)]
+		if !ok {
+			s.lastCategoryID++
+			categoryID = s.lastCategoryID
+			s.putCategory(entity.Category{ID: categoryID, Name: p.CategoryName}, nil)
+		}
+		s.link(row.id, categoryID, nil)
+	}
+
+	if len(conflicts) > 0 {
+		slog.Warn("skipped products that clash with other products",
+			"conflicts", conflicts,
+		)
+	}
+
+	return nil
+}
+
+// syncKey identifies a product of a sync batch: by its source reference if
+// it has one, by its name otherwise.
+type syncKey struct {
+	ref  entity.ProductSourceRef
+	name string
+}
+
+func newSyncKey(p entity.AddOrUpdateProductDTO) syncKey {
+	if p.Source != "" {
+		return syncKey{ref: p.ProductSourceRef}
+	}
+	return syncKey{name: nameKey(p.ProductName)}
+}
+
+// syncedProduct returns the ID of the stored product that p updates: the one
+// with its source reference, or the one with its name and no source.
+func (s *Store) syncedProduct(p entity.AddOrUpdateProductDTO) (int64, bool) {
+	if p.Source != "" {
+		id, ok := s.productsByRef[p.ProductSourceRef]
+		return id, ok
+	}
+	id, ok := s.productsByName[nameKey(p.ProductName)]
+	if !ok || s.products[id].ref.Source != "" {
+		return 0, false
+	}
+	return id, true
+}
+
+func (ps *productStorage) Add(ctx context.Context, product entity.AddProductDTO) error {
+	s := ps.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.categories[product.CategoryID]; !ok {
+		return errors.NewDomainError(errors.ErrCategoryNotFound, "")
+	}
+
+	row := productRow(0, product.ProductName, product.ProductDetails)
+	if conflict := s.productConflict(row); conflict != "" {
+		return conflictError(conflict)
+	}
+
+	s.lastProductID++
+	row.id = s.lastProductID
+	s.putProduct(row, nil)
+	s.link(row.id, product.CategoryID, nil)
+	return nil
+}
+
+func (ps *productStorage) GetByID(ctx context.Context, ID int64) (entity.ProductView, error) {
+	s := ps.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.products[ID]
+	if !ok || p.deleted {
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	view := entity.ProductView{
+		ID:               p.id,
+		Name:             p.name,
+		Categories:       make([]entity.Category, 0, len(s.productCategories[ID])),
+		ProductSourceRef: p.ref,
+		ProductDetails:   copyDetails(p.details),
+	}
+	for categoryID := range s.productCategories[ID] {
+		view.Categories = append(view.Categories, copyCategory(s.categories[categoryID]))
+	}
+	sortCategories(view.Categories)
+
+	return view, nil
+}
+
+func (ps *productStorage) GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error) {
+	s := ps.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.categories[query.CategoryID]; !ok {
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrCategoryNotFound, "")
+	}
+
+	inTree := s.categoryFilter(query.CategoryID, query.IncludeDescendants)
+	list := make([]entity.ProductCategoryListItem, 0)
+	for _, p := range s.products {
+		if p.deleted || !hasNamePrefix(p.name, query.NamePrefix) || !s.inCategories(p.id, inTree) {
+			continue
+		}
+		list = append(list, entity.ProductCategoryListItem{ID: p.id, Name: p.name})
+	}
+
+	list, cursor := productPage(list, query.Sort, query.After, query.Limit)
+	return entity.ProductPage{Products: list, NextCursor: cursor}, nil
+}
+
+func (ps *productStorage) UpdateName(ctx context.Context, product entity.UpdateProductNameDTO) error {
+	s := ps.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.products[product.ProductID]
+	if !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	p.name = product.NewName
+	if conflict := s.productConflict(p); conflict != "" {
+		return conflictError(conflict)
+	}
+	s.putProduct(p, nil)
+	return nil
+}
+
+func (ps *productStorage) UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error {
+	s := ps.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.products[product.ProductID]
+	if !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	p.details = storedDetails(product.ProductDetails)
+	if conflict := s.productConflict(p); conflict != "" {
+		return conflictError(conflict)
+	}
+	s.putProduct(p, nil)
+	return nil
+}
+
+func (ps *productStorage) UpdateCategory(ctx context.Context, product entity.UpdateProductCategoryDTO) error {
+	s := ps.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	links := s.productCategories[product.ProductID]
+	if _, ok := links[product.OldCategoryID]; !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	if product.NewCategoryID == product.OldCategoryID {
+		return nil
+	}
+	if _, ok := links[product.NewCategoryID]; ok {
+		return errors.NewDomainError(errors.ErrAlreadyExists, "")
+	}
+	if _, ok := s.categories[product.NewCategoryID]; !ok {
+		return errors.NewDomainError(errors.ErrCategoryNotFound, "")
+	}
+
+	s.unlink(product.ProductID, product.OldCategoryID, nil)
+	s.link(product.ProductID, product.NewCategoryID, nil)
+	return nil
+}
+
+func (ps *productStorage) Delete(ctx context.Context, ID int64) error {
+	s := ps.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.products[ID]; !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	s.deleteProduct(ID, nil)
+	return nil
+}
+
+// conflictError maps a violated constraint to the error the database
+// storages return for it: a taken unique key is reported as already existing,
+// a failed check as a database error.
+func conflictError(constraint string) error {
+	if strings.HasSuffix(constraint, "_check") {
+		return errors.NewDomainError(errors.ErrDB, "violates %s", constraint)
+	}
+	return errors.NewDomainError(errors.ErrAlreadyExists, "")
+}
+
+func productRow(id int64, name string, details entity.ProductDetails) product {
+	return product{id: id, name: name, details: storedDetails(details)}
+}
+
+// categoryFilter returns the set of categories a listing of categoryID
+// covers, nil for all of them if categoryID is 0.
+func (s *Store) categoryFilter(categoryID int64, includeDescendants bool) map[int64]bool {
+	if categoryID == 0 {
+		return nil
+	}
+
+	tree := []entity.Category{{ID: categoryID}}
+	if includeDescendants {
+		tree = s.subtree(categoryID)
+	}
+	ids := make(map[int64]bool, len(tree))
+	for _, c := range tree {
+		ids[c.ID] = true
+	}
+	return ids
+}
+
+// inCategories reports whether the product is in one of the categories, or
+// in any if categories is nil.
+func (s *Store) inCategories(productID int64, categories map[int64]bool) bool {
+	if categories == nil {
+		return true
+	}
+	for categoryID := range s.productCategories[productID] {
+		if categories[categoryID] {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNamePrefix(name, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix))
+}
+
+// productPage sorts list by sort, which falls back to the ID, and returns the
+// page after the cursor with the cursor of the next one.
+func productPage(list []entity.ProductCategoryListItem, sortBy entity.ProductSort, after *entity.ProductCursor, limit int) ([]entity.ProductCategoryListItem, string) {
+	less := func(a, b entity.ProductCategoryListItem) bool {
+		return a.ID < b.ID
+	}
+	switch sortBy {
+	case entity.SortByName:
+		less = func(a, b entity.ProductCategoryListItem) bool {
+			return a.Name < b.Name || a.Name == b.Name && a.ID < b.ID
+		}
+	case entity.SortByNameDesc:
+		less = func(a, b entity.ProductCategoryListItem) bool {
+			return a.Name > b.Name || a.Name == b.Name && a.ID > b.ID
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return less(list[i], list[j]) })
+
+	if after != nil {
+		last := entity.ProductCategoryListItem{ID: after.ID, Name: after.Name}
+		list = list[sort.Search(len(list), func(i int) bool { return less(last, list[i]) }):]
+	}
+
+	if len(list) <= limit {
+		return list, ""
+	}
+	list = list[:limit]
+	last := list[limit-1]
+	return list, entity.ProductCursor{Sort: sortBy, ID: last.ID, Name: last.Name}.Encode()
+}
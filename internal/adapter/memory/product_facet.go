@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+)
+
+var _ service.ProductFacetCounter = new(productStorage)
+
+// facet names used to leave a facet's own filter out of its counts
+const (
+	facetCategory = "category"
+	facetBrand    = "brand"
+	facetPrice    = "price"
+	facetAttr     = "attribute"
+)
+
+// Facets returns a page of products matching query together with the facet
+// counts. Text is matched like Search does.
+func (ps *productStorage) Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error) {
+	s := ps.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filter := query.FacetFilter
+	words := searchWords(filter.Text)
+
+	var (
+		list       = make([]entity.ProductCategoryListItem, 0)
+		categories = make(map[int64]int64)
+		brands     = make(map[string]int64)
+		prices     = make(map[int]int64)
+		attributes = make(map[string]map[string]int64)
+	)
+	for _, p := range s.products {
+		if p.deleted {
+			continue
+		}
+		if _, ok := matchText(p, words); filter.Text != "" && !ok {
+			continue
+		}
+
+		if s.matchFacets(p, filter, "") {
+			list = append(list, entity.ProductCategoryListItem{ID: p.id, Name: p.name})
+		}
+		if s.matchFacets(p, filter, facetCategory) {
+			for categoryID := range s.productCategories[p.id] {
+				categories[categoryID]++
+			}
+		}
+		if p.details.Brand != "" && s.matchFacets(p, filter, facetBrand) {
+			brands[p.details.Brand]++
+		}
+		if s.matchFacets(p, filter, facetPrice) {
+			prices[priceBucket(p.details.Price)]++
+		}
+		if s.matchFacets(p, filter, facetAttr) {
+			// Each attribute is counted with the filters on all other
+			// attributes.
+			for name, v := range p.details.Attributes {
+				if !matchAttributes(p, filter.Attributes, name) {
+					continue
+				}
+				if attributes[name] == nil {
+					attributes[name] = make(map[string]int64)
+				}
+				attributes[name][attributeText(v)]++
+			}
+		}
+	}
+
+	page := entity.FacetPage{
+		Total: int64(len(list)),
+		Facets: entity.Facets{
+			Categories: make([]entity.CategoryFacetCount, 0, len(categories)),
+			Brands:     facetCounts(brands),
+			Prices:     make([]entity.PriceFacetCount, 0, len(prices)),
+			Attributes: make(map[string][]entity.FacetCount, len(attributes)),
+		},
+	}
+	page.Products, page.NextCursor = productPage(list, query.Sort, query.After, query.Limit)
+
+	for id, count := range categories {
+		page.Facets.Categories = append(page.Facets.Categories, entity.CategoryFacetCount{
+			ID:    id,
+			Name:  s.categories[id].Name,
+			Count: count,
+		})
+	}
+	sort.Slice(page.Facets.Categories, func(i, j int) bool {
+		a, b := page.Facets.Categories[i], page.Facets.Categories[j]
+		return a.Count > b.Count || a.Count == b.Count && a.ID < b.ID
+	})
+
+	for bucket, count := range prices {
+		page.Facets.Prices = append(page.Facets.Prices, entity.NewPriceFacetCount(bucket, count))
+	}
+	sort.Slice(page.Facets.Prices, func(i, j int) bool {
+		return page.Facets.Prices[i].Bucket < page.Facets.Prices[j].Bucket
+	})
+
+	for name, counts := range attributes {
+		page.Facets.Attributes[name] = facetCounts(counts)
+	}
+
+	return page, nil
+}
+
+// matchFacets reports whether p matches the facet filters, but the one of
+// the facet named except.
+func (s *Store) matchFacets(p product, filter entity.FacetFilter, except string) bool {
+	if len(filter.CategoryIDs) > 0 && except != facetCategory {
+		ids := make(map[int64]bool, len(filter.CategoryIDs))
+		for _, id := range filter.CategoryIDs {
+			ids[id] = true
+		}
+		if !s.inCategories(p.id, ids) {
+			return false
+		}
+	}
+	if len(filter.Brands) > 0 && except != facetBrand && !contains(filter.Brands, p.details.Brand) {
+		return false
+	}
+	if len(filter.PriceBuckets) > 0 && except != facetPrice {
+		bucket := priceBucket(p.details.Price)
+		found := false
+		for _, b := range filter.PriceBuckets {
+			found = found || b == bucket
+		}
+		if !found {
+			return false
+		}
+	}
+	if except != facetAttr && !matchAttributes(p, filter.Attributes, "") {
+		return false
+	}
+	return true
+}
+
+// matchAttributes reports whether p has one of the selected values of every
+// filtered attribute but except.
+func matchAttributes(p product, filter map[string][]string, except string) bool {
+	for name, values := range filter {
+		if name == except {
+			continue
+		}
+		v, ok := p.details.Attributes[name]
+		if !ok || !contains(values, attributeText(v)) {
+			return false
+		}
+	}
+	return true
+}
+
+// priceBucket numbers the bucket of entity.PriceBucketBounds price is in,
+// like width_bucket does.
+func priceBucket(price float64) int {
+	return sort.Search(len(entity.PriceBucketBounds), func(i int) bool {
+		return price < entity.PriceBucketBounds[i]
+	})
+}
+
+// attributeText returns an attribute value as text, the way the database
+// reads it out of JSON.
+func attributeText(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// facetCounts orders counts by count, the most frequent value first.
+func facetCounts(counts map[string]int64) []entity.FacetCount {
+	list := make([]entity.FacetCount, 0, len(counts))
+	for v, c := range counts {
+		list = append(list, entity.FacetCount{Value: v, Count: c})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Count > list[j].Count || list[i].Count == list[j].Count && list[i].Value < list[j].Value
+	})
+	return list
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
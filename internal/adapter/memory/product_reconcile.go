@@ -0,0 +1,200 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+func (ps *productStorage) GetSourceProducts(ctx context.Context, source string) ([]entity.SourceProduct, error) {
+	s := ps.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	products := make([]entity.SourceProduct, 0)
+	for _, p := range s.products {
+		if p.ref.Source != source {
+			continue
+		}
+		sp := entity.SourceProduct{
+			ID:         p.id,
+			ExternalID: p.ref.ExternalID,
+			Name:       p.name,
+			Categories: make([]string, 0, len(s.productCategories[p.id])),
+			Deleted:    p.deleted,
+		}
+		for categoryID := range s.productCategories[p.id] {
+			sp.Categories = append(sp.Categories, s.categories[categoryID].Name)
+		}
+		sort.Strings(sp.Categories)
+		products = append(products, sp)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID < products[j].ID
+	})
+
+	return products, nil
+}
+
+// ApplyReconcilePlan writes a reconciliation plan at once. A product whose
+// name or SKU is taken by another product is reported as a conflict instead
+// of failing the run. The category of a source product replaces all the
+// categories it had, and a product without attributes keeps its stored ones.
+func (ps *productStorage) ApplyReconcilePlan(ctx context.Context, plan entity.ReconcilePlan) ([]entity.ProductConflict, error) {
+	s := ps.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		conflicts []entity.ProductConflict
+		undo      undoLog
+	)
+	for _, p := range plan.Upserts {
+		sp := undo.mark()
+		if conflict := s.reconcileProduct(p, &undo); conflict != "" {
+			undo.rollbackTo(sp)
+			conflicts = append(conflicts, entity.ProductConflict{
+				ExternalID: p.ExternalID,
+				Name:       p.ProductName,
+				Reason:     "violates " + conflict,
+			})
+		}
+	}
+
+	for _, externalID := range plan.Deletes {
+		id, ok := s.productsByRef[entity.ProductSourceRef{Source: plan.Source, ExternalID: externalID}]
+		if !ok {
+			continue
+		}
+		if plan.Mode == entity.DeleteModeDelete {
+			s.deleteProduct(id, nil)
+			continue
+		}
+		p := s.products[id]
+		p.deleted = true
+		s.putProduct(p, nil)
+	}
+
+	return conflicts, nil
+}
+
+// reconcileProduct upserts one product by its source reference and moves it
+// to its category. It returns the constraint the product would violate, if
+// any, having written part of it.
+func (s *Store) reconcileProduct(p entity.AddOrUpdateProductDTO, undo *undoLog) string {
+	row := product{
+		name:    p.ProductName,
+		details: storedDetails(p.ProductDetails),
+		ref:     p.ProductSourceRef,
+	}
+	id, existed := s.productsByRef[p.ProductSourceRef]
+	if existed {
+		row.id = id
+		row.details.Attributes = keptAttributes(row.details.Attributes, s.products[id].details.Attributes)
+	} else {
+		s.lastProductID++
+		row.id = s.lastProductID
+	}
+	if conflict := s.productConflict(row); conflict != "" {
+		return conflict
+	}
+	s.putProduct(row, undo)
+
+	categoryID, ok := s.categoriesByName[nameKey(p.CategoryName)]
+	if !ok {
+		if strings.TrimSpace(p.CategoryName) == "" {
+			return "category_name_check"
+		}
+		s.lastCategoryID++
+		categoryID = s.lastCategoryID
+		s.putCategory(entity.Category{ID: categoryID, Name: p.CategoryName}, undo)
+	}
+
+	for linked := range s.productCategories[row.id] {
+		if linked != categoryID {
+			s.unlink(row.id, linked, undo)
+		}
+	}
+	s.link(row.id, categoryID, undo)
+	return ""
+}
+
+var _ service.ReconcileReportStorage = new(reconcileReportStorage)
+
+type reconcileReportStorage struct {
+	store *Store
+}
+
+func NewReconcileReportStorage(store *Store) *reconcileReportStorage {
+	return &reconcileReportStorage{
+		store: store,
+	}
+}
+
+func (rs *reconcileReportStorage) Save(ctx context.Context, report entity.ReconcileReport) (entity.ReconcileReport, error) {
+	s := rs.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report, err := copyReport(report)
+	if err != nil {
+		return entity.ReconcileReport{}, err
+	}
+
+	s.lastReportID++
+	report.ID = s.lastReportID
+	s.reports = append(s.reports, report)
+
+	return copyReport(report)
+}
+
+// List returns the latest reports first, of all sources if source is empty.
+func (rs *reconcileReportStorage) List(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error) {
+	s := rs.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reports := make([]entity.ReconcileReport, 0)
+	for i := len(s.reports) - 1; i >= 0 && len(reports) < limit; i-- {
+		if source != "" && s.reports[i].Source != source {
+			continue
+		}
+		report, err := copyReport(s.reports[i])
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// copyReport returns a deep copy of report the way it reads back from JSON.
+func copyReport(report entity.ReconcileReport) (entity.ReconcileReport, error) {
+	id := report.ID
+	b, err := json.Marshal(report)
+	if err != nil {
+		slog.Error("error marshalling reconcile report",
+			"error", err,
+		)
+		return entity.ReconcileReport{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	var res entity.ReconcileReport
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		slog.Error("error unmarshalling reconcile report",
+			"error", err,
+		)
+		return entity.ReconcileReport{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	res.ID = id
+
+	return res, nil
+}
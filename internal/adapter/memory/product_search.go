@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+)
+
+var _ service.ProductSearcher = new(productSearcher)
+
+// highlightWords is the most words a highlight shows.
+const highlightWords = 15
+
+type productSearcher struct {
+	store *Store
+}
+
+func NewProductSearcher(store *Store) *productSearcher {
+	return &productSearcher{
+		store: store,
+	}
+}
+
+// Search matches the products whose name or description contain every word
+// of the text, ignoring case. It has neither the stemming nor the typo
+// tolerance of the full-text search of the database. A word found in the
+// name ranks higher than one found in the description.
+func (ps *productSearcher) Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error) {
+	s := ps.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	words := searchWords(query.Text)
+	inTree := s.categoryFilter(query.CategoryID, query.IncludeDescendants)
+
+	hits := make([]entity.ProductSearchHit, 0)
+	for _, p := range s.products {
+		if p.deleted || !hasNamePrefix(p.name, query.NamePrefix) || !s.inCategories(p.id, inTree) {
+			continue
+		}
+		rank, ok := matchText(p, words)
+		if !ok {
+			continue
+		}
+		hits = append(hits, entity.ProductSearchHit{
+			ID:        p.id,
+			Name:      p.name,
+			Rank:      rank,
+			Highlight: highlight(p.name+" "+p.details.Description, words),
+		})
+	}
+
+	if query.Sort != entity.SortByRelevance {
+		return searchPageBy(hits, query), nil
+	}
+
+	less := func(a, b entity.ProductSearchHit) bool {
+		return a.Rank > b.Rank || a.Rank == b.Rank && a.ID < b.ID
+	}
+	sort.Slice(hits, func(i, j int) bool { return less(hits[i], hits[j]) })
+	if query.After != nil {
+		last := entity.ProductSearchHit{ID: query.After.ID, Rank: query.After.Rank}
+		hits = hits[sort.Search(len(hits), func(i int) bool { return less(last, hits[i]) }):]
+	}
+
+	page := entity.ProductSearchPage{Products: hits}
+	if len(hits) > query.Limit {
+		page.Products = hits[:query.Limit]
+		last := page.Products[query.Limit-1]
+		page.NextCursor = entity.ProductCursor{
+			Sort: query.Sort,
+			ID:   last.ID,
+			Name: last.Name,
+			Rank: last.Rank,
+		}.Encode()
+	}
+	return page, nil
+}
+
+// searchPageBy pages hits in the order of a product listing.
+func searchPageBy(hits []entity.ProductSearchHit, query entity.ProductSearchQuery) entity.ProductSearchPage {
+	byID := make(map[int64]entity.ProductSearchHit, len(hits))
+	list := make([]entity.ProductCategoryListItem, len(hits))
+	for i, h := range hits {
+		byID[h.ID] = h
+		list[i] = entity.ProductCategoryListItem{ID: h.ID, Name: h.Name}
+	}
+
+	list, cursor := productPage(list, query.Sort, query.After, query.Limit)
+	page := entity.ProductSearchPage{
+		Products:   make([]entity.ProductSearchHit, len(list)),
+		NextCursor: cursor,
+	}
+	for i, item := range list {
+		page.Products[i] = byID[item.ID]
+	}
+	return page
+}
+
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchText reports whether every word is in the name or the description of
+// p and ranks the match. Without words nothing matches.
+func matchText(p product, words []string) (float32, bool) {
+	if len(words) == 0 {
+		return 0, false
+	}
+
+	name := strings.ToLower(p.name)
+	description := strings.ToLower(p.details.Description)
+	var rank float32
+	for _, w := range words {
+		switch {
+		case strings.Contains(name, w):
+			rank += 1
+		case strings.Contains(description, w):
+			rank += 0.4
+		default:
+			return 0, false
+		}
+	}
+	return rank / float32(len(words)), true
+}
+
+// highlight HTML-escapes text and wraps its words containing a searched word
+// in <b></b>, showing at most highlightWords words from the first match on.
+func highlight(text string, words []string) string {
+	fields := strings.Fields(text)
+	first := -1
+	for i, f := range fields {
+		fields[i] = html.EscapeString(f)
+		lower := strings.ToLower(f)
+		for _, w := range words {
+			if strings.Contains(lower, w) {
+				fields[i] = "<b>" + fields[i] + "</b>"
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+
+	fields = fields[first:]
+	if len(fields) > highlightWords {
+		fields = fields[:highlightWords]
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_highlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		words []string
+		want  string
+	}{
+		{
+			name:  "match",
+			text:  "Apple smartphone",
+			words: []string{"apple"},
+			want:  "<b>Apple</b> smartphone",
+		},
+		{
+			name:  "escaped markup",
+			text:  `charger <img src=x onerror=alert(1)> "fast"`,
+			words: []string{"charger"},
+			want:  `<b>charger</b> &lt;img src=x onerror=alert(1)&gt; &#34;fast&#34;`,
+		},
+		{
+			name:  "escaped match",
+			text:  "<b>charger</b>",
+			words: []string{"charger"},
+			want:  "<b>&lt;b&gt;charger&lt;/b&gt;</b>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, highlight(tt.text, tt.words))
+		})
+	}
+}
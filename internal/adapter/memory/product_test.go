@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_productStorage_Add(t *testing.T) {
+	store := NewStore()
+	storage := NewProductStorage(store)
+	ctx := context.Background()
+	require.NoError(t, NewCategoryStorage(store).Add(ctx, entity.AddCategoryDTO{Name: "phone"}))
+	require.NoError(t, storage.Add(ctx, entity.AddProductDTO{
+		ProductName:    "iPhone",
+		CategoryID:     1,
+		ProductDetails: entity.ProductDetails{SKU: "SKU-1", Price: 9.999},
+	}))
+
+	tests := []struct {
+		name      string
+		dto       entity.AddProductDTO
+		wantErr   bool
+		errorCode errors.ErrorCode
+	}{
+		{
+			name:      "name exists in another case",
+			dto:       entity.AddProductDTO{ProductName: "IPHONE", CategoryID: 1},
+			wantErr:   true,
+			errorCode: errors.ErrAlreadyExists,
+		},
+		{
+			name: "sku exists",
+			dto: entity.AddProductDTO{ProductName: "Pixel", CategoryID: 1,
+				ProductDetails: entity.ProductDetails{SKU: "SKU-1"}},
+			wantErr:   true,
+			errorCode: errors.ErrAlreadyExists,
+		},
+		{
+			name:      "category not found",
+			dto:       entity.AddProductDTO{ProductName: "Pixel", CategoryID: 2},
+			wantErr:   true,
+			errorCode: errors.ErrCategoryNotFound,
+		},
+		{
+			name:    "success",
+			dto:     entity.AddProductDTO{ProductName: "Pixel", CategoryID: 1},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := storage.Add(ctx, tt.dto)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	product, err := storage.GetByID(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 10.0, product.Price)
+	require.Equal(t, []string{}, product.Images)
+}
+
+func Test_productStorage_concurrent(t *testing.T) {
+	store := NewStore()
+	storage := NewProductStorage(store)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				err := storage.AddOrUpdateProduct(ctx, entity.AddOrUpdateProductDTO{
+					ProductName:  fmt.Sprintf("product %d", j),
+					CategoryName: fmt.Sprintf("category %d", i%2),
+				})
+				require.NoError(t, err)
+				_, err = storage.GetByCategory(ctx, entity.ProductQuery{CategoryID: 1, Limit: 10})
+				require.NoError(t, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, store.products, 50)
+	require.Len(t, store.categories, 2)
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.SessionStorage = new(sessionStorage)
+
+type sessionStorage struct {
+	store *Store
+}
+
+func NewSessionStorage(store *Store) *sessionStorage {
+	return &sessionStorage{
+		store: store,
+	}
+}
+
+func (ss *sessionStorage) GetByID(ctx context.Context, ID int64) (entity.Session, error) {
+	s := ss.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[ID]
+	if !ok {
+		return entity.Session{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return session, nil
+}
+
+// Create opens a session of an existing user. A taken token is reported as
+// already existing.
+func (ss *sessionStorage) Create(ctx context.Context, session entity.Session) (entity.Session, error) {
+	s := ss.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessionsByToken[session.Token]; ok {
+		return entity.Session{}, errors.NewDomainError(errors.ErrAlreadyExists, "")
+	}
+	if _, ok := s.users[session.UserID]; !ok {
+		return entity.Session{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	s.lastSessionID++
+	session.ID = s.lastSessionID
+	s.sessions[session.ID] = session
+	s.sessionsByToken[session.Token] = session.ID
+
+	return session, nil
+}
+
+// GetByUser returns the unexpired sessions of a user, most recently used first.
+func (ss *sessionStorage) GetByUser(ctx context.Context, userID int64) ([]entity.Session, error) {
+	s := ss.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	sessions := make([]entity.Session, 0)
+	for _, session := range s.sessions {
+		if session.UserID == userID && !session.Expiry.Before(now) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		return a.LastUsedAt.After(b.LastUsedAt) || a.LastUsedAt.Equal(b.LastUsedAt) && a.ID > b.ID
+	})
+
+	return sessions, nil
+}
+
+// Rotate replaces the token of a session if it is still oldToken. A token
+// that was rotated meanwhile is reported as not found.
+func (ss *sessionStorage) Rotate(ctx context.Context, ID int64, oldToken, newToken string, lastUsedAt time.Time) error {
+	s := ss.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[ID]
+	if !ok || session.Token != oldToken {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+	if id, ok := s.sessionsByToken[newToken]; ok && id != ID {
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	delete(s.sessionsByToken, oldToken)
+	session.Token = newToken
+	session.LastUsedAt = lastUsedAt
+	s.sessions[ID] = session
+	s.sessionsByToken[newToken] = ID
+
+	return nil
+}
+
+// DeleteByID deletes a session of a user. Sessions of other users are
+// reported as not found.
+func (ss *sessionStorage) DeleteByID(ctx context.Context, userID, ID int64) error {
+	s := ss.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[ID]
+	if !ok || session.UserID != userID {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	s.deleteSession(session)
+	return nil
+}
+
+func (ss *sessionStorage) DeleteByUser(ctx context.Context, userID int64) error {
+	s := ss.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteSessions(func(session entity.Session) bool {
+		return session.UserID == userID
+	})
+	return nil
+}
+
+// DeleteOthers deletes the sessions of a user but the one with ID keepID.
+func (ss *sessionStorage) DeleteOthers(ctx context.Context, userID, keepID int64) error {
+	s := ss.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteSessions(func(session entity.Session) bool {
+		return session.UserID == userID && session.ID != keepID
+	})
+	return nil
+}
+
+func (ss *sessionStorage) DeleteExpired(ctx context.Context) error {
+	s := ss.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.deleteSessions(func(session entity.Session) bool {
+		return session.Expiry.Before(now)
+	})
+	return nil
+}
+
+func (s *Store) deleteSession(session entity.Session) {
+	delete(s.sessionsByToken, session.Token)
+	delete(s.sessions, session.ID)
+}
+
+func (s *Store) deleteSessions(match func(entity.Session) bool) {
+	for _, session := range s.sessions {
+		if match(session) {
+			s.deleteSession(session)
+		}
+	}
+}
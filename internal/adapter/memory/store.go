@@ -0,0 +1,343 @@
+package memory
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+// Store holds the data of the catalog for the storages of this package, so
+// that they keep the invariants the database schema enforces: unique names,
+// foreign keys and cascading deletes. It is safe for concurrent use.
+type Store struct {
+	mu sync.RWMutex
+
+	lastProductID  int64
+	lastCategoryID int64
+	lastUserID     int64
+	lastSessionID  int64
+	lastAPIKeyID   int64
+	lastReportID   int64
+
+	products          map[int64]product
+	productsByName    map[string]int64
+	productsBySKU     map[string]int64
+	productsByRef     map[entity.ProductSourceRef]int64
+	productCategories map[int64]map[int64]struct{}
+
+	categories       map[int64]entity.Category
+	categoriesByName map[string]int64
+
+	users        map[int64]entity.User
+	usersByLogin map[string]int64
+
+	sessions        map[int64]entity.Session
+	sessionsByToken map[string]int64
+
+	apiKeys       map[int64]entity.APIKey
+	apiKeysByHash map[string]int64
+
+	mfa           map[int64]entity.MFA
+	recoveryCodes map[int64]map[string]bool
+
+	passwordResets map[string]passwordReset
+
+	syncStates map[string]entity.SyncState
+	reports    []entity.ReconcileReport
+}
+
+func NewStore() *Store {
+	return &Store{
+		products:          make(map[int64]product),
+		productsByName:    make(map[string]int64),
+		productsBySKU:     make(map[string]int64),
+		productsByRef:     make(map[entity.ProductSourceRef]int64),
+		productCategories: make(map[int64]map[int64]struct{}),
+		categories:        make(map[int64]entity.Category),
+		categoriesByName:  make(map[string]int64),
+		users:             make(map[int64]entity.User),
+		usersByLogin:      make(map[string]int64),
+		sessions:          make(map[int64]entity.Session),
+		sessionsByToken:   make(map[string]int64),
+		apiKeys:           make(map[int64]entity.APIKey),
+		apiKeysByHash:     make(map[string]int64),
+		mfa:               make(map[int64]entity.MFA),
+		recoveryCodes:     make(map[int64]map[string]bool),
+		passwordResets:    make(map[string]passwordReset),
+		syncStates:        make(map[string]entity.SyncState),
+	}
+}
+
+// product is a row of the product table. A deleted product is a tombstone
+// left by reconciliation: it keeps its name but isn't listed.
+type product struct {
+	id      int64
+	name    string
+	details entity.ProductDetails
+	ref     entity.ProductSourceRef
+	deleted bool
+}
+
+type passwordReset struct {
+	entity.PasswordReset
+	used bool
+}
+
+// undoLog records how to revert the writes of an operation, so that one that
+// fails halfway leaves the store as it found it, like a rolled back
+// transaction. A nil log records nothing.
+type undoLog struct {
+	fns []func()
+}
+
+func (u *undoLog) add(fn func()) {
+	if u != nil {
+		u.fns = append(u.fns, fn)
+	}
+}
+
+// mark returns the position to roll back to, like a savepoint.
+func (u *undoLog) mark() int {
+	return len(u.fns)
+}
+
+func (u *undoLog) rollbackTo(mark int) {
+	for i := len(u.fns) - 1; i >= mark; i-- {
+		u.fns[i]()
+	}
+	u.fns = u.fns[:mark]
+}
+
+func (u *undoLog) rollback() {
+	u.rollbackTo(0)
+}
+
+// nameKey is the key of the case-insensitive unique names.
+func nameKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// productConflict returns the unique constraint p violates, if any, named as
+// in the database schema.
+func (s *Store) productConflict(p product) string {
+	if strings.TrimSpace(p.name) == "" {
+		return "product_name_check"
+	}
+	if id, ok := s.productsByName[nameKey(p.name)]; ok && id != p.id {
+		return "product_name_lower_key"
+	}
+	if id, ok := s.productsBySKU[p.details.SKU]; ok && p.details.SKU != "" && id != p.id {
+		return "product_sku_key"
+	}
+	if id, ok := s.productsByRef[p.ref]; ok && p.ref.Source != "" && id != p.id {
+		return "product_source_external_id_key"
+	}
+	return ""
+}
+
+// putProduct inserts or replaces p, which must not violate productConflict.
+func (s *Store) putProduct(p product, undo *undoLog) {
+	old, existed := s.products[p.id]
+	if existed {
+		s.unindexProduct(old)
+		undo.add(func() {
+			s.unindexProduct(s.products[p.id])
+			s.products[p.id] = old
+			s.indexProduct(old)
+		})
+	} else {
+		undo.add(func() {
+			s.unindexProduct(s.products[p.id])
+			delete(s.products, p.id)
+		})
+	}
+
+	s.products[p.id] = p
+	s.indexProduct(p)
+}
+
+// deleteProduct removes a product and its category links.
+func (s *Store) deleteProduct(id int64, undo *undoLog) {
+	for categoryID := range s.productCategories[id] {
+		s.unlink(id, categoryID, undo)
+	}
+
+	old := s.products[id]
+	s.unindexProduct(old)
+	delete(s.products, id)
+	undo.add(func() {
+		s.products[id] = old
+		s.indexProduct(old)
+	})
+}
+
+func (s *Store) indexProduct(p product) {
+	s.productsByName[nameKey(p.name)] = p.id
+	if p.details.SKU != "" {
+		s.productsBySKU[p.details.SKU] = p.id
+	}
+	if p.ref.Source != "" {
+		s.productsByRef[p.ref] = p.id
+	}
+}
+
+func (s *Store) unindexProduct(p product) {
+	delete(s.productsByName, nameKey(p.name))
+	if p.details.SKU != "" {
+		delete(s.productsBySKU, p.details.SKU)
+	}
+	if p.ref.Source != "" {
+		delete(s.productsByRef, p.ref)
+	}
+}
+
+func (s *Store) link(productID, categoryID int64, undo *undoLog) {
+	links, ok := s.productCategories[productID]
+	if !ok {
+		links = make(map[int64]struct{})
+		s.productCategories[productID] = links
+	}
+	if _, ok := links[categoryID]; ok {
+		return
+	}
+	links[categoryID] = struct{}{}
+	undo.add(func() { delete(s.productCategories[productID], categoryID) })
+}
+
+func (s *Store) unlink(productID, categoryID int64, undo *undoLog) {
+	if _, ok := s.productCategories[productID][categoryID]; !ok {
+		return
+	}
+	delete(s.productCategories[productID], categoryID)
+	undo.add(func() { s.link(productID, categoryID, nil) })
+}
+
+// putCategory inserts or replaces c. Its name must be free.
+func (s *Store) putCategory(c entity.Category, undo *undoLog) {
+	c.ParentID = cloneID(c.ParentID)
+	old, existed := s.categories[c.ID]
+	if existed {
+		delete(s.categoriesByName, nameKey(old.Name))
+		undo.add(func() {
+			delete(s.categoriesByName, nameKey(s.categories[c.ID].Name))
+			s.categories[c.ID] = old
+			s.categoriesByName[nameKey(old.Name)] = old.ID
+		})
+	} else {
+		undo.add(func() {
+			delete(s.categoriesByName, nameKey(s.categories[c.ID].Name))
+			delete(s.categories, c.ID)
+		})
+	}
+
+	s.categories[c.ID] = c
+	s.categoriesByName[nameKey(c.Name)] = c.ID
+}
+
+// deleteCategory removes a category with its subtree and their product links.
+func (s *Store) deleteCategory(id int64) {
+	for _, c := range s.subtree(id) {
+		for productID := range s.productCategories {
+			s.unlink(productID, c.ID, nil)
+		}
+		delete(s.categoriesByName, nameKey(c.Name))
+		delete(s.categories, c.ID)
+	}
+}
+
+// subtree returns the category with the given ID and its descendants,
+// ordered by depth and ID, or nothing if there is no such category.
+func (s *Store) subtree(id int64) []entity.Category {
+	root, ok := s.categories[id]
+	if !ok {
+		return nil
+	}
+
+	children := make(map[int64][]entity.Category)
+	for _, c := range s.categories {
+		if c.ParentID != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		}
+	}
+
+	tree := []entity.Category{root}
+	for level := tree; len(level) > 0; {
+		var next []entity.Category
+		for _, c := range level {
+			next = append(next, children[c.ID]...)
+		}
+		sortCategories(next)
+		tree = append(tree, next...)
+		level = next
+	}
+	return tree
+}
+
+func cloneID(id *int64) *int64 {
+	if id == nil {
+		return nil
+	}
+	v := *id
+	return &v
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := *t
+	return &v
+}
+
+// storedDetails returns d as the database stores it: amounts rounded to
+// their numeric scale, empty lists and attributes instead of nil, and the
+// attributes as they read back from JSON.
+func storedDetails(d entity.ProductDetails) entity.ProductDetails {
+	d.Price = roundCents(d.Price)
+	d.DiscountPercentage = roundCents(d.DiscountPercentage)
+	d.Rating = roundCents(d.Rating)
+	d.Images = append([]string{}, d.Images...)
+
+	attributes := entity.ProductAttributes{}
+	if len(d.Attributes) > 0 {
+		b, err := json.Marshal(d.Attributes)
+		if err == nil {
+			err = json.Unmarshal(b, &attributes)
+		}
+		if err != nil {
+			attributes = entity.ProductAttributes{}
+		}
+	}
+	d.Attributes = attributes
+	return d
+}
+
+// keptAttributes returns the attributes a synced product is stored with: the
+// stored ones if the source sends none, since they may have been set through
+// the API.
+func keptAttributes(synced, stored entity.ProductAttributes) entity.ProductAttributes {
+	if len(synced) == 0 {
+		return stored
+	}
+	return synced
+}
+
+// copyDetails returns d with its lists and attributes copied, so that
+// callers can't change the stored ones.
+func copyDetails(d entity.ProductDetails) entity.ProductDetails {
+	d.Images = append([]string{}, d.Images...)
+	attributes := make(entity.ProductAttributes, len(d.Attributes))
+	for k, v := range d.Attributes {
+		attributes[k] = v
+	}
+	d.Attributes = attributes
+	return d
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
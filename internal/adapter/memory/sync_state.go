@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.SyncStateStorage = new(syncStateStorage)
+
+type syncStateStorage struct {
+	store *Store
+}
+
+func NewSyncStateStorage(store *Store) *syncStateStorage {
+	return &syncStateStorage{
+		store: store,
+	}
+}
+
+func (ss *syncStateStorage) Get(ctx context.Context, source string) (entity.SyncState, error) {
+	s := ss.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.syncStates[source]
+	if !ok {
+		return entity.SyncState{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return state, nil
+}
+
+func (ss *syncStateStorage) Save(ctx context.Context, state entity.SyncState) error {
+	s := ss.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state.UpdatedAt = time.Now()
+	s.syncStates[state.Source] = state
+	return nil
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.UserStorage = new(userStorage)
+
+type userStorage struct {
+	store *Store
+}
+
+func NewUserStorage(store *Store) *userStorage {
+	return &userStorage{
+		store: store,
+	}
+}
+
+func (us *userStorage) Create(ctx context.Context, user entity.User) (entity.User, error) {
+	s := us.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user.Role == "" {
+		user.Role = entity.RoleViewer
+	}
+	if strings.TrimSpace(user.Login) == "" {
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "error adding user")
+	}
+	if _, ok := s.usersByLogin[nameKey(user.Login)]; ok {
+		return entity.User{}, errors.NewDomainError(errors.ErrAlreadyExists, "")
+	}
+
+	s.lastUserID++
+	user.ID = s.lastUserID
+	s.users[user.ID] = user
+	s.usersByLogin[nameKey(user.Login)] = user.ID
+
+	return user, nil
+}
+
+func (us *userStorage) GetByLogin(ctx context.Context, login string) (entity.User, error) {
+	s := us.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.usersByLogin[nameKey(login)]
+	if !ok {
+		return entity.User{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return s.users[id], nil
+}
+
+func (us *userStorage) GetByID(ctx context.Context, ID int64) (entity.User, error) {
+	s := us.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[ID]
+	if !ok {
+		return entity.User{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return user, nil
+}
+
+// SetRole changes the role of a user. The store is locked, so that two
+// concurrent demotions can't leave the catalog without an admin.
+func (us *userStorage) SetRole(ctx context.Context, dto entity.SetRoleDTO) (entity.User, error) {
+	s := us.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.usersByLogin[nameKey(dto.Login)]
+	if !ok {
+		return entity.User{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	user := s.users[id]
+
+	if user.Role == entity.RoleAdmin && dto.Role != entity.RoleAdmin && s.admins() <= 1 {
+		return entity.User{}, errors.NewDomainError(errors.ErrLastAdmin, "")
+	}
+
+	user.Role = dto.Role
+	s.users[id] = user
+	return user, nil
+}
+
+func (us *userStorage) HasAdmin(ctx context.Context) (bool, error) {
+	s := us.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.admins() > 0, nil
+}
+
+func (us *userStorage) SetPassword(ctx context.Context, ID int64, password string) error {
+	s := us.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[ID]
+	if !ok {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	user.Password = password
+	s.users[ID] = user
+	return nil
+}
+
+func (s *Store) admins() int {
+	n := 0
+	for _, u := range s.users {
+		if u.Role == entity.RoleAdmin {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,253 @@
+// Package migration applies schema migrations and keeps the schema version
+// in a schema_migrations table, the same one migrate/migrate uses, so that
+// databases migrated by that tool carry on from their version. The database
+// specific part is left to a Driver.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and whether the schema has it.
+type Status struct {
+	Version int64
+	Name    string
+	Applied bool
+}
+
+// Driver runs migrations against one database.
+type Driver interface {
+	// Version returns the schema version, 0 if no migration was ever applied.
+	Version(ctx context.Context) (int64, error)
+	// Begin starts a transaction holding the migration lock, so that
+	// processes migrating at the same time don't apply a migration twice.
+	Begin(ctx context.Context) (Tx, error)
+}
+
+// Tx is a transaction of a Driver.
+type Tx interface {
+	Version(ctx context.Context) (int64, error)
+	Exec(ctx context.Context, sql string) error
+	SetVersion(ctx context.Context, version int64) error
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// Load reads the migrations of fsys, NNNNNN_name.up.sql and
+// NNNNNN_name.down.sql pairs in its migration directory, ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "migration/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, file := range files {
+		m := fileName.FindStringSubmatch(path.Base(file))
+		if m == nil {
+			return nil, fmt.Errorf("migration file %s: name should be NNNNNN_name.up.sql or NNNNNN_name.down.sql", file)
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration file %s: version should be a positive integer", file)
+		}
+
+		sql, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(sql)
+		} else {
+			mig.Down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s should have both an up and a down file", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+type Migrator struct {
+	driver     Driver
+	migrations []Migration
+}
+
+// NewMigrator reads the migrations of fsys, laid out as Load expects.
+func NewMigrator(driver Driver, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		driver:     driver,
+		migrations: migrations,
+	}, nil
+}
+
+// Latest returns the version of the last migration, 0 if there are none.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies all the pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.Goto(ctx, m.Latest())
+}
+
+// Down rolls back the last steps migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	current, err := m.driver.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	i := m.index(current)
+	if i < 0 {
+		return fmt.Errorf("schema version %d has no migration", current)
+	}
+	target := int64(0)
+	if i-steps >= 0 {
+		target = m.migrations[i-steps].Version
+	}
+
+	return m.Goto(ctx, target)
+}
+
+// Goto migrates the schema up or down to version, 0 being the empty schema.
+// Every migration runs in its own transaction, so a failing one leaves the
+// schema at the version before it.
+func (m *Migrator) Goto(ctx context.Context, version int64) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("no migration has version %d", version)
+	}
+
+	for {
+		done, err := m.step(ctx, version)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// step applies the one migration that brings the schema closer to target,
+// under the migration lock, and reports whether the schema is at target.
+func (m *Migrator) step(ctx context.Context, target int64) (bool, error) {
+	tx, err := m.driver.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := tx.Version(ctx)
+	if err != nil {
+		return false, err
+	}
+	if current == target {
+		return true, nil
+	}
+
+	var (
+		sql       string
+		next      int64
+		direction string
+	)
+	if current < target {
+		i := sort.Search(len(m.migrations), func(i int) bool {
+			return m.migrations[i].Version > current
+		})
+		sql, next, direction = m.migrations[i].Up, m.migrations[i].Version, "up"
+	} else {
+		i := m.index(current)
+		if i < 0 {
+			return false, fmt.Errorf("schema version %d has no migration", current)
+		}
+		sql, direction = m.migrations[i].Down, "down"
+		if i > 0 {
+			next = m.migrations[i-1].Version
+		}
+	}
+
+	err = tx.Exec(ctx, sql)
+	if err != nil {
+		return false, fmt.Errorf("migrating %s from version %d: %w", direction, current, err)
+	}
+
+	err = tx.SetVersion(ctx, next)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	slog.Info("migrated schema", "direction", direction, "from", current, "to", next)
+	return next == target, nil
+}
+
+// Status returns the schema version and every migration with whether it is
+// applied.
+func (m *Migrator) Status(ctx context.Context) (int64, []Status, error) {
+	current, err := m.driver.Version(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		statuses[i] = Status{
+			Version: mig.Version,
+			Name:    mig.Name,
+			Applied: mig.Version <= current,
+		}
+	}
+	return current, statuses, nil
+}
+
+func (m *Migrator) index(version int64) int {
+	for i, mig := range m.migrations {
+		if mig.Version == version {
+			return i
+		}
+	}
+	return -1
+}
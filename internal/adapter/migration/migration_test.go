@@ -0,0 +1,176 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Load(t *testing.T) {
+	file := func(sql string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(sql)}
+	}
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"migration/000010_ten.up.sql":   file("up 10"),
+				"migration/000010_ten.down.sql": file("down 10"),
+				"migration/000002_two.up.sql":   file("up 2"),
+				"migration/000002_two.down.sql": file("down 2"),
+			},
+			want: []Migration{
+				{Version: 2, Name: "two", Up: "up 2", Down: "down 2"},
+				{Version: 10, Name: "ten", Up: "up 10", Down: "down 10"},
+			},
+		},
+		{
+			name: "no down file",
+			fsys: fstest.MapFS{
+				"migration/000001_one.up.sql": file("up 1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "two names for a version",
+			fsys: fstest.MapFS{
+				"migration/000001_one.up.sql":   file("up 1"),
+				"migration/000001_uno.down.sql": file("down 1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad file name",
+			fsys: fstest.MapFS{
+				"migration/one.up.sql": file("up 1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "version zero",
+			fsys: fstest.MapFS{
+				"migration/000000_zero.up.sql":   file("up 0"),
+				"migration/000000_zero.down.sql": file("down 0"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.fsys)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// fakeDriver keeps the version and the statements applied in memory.
+type fakeDriver struct {
+	version int64
+	applied []string
+}
+
+func (d *fakeDriver) Version(ctx context.Context) (int64, error) {
+	return d.version, nil
+}
+
+func (d *fakeDriver) Begin(ctx context.Context) (Tx, error) {
+	return &fakeTx{driver: d, version: d.version}, nil
+}
+
+type fakeTx struct {
+	driver  *fakeDriver
+	version int64
+	applied []string
+}
+
+func (t *fakeTx) Version(ctx context.Context) (int64, error) {
+	return t.version, nil
+}
+
+func (t *fakeTx) Exec(ctx context.Context, sql string) error {
+	if sql == "fail" {
+		return errors.New("fail")
+	}
+	t.applied = append(t.applied, sql)
+	return nil
+}
+
+func (t *fakeTx) SetVersion(ctx context.Context, version int64) error {
+	t.version = version
+	return nil
+}
+
+func (t *fakeTx) Commit(ctx context.Context) error {
+	t.driver.version = t.version
+	t.driver.applied = append(t.driver.applied, t.applied...)
+	return nil
+}
+
+func (t *fakeTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+func Test_Migrator(t *testing.T) {
+	file := func(sql string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(sql)}
+	}
+	fsys := fstest.MapFS{
+		"migration/000001_one.up.sql":    file("up 1"),
+		"migration/000001_one.down.sql":  file("down 1"),
+		"migration/000002_two.up.sql":    file("up 2"),
+		"migration/000002_two.down.sql":  file("down 2"),
+		"migration/000005_five.up.sql":   file("fail"),
+		"migration/000005_five.down.sql": file("down 5"),
+	}
+	ctx := context.Background()
+
+	driver := &fakeDriver{}
+	m, err := NewMigrator(driver, fsys)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), m.Latest())
+
+	err = m.Goto(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), driver.version)
+	require.Equal(t, []string{"up 1", "up 2"}, driver.applied)
+
+	current, statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), current)
+	require.Equal(t, []Status{
+		{Version: 1, Name: "one", Applied: true},
+		{Version: 2, Name: "two", Applied: true},
+		{Version: 5, Name: "five", Applied: false},
+	}, statuses)
+
+	// A failing migration leaves the schema at the version before it.
+	err = m.Up(ctx)
+	require.Error(t, err)
+	require.Equal(t, int64(2), driver.version)
+
+	err = m.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), driver.version)
+
+	err = m.Down(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), driver.version)
+	require.Equal(t, []string{"up 1", "up 2", "down 2", "down 1"}, driver.applied)
+
+	err = m.Goto(ctx, 3)
+	require.Error(t, err)
+}
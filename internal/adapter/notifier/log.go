@@ -0,0 +1,38 @@
+// Package notifier delivers notifications to users: by email over SMTP, or
+// into a log for local use.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+)
+
+var _ service.Notifier = new(logNotifier)
+
+// logNotifier writes notifications to a file or to the standard error
+// instead of sending them, so that they can be read when running locally.
+type logNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewLogNotifier(w io.Writer) *logNotifier {
+	return &logNotifier{w: w}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, msg entity.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err := fmt.Fprintf(n.w,
+		"--- notification %s\nTo: %s\nSubject: %s\n\n%s\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body,
+	)
+	return err
+}
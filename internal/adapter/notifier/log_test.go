@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_logNotifier_Notify(t *testing.T) {
+	var b bytes.Buffer
+	n := NewLogNotifier(&b)
+
+	err := n.Notify(context.Background(), entity.Notification{
+		To:      "user@example.com",
+		Subject: "Password reset",
+		Body:    "https://catalog.example.com/reset?token=abc",
+	})
+	require.NoError(t, err)
+	require.Contains(t, b.String(), "To: user@example.com")
+	require.Contains(t, b.String(), "token=abc")
+}
@@ -0,0 +1,128 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMail is a message received by the fake SMTP server.
+type fakeMail struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// newFakeSMTPServer accepts one SMTP session and sends the message it got.
+// It offers AUTH PLAIN but no STARTTLS.
+func newFakeSMTPServer(t *testing.T) (string, <-chan fakeMail) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	mails := make(chan fakeMail, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		tp := textproto.NewConn(conn)
+		var mail fakeMail
+		tp.PrintfLine("220 localhost fake ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250-localhost")
+				tp.PrintfLine("250 AUTH PLAIN")
+			case "AUTH":
+				fields := strings.Fields(line)
+				b, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+				mail.auth = string(b)
+				tp.PrintfLine("235 2.7.0 Authentication successful")
+			case "MAIL":
+				mail.from = strings.TrimSuffix(strings.TrimPrefix(line, "MAIL FROM:<"), ">")
+				tp.PrintfLine("250 OK")
+			case "RCPT":
+				mail.to = append(mail.to, strings.TrimSuffix(strings.TrimPrefix(line, "RCPT TO:<"), ">"))
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				lines, err := tp.ReadDotLines()
+				if err != nil {
+					return
+				}
+				mail.data = strings.Join(lines, "\n")
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 Bye")
+				mails <- mail
+				return
+			default:
+				tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	return l.Addr().String(), mails
+}
+
+func Test_smtpNotifier_Notify(t *testing.T) {
+	addr, mails := newFakeSMTPServer(t)
+
+	n, err := NewSMTPNotifier(SMTPConfig{
+		Addr:     addr,
+		Username: "catalog",
+		Password: "secret",
+		From:     "catalog@example.com",
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = n.Notify(ctx, entity.Notification{
+		To:      "user@example.com",
+		Subject: "Password reset",
+		Body:    "line 1\nline 2\n",
+	})
+	require.NoError(t, err)
+
+	mail := <-mails
+	require.Equal(t, "\x00catalog\x00secret", mail.auth)
+	require.Equal(t, "catalog@example.com", mail.from)
+	require.Equal(t, []string{"user@example.com"}, mail.to)
+
+	msg, err := textproto.NewReader(bufio.NewReader(strings.NewReader(mail.data + "\n"))).ReadMIMEHeader()
+	require.NoError(t, err)
+	require.Equal(t, "user@example.com", msg.Get("To"))
+	require.Equal(t, "Password reset", msg.Get("Subject"))
+	require.Contains(t, mail.data, "line 1\nline 2")
+}
+
+func Test_smtpNotifier_Notify_headerInjection(t *testing.T) {
+	n, err := NewSMTPNotifier(SMTPConfig{Addr: "127.0.0.1:25", From: "catalog@example.com"})
+	require.NoError(t, err)
+
+	err = n.Notify(context.Background(), entity.Notification{
+		To:      "user@example.com\r\nBcc: other@example.com",
+		Subject: "Password reset",
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,89 @@
+// Package source builds the upstream product clients from the configuration.
+package source
+
+import (
+	"fmt"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/dirfeed"
+	"github.com/The-Gleb/product_catalog/internal/adapter/dummyjson"
+	"github.com/The-Gleb/product_catalog/internal/adapter/fieldmap"
+	"github.com/The-Gleb/product_catalog/internal/adapter/httpfeed"
+	"github.com/The-Gleb/product_catalog/internal/config"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+)
+
+// Factory creates a product client of one provider type.
+type Factory func(source config.ProductSource) (service.ProductClient, error)
+
+type registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry returns a registry with the dummyjson, http and dir providers.
+func NewRegistry() *registry {
+	r := &registry{
+		factories: make(map[string]Factory),
+	}
+	r.Register("dummyjson", newDummyJSON)
+	r.Register("http", newHTTPFeed)
+	r.Register("dir", newDirFeed)
+	return r
+}
+
+func (r *registry) Register(kind string, f Factory) *registry {
+	r.factories[kind] = f
+	return r
+}
+
+// Build creates a client for every source. Source names identify the sync
+// checkpoints and the imported products, so they must be unique.
+func (r *registry) Build(sources []config.ProductSource) ([]service.ProductClient, error) {
+	clients := make([]service.ProductClient, 0, len(sources))
+	names := make(map[string]bool, len(sources))
+	for _, s := range sources {
+		if s.Name == "" {
+			return nil, fmt.Errorf("product source of type %q has no name", s.Type)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("duplicate product source %q", s.Name)
+		}
+		names[s.Name] = true
+
+		factory, ok := r.factories[s.Type]
+		if !ok {
+			return nil, fmt.Errorf("product source %q has unknown type %q", s.Name, s.Type)
+		}
+		client, err := factory(s)
+		if err != nil {
+			return nil, fmt.Errorf("product source %q: %w", s.Name, err)
+		}
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func newDummyJSON(s config.ProductSource) (service.ProductClient, error) {
+	if s.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	return dummyjson.NewProductClient(s.Name, s.URL), nil
+}
+
+func newHTTPFeed(s config.ProductSource) (service.ProductClient, error) {
+	return httpfeed.NewProductClient(s.Name, httpfeed.Config{
+		URL:         s.URL,
+		ItemsPath:   s.ItemsPath,
+		TotalPath:   s.TotalPath,
+		OffsetParam: s.OffsetParam,
+		LimitParam:  s.LimitParam,
+		Fields:      fieldmap.Mapping(s.Fields),
+	})
+}
+
+func newDirFeed(s config.ProductSource) (service.ProductClient, error) {
+	return dirfeed.NewProductClient(s.Name, dirfeed.Config{
+		Dir:       s.Dir,
+		ItemsPath: s.ItemsPath,
+		Fields:    fieldmap.Mapping(s.Fields),
+	})
+}
@@ -0,0 +1,79 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/config"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Build(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		sources []config.ProductSource
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all providers",
+			sources: []config.ProductSource{
+				{Name: "dummyjson", Type: "dummyjson", URL: "https://dummyjson.com"},
+				{Name: "partner", Type: "http", URL: "https://partner.example.com/feed", Fields: map[string]string{"name": "title"}},
+				{Name: "warehouse", Type: "dir", Dir: dir},
+			},
+			want: []string{"dummyjson", "partner", "warehouse"},
+		},
+		{
+			name:    "unknown type",
+			sources: []config.ProductSource{{Name: "ftp", Type: "ftp"}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			sources: []config.ProductSource{
+				{Name: "feed", Type: "dummyjson", URL: "https://dummyjson.com"},
+				{Name: "feed", Type: "dir", Dir: dir},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no name",
+			sources: []config.ProductSource{{Type: "dir", Dir: dir}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mapping",
+			sources: []config.ProductSource{{Name: "partner", Type: "http", URL: "https://partner.example.com", Fields: map[string]string{"title": "name"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients, err := NewRegistry().Build(tt.sources)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(clients))
+			for _, c := range clients {
+				names = append(names, c.Source())
+			}
+			require.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := NewRegistry().Register("static", func(s config.ProductSource) (service.ProductClient, error) {
+		return newDummyJSON(config.ProductSource{Name: s.Name, URL: "http://localhost"})
+	})
+
+	clients, err := r.Build([]config.ProductSource{{Name: "static", Type: "static"}})
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+}
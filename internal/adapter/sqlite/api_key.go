@@ -0,0 +1,182 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.APIKeyStorage = new(apiKeyStorage)
+
+type apiKeyStorage struct {
+	db *sql.DB
+}
+
+func NewAPIKeyStorage(db *sql.DB) *apiKeyStorage {
+	return &apiKeyStorage{
+		db: db,
+	}
+}
+
+const apiKeyColumns = `id, name, prefix, hash, scopes, COALESCE(created_by, 0),
+	created_at, expires_at, revoked_at, last_used_at`
+
+func scanAPIKey(row rowScanner) (entity.APIKey, error) {
+	var (
+		key    entity.APIKey
+		scopes string
+	)
+	err := row.Scan(
+		&key.ID, &key.Name, &key.Prefix, &key.Hash, &scopes, &key.CreatedBy,
+		&key.CreatedAt, &key.ExpiresAt, &key.RevokedAt, &key.LastUsedAt,
+	)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+	key.Scopes = make([]entity.Permission, 0)
+	err = json.Unmarshal([]byte(scopes), &key.Scopes)
+	return key, err
+}
+
+func (ks *apiKeyStorage) Create(ctx context.Context, key entity.APIKey) (entity.APIKey, error) {
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []entity.Permission{}
+	}
+	scopesJSON, err := json.Marshal(scopes)
+	if err != nil {
+		slog.Error("error encoding api key scopes",
+			"error", err,
+		)
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	var createdBy *int64
+	if key.CreatedBy != 0 {
+		createdBy = &key.CreatedBy
+	}
+
+	created, err := scanAPIKey(ks.db.QueryRowContext(
+		ctx,
+		`INSERT INTO api_key
+			(name, prefix, hash, scopes, created_by, created_at, expires_at)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?)
+		RETURNING `+apiKeyColumns+`;`,
+		key.Name, key.Prefix, key.Hash, string(scopesJSON), createdBy,
+		timestamp(key.CreatedAt), nullTimestamp(key.ExpiresAt),
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.APIKey{}, errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		slog.Error("error adding api key to db",
+			"error", err,
+		)
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return created, nil
+}
+
+func (ks *apiKeyStorage) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	key, err := scanAPIKey(ks.db.QueryRowContext(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_key
+		WHERE hash = ?;`,
+		hash,
+	))
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.APIKey{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting api key from db",
+			"error", err,
+		)
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return key, nil
+}
+
+// GetAll returns all API keys, revoked ones included, newest first.
+func (ks *apiKeyStorage) GetAll(ctx context.Context) ([]entity.APIKey, error) {
+	rows, err := ks.db.QueryContext(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_key
+		ORDER BY id DESC;`,
+	)
+	if err != nil {
+		slog.Error("error getting api keys from db",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer rows.Close()
+
+	keys := make([]entity.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			slog.Error("error scanning from row",
+				"error", err,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return keys, nil
+}
+
+// Revoke marks a key revoked. Keys that don't exist or are already revoked
+// are reported as not found.
+func (ks *apiKeyStorage) Revoke(ctx context.Context, ID int64, revokedAt time.Time) error {
+	c, err := ks.db.ExecContext(
+		ctx,
+		`UPDATE api_key SET revoked_at = ?
+		WHERE id = ? AND revoked_at IS NULL;`,
+		timestamp(revokedAt), ID,
+	)
+	if err != nil {
+		slog.Error("error revoking api key",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	return nil
+}
+
+func (ks *apiKeyStorage) Touch(ctx context.Context, ID int64, lastUsedAt time.Time) error {
+	_, err := ks.db.ExecContext(
+		ctx,
+		`UPDATE api_key SET last_used_at = ?
+		WHERE id = ?;`,
+		timestamp(lastUsedAt), ID,
+	)
+	if err != nil {
+		slog.Error("error updating api key last use",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.CategoryStorage = new(categoryStorage)
+
+type categoryStorage struct {
+	db *sql.DB
+}
+
+func NewCategoryStorage(db *sql.DB) *categoryStorage {
+	return &categoryStorage{
+		db: db,
+	}
+}
+
+func (s *categoryStorage) Add(ctx context.Context, category entity.AddCategoryDTO) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO "category"
+			(name, parent_id)
+		VALUES
+			(?, ?);`,
+		category.Name,
+		category.ParentID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		if isForeignKeyViolation(err) {
+			return errors.NewDomainError(errors.ErrCategoryNotFound, "parent")
+		}
+		slog.Error("error inserting into category",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	return nil
+}
+
+func (s *categoryStorage) GetAll(ctx context.Context) ([]entity.Category, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT id, name, parent_id FROM category;`,
+	)
+	if err != nil {
+		slog.Error("error selecting from category",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	cats, err := scanCategories(rows)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return cats, nil
+}
+
+// GetSubtree returns the category with the given ID followed by all of its
+// descendants.
+func (s *categoryStorage) GetSubtree(ctx context.Context, ID int64) ([]entity.Category, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`WITH RECURSIVE subtree AS (
+			SELECT id, name, parent_id, 0 AS depth
+			FROM category
+			WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.name, c.parent_id, subtree.depth + 1
+			FROM category c
+			JOIN subtree ON c.parent_id = subtree.id
+		)
+		SELECT id, name, parent_id FROM subtree
+		ORDER BY depth, id;`,
+		ID,
+	)
+	if err != nil {
+		slog.Error("error selecting category subtree",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	cats, err := scanCategories(rows)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	if len(cats) == 0 {
+		return nil, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return cats, nil
+}
+
+// GetAncestors returns the path from the root down to the category with the
+// given ID, the category itself included.
+func (s *categoryStorage) GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`WITH RECURSIVE ancestors AS (
+			SELECT id, name, parent_id, 0 AS depth
+			FROM category
+			WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.name, c.parent_id, ancestors.depth + 1
+			FROM category c
+			JOIN ancestors ON c.id = ancestors.parent_id
+		)
+		SELECT id, name, parent_id FROM ancestors
+		ORDER BY depth DESC;`,
+		ID,
+	)
+	if err != nil {
+		slog.Error("error selecting category ancestors",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	cats, err := scanCategories(rows)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	if len(cats) == 0 {
+		return nil, errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return cats, nil
+}
+
+// Move sets the parent of a category. The transaction holds the write lock
+// of the database from its start, so no concurrent move can build a cycle
+// the check below doesn't see.
+func (s *categoryStorage) Move(ctx context.Context, category entity.MoveCategoryDTO) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	if category.NewParentID != nil {
+		var cycle bool
+		err = tx.QueryRowContext(
+			ctx,
+			`WITH RECURSIVE subtree AS (
+				SELECT id FROM category
+				WHERE id = ?
+				UNION ALL
+				SELECT c.id FROM category c
+				JOIN subtree ON c.parent_id = subtree.id
+			)
+			SELECT EXISTS (SELECT 1 FROM subtree WHERE id = ?);`,
+			category.CategoryID,
+			*category.NewParentID,
+		).Scan(&cycle)
+		if err != nil {
+			slog.Error("error checking category subtree",
+				"error", err,
+			)
+			return errors.NewDomainError(errors.ErrDB, "")
+		}
+		if cycle {
+			return errors.NewDomainError(errors.ErrCategoryCycle, "")
+		}
+	}
+
+	c, err := tx.ExecContext(
+		ctx,
+		`UPDATE category
+		SET parent_id = ?
+		WHERE id = ?;`,
+		category.NewParentID,
+		category.CategoryID,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return errors.NewDomainError(errors.ErrCategoryNotFound, "parent")
+		}
+		slog.Error("error updating category parent",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Error("error committing transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+func (s *categoryStorage) UpdateName(ctx context.Context, category entity.UpdateCategoryNameDTO) error {
+	c, err := s.db.ExecContext(
+		ctx,
+		`UPDATE category
+		SET name = ?
+		WHERE id = ?;`,
+		category.NewName,
+		category.CategoryID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		slog.Error("error updating category name",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
+
+func (s *categoryStorage) Delete(ctx context.Context, ID int64) error {
+	c, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM category
+		WHERE id = ?;`,
+		ID,
+	)
+	if err != nil {
+		slog.Error("error deleting from category",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
+
+// scanCategories reads and closes rows of id, name and parent_id.
+func scanCategories(rows *sql.Rows) ([]entity.Category, error) {
+	defer rows.Close()
+
+	cats := make([]entity.Category, 0)
+	for rows.Next() {
+		var cat entity.Category
+		err := rows.Scan(&cat.ID, &cat.Name, &cat.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		cats = append(cats, cat)
+	}
+	return cats, rows.Err()
+}
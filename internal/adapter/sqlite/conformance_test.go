@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/storagetest"
+)
+
+func Test_conformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storages {
+		db := getTestDB(t)
+		return storagetest.Storages{
+			Product:         NewProductStorage(db),
+			Category:        NewCategoryStorage(db),
+			User:            NewUserStorage(db),
+			Session:         NewSessionStorage(db),
+			SyncState:       NewSyncStateStorage(db),
+			ReconcileReport: NewReconcileReportStorage(db),
+			APIKey:          NewAPIKeyStorage(db),
+			MFA:             NewMFAStorage(db),
+			PasswordReset:   NewPasswordResetStorage(db),
+			LoginAttempt:    NewLoginAttemptStorage(db),
+		}
+	})
+}
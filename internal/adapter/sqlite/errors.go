@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	stdErrors "errors"
+	"regexp"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// SQLite names the unique index or the check constraint an error violates
+// in its message only.
+var (
+	uniqueIndex     = regexp.MustCompile(`index '(\w+)'`)
+	checkConstraint = regexp.MustCompile(`CHECK constraint failed: (\w+)`)
+)
+
+// isUniqueViolation reports whether err violates a unique index or a primary
+// key, the errors Postgres reports as unique_violation.
+func isUniqueViolation(err error) bool {
+	code := errorCode(err)
+	return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+}
+
+func isForeignKeyViolation(err error) bool {
+	return errorCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}
+
+func isCheckViolation(err error) bool {
+	return errorCode(err) == sqlite3.SQLITE_CONSTRAINT_CHECK
+}
+
+// constraintName returns the unique index or the check constraint err
+// violates, or "" if SQLite doesn't name it.
+func constraintName(err error) string {
+	for _, re := range []*regexp.Regexp{uniqueIndex, checkConstraint} {
+		if m := re.FindStringSubmatch(err.Error()); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+func errorCode(err error) int {
+	var sqliteErr *sqlite.Error
+	if stdErrors.As(err, &sqliteErr) {
+		return sqliteErr.Code()
+	}
+	return 0
+}
@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.LoginAttemptStorage = new(loginAttemptStorage)
+
+type loginAttemptStorage struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptStorage(db *sql.DB) *loginAttemptStorage {
+	return &loginAttemptStorage{
+		db: db,
+	}
+}
+
+func scanLoginAttempts(row rowScanner) (entity.LoginAttempts, error) {
+	var (
+		attempts    entity.LoginAttempts
+		lockedUntil *time.Time
+	)
+	err := row.Scan(&attempts.Key, &attempts.Failures, &attempts.LastFailure, &lockedUntil)
+	if lockedUntil != nil {
+		attempts.LockedUntil = *lockedUntil
+	}
+	return attempts, err
+}
+
+func (ls *loginAttemptStorage) Get(ctx context.Context, key string) (entity.LoginAttempts, error) {
+	attempts, err := scanLoginAttempts(ls.db.QueryRowContext(
+		ctx,
+		`SELECT key, failures, last_failure, locked_until FROM login_attempt
+		WHERE key = ?;`,
+		key,
+	))
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.LoginAttempts{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting login attempts from db",
+			"error", err,
+		)
+		return entity.LoginAttempts{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return attempts, nil
+}
+
+// RecordFailure counts the failure in a single upsert, so that concurrent
+// failures are all counted.
+func (ls *loginAttemptStorage) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (entity.LoginAttempts, error) {
+	attempts, err := scanLoginAttempts(ls.db.QueryRowContext(
+		ctx,
+		`INSERT INTO login_attempt
+			(key, failures, last_failure)
+		VALUES
+			(?1, 1, ?2)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE
+				WHEN login_attempt.last_failure < ?3 THEN 1
+				ELSE login_attempt.failures + 1
+			END,
+			last_failure = excluded.last_failure
+		RETURNING key, failures, last_failure, locked_until;`,
+		key, timestamp(now), timestamp(now.Add(-window)),
+	))
+	if err != nil {
+		slog.Error("error recording failed login",
+			"error", err,
+		)
+		return entity.LoginAttempts{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return attempts, nil
+}
+
+// Lock refuses logins under key until the given time. A longer lock that is
+// already in place is kept.
+func (ls *loginAttemptStorage) Lock(ctx context.Context, key string, until time.Time) error {
+	_, err := ls.db.ExecContext(
+		ctx,
+		`UPDATE login_attempt
+		SET locked_until = max(COALESCE(locked_until, ?2), ?2)
+		WHERE key = ?1;`,
+		key, timestamp(until),
+	)
+	if err != nil {
+		slog.Error("error locking login",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+func (ls *loginAttemptStorage) Reset(ctx context.Context, key string) error {
+	_, err := ls.db.ExecContext(
+		ctx,
+		`DELETE FROM login_attempt
+		WHERE key = ?;`,
+		key,
+	)
+	if err != nil {
+		slog.Error("error resetting login attempts",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
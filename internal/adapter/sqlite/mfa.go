@@ -0,0 +1,238 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.MFAStorage = new(mfaStorage)
+
+type mfaStorage struct {
+	db *sql.DB
+}
+
+func NewMFAStorage(db *sql.DB) *mfaStorage {
+	return &mfaStorage{
+		db: db,
+	}
+}
+
+func (ms *mfaStorage) Get(ctx context.Context, userID int64) (entity.MFA, error) {
+	var mfa entity.MFA
+	err := ms.db.QueryRowContext(
+		ctx,
+		`SELECT user_id, secret, enabled, last_step FROM user_mfa
+		WHERE user_id = ?;`,
+		userID,
+	).Scan(&mfa.UserID, &mfa.Secret, &mfa.Enabled, &mfa.LastStep)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.MFA{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting mfa from db",
+			"error", err,
+		)
+		return entity.MFA{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return mfa, nil
+}
+
+// Save stores a pending enrollment. An enabled one is never replaced, that
+// is reported as ErrAlreadyExists.
+func (ms *mfaStorage) Save(ctx context.Context, mfa entity.MFA, recoveryCodes []string) error {
+	tx, err := ms.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	c, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO user_mfa
+			(user_id, secret, enabled, last_step, created_at)
+		VALUES
+			(?, ?, false, 0, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = excluded.secret,
+			last_step = 0,
+			created_at = excluded.created_at
+		WHERE NOT user_mfa.enabled;`,
+		mfa.UserID, mfa.Secret, timestamp(time.Now()),
+	)
+	if err != nil {
+		slog.Error("error saving mfa to db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrAlreadyExists, "two-factor authentication is already enabled")
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`DELETE FROM recovery_code
+		WHERE user_id = ?;`,
+		mfa.UserID,
+	)
+	if err != nil {
+		slog.Error("error deleting recovery codes from db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	for _, hash := range recoveryCodes {
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO recovery_code
+				(user_id, hash)
+			VALUES
+				(?, ?);`,
+			mfa.UserID, hash,
+		)
+		if err != nil {
+			slog.Error("error adding recovery codes to db",
+				"error", err,
+			)
+			return errors.NewDomainError(errors.ErrDB, "")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Error("error committing transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+func (ms *mfaStorage) Enable(ctx context.Context, userID int64) error {
+	c, err := ms.db.ExecContext(
+		ctx,
+		`UPDATE user_mfa
+		SET enabled = true
+		WHERE user_id = ?;`,
+		userID,
+	)
+	if err != nil {
+		slog.Error("error enabling mfa",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
+
+// Delete removes the enrollment. The recovery codes go with it.
+func (ms *mfaStorage) Delete(ctx context.Context, userID int64) error {
+	tx, err := ms.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	c, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM user_mfa
+		WHERE user_id = ?;`,
+		userID,
+	)
+	if err != nil {
+		slog.Error("error deleting mfa from db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`DELETE FROM recovery_code
+		WHERE user_id = ?;`,
+		userID,
+	)
+	if err != nil {
+		slog.Error("error deleting recovery codes from db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Error("error committing transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+// UseStep advances the last used step in a single update, so that a code
+// sent twice at once is accepted only once.
+func (ms *mfaStorage) UseStep(ctx context.Context, userID, step int64) error {
+	c, err := ms.db.ExecContext(
+		ctx,
+		`UPDATE user_mfa
+		SET last_step = ?2
+		WHERE user_id = ?1 AND last_step < ?2;`,
+		userID, step,
+	)
+	if err != nil {
+		slog.Error("error updating mfa step",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
+
+func (ms *mfaStorage) UseRecoveryCode(ctx context.Context, userID int64, hash string, usedAt time.Time) error {
+	c, err := ms.db.ExecContext(
+		ctx,
+		`UPDATE recovery_code
+		SET used_at = ?
+		WHERE user_id = ? AND hash = ? AND used_at IS NULL;`,
+		timestamp(usedAt), userID, hash,
+	)
+	if err != nil {
+		slog.Error("error using recovery code",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
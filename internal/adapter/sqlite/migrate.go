@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	stdErrors "errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/migration"
+)
+
+// Migrations are the schema migrations of SQLite, laid out like the ones of
+// Postgres. They are numbered on their own.
+//
+//go:embed migration/*.sql
+var Migrations embed.FS
+
+// NewMigrator reads the migrations of fsys, laid out like Migrations.
+func NewMigrator(db *sql.DB, fsys fs.FS) (*migration.Migrator, error) {
+	return migration.NewMigrator(migrationDriver{db: db}, fsys)
+}
+
+// migrationDriver runs migrations on SQLite. A transaction that writes holds
+// the lock of the whole database, so no other lock is needed.
+type migrationDriver struct {
+	db *sql.DB
+}
+
+func (d migrationDriver) Version(ctx context.Context) (int64, error) {
+	var exists bool
+	err := d.db.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations');`,
+	).Scan(&exists)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+	return schemaVersion(ctx, d.db)
+}
+
+func (d migrationDriver) Begin(ctx context.Context) (migration.Tx, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			"version" integer NOT NULL PRIMARY KEY,
+			"dirty" boolean NOT NULL
+		);`,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	return migrationTx{tx: tx}, nil
+}
+
+type migrationTx struct {
+	tx *sql.Tx
+}
+
+func (t migrationTx) Version(ctx context.Context) (int64, error) {
+	return schemaVersion(ctx, t.tx)
+}
+
+func (t migrationTx) Exec(ctx context.Context, sql string) error {
+	_, err := t.tx.ExecContext(ctx, sql)
+	return err
+}
+
+func (t migrationTx) SetVersion(ctx context.Context, version int64) error {
+	_, err := t.tx.ExecContext(ctx, `DELETE FROM schema_migrations;`)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	_, err = t.tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES (?, false);`, version)
+	return err
+}
+
+func (t migrationTx) Commit(ctx context.Context) error {
+	return t.tx.Commit()
+}
+
+func (t migrationTx) Rollback(ctx context.Context) error {
+	return t.tx.Rollback()
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// schemaVersion reads the version of schema_migrations, 0 if it is empty.
+func schemaVersion(ctx context.Context, q queryRower) (int64, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := q.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1;`).Scan(&version, &dirty)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("schema is dirty at version %d, fix it and clear schema_migrations.dirty", version)
+	}
+	return version, nil
+}
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/adapter/migration"
+	"github.com/The-Gleb/product_catalog/internal/config"
+	sqliteclient "github.com/The-Gleb/product_catalog/pkg/client/sqlite"
+	"github.com/stretchr/testify/require"
+)
+
+// getTestDB opens a migrated database in a temporary file.
+func getTestDB(t testing.TB) *sql.DB {
+	ctx := context.Background()
+	db, err := sqliteclient.NewClient(ctx, config.Database{
+		Path: filepath.Join(t.TempDir(), "catalog.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	m, err := NewMigrator(db, Migrations)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(ctx))
+	return db
+}
+
+func Test_Migrations(t *testing.T) {
+	migrations, err := migration.Load(Migrations)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		require.Equal(t, int64(i+1), m.Version, "migration versions should have no gaps")
+	}
+}
+
+func Test_migrator(t *testing.T) {
+	db := getTestDB(t)
+	ctx := context.Background()
+
+	m, err := NewMigrator(db, Migrations)
+	require.NoError(t, err)
+
+	current, statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, m.Latest(), current)
+	for _, s := range statuses {
+		require.True(t, s.Applied)
+	}
+
+	require.NoError(t, m.Goto(ctx, 0))
+	current, statuses, err = m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), current)
+	for _, s := range statuses {
+		require.False(t, s.Applied)
+	}
+
+	var tables int
+	err = db.QueryRow(
+		`SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name <> 'schema_migrations';`,
+	).Scan(&tables)
+	require.NoError(t, err)
+	require.Zero(t, tables)
+
+	require.NoError(t, m.Up(ctx))
+	require.Error(t, m.Goto(ctx, m.Latest()+1))
+}
@@ -0,0 +1,12 @@
+DROP TABLE "password_reset";
+DROP TABLE "recovery_code";
+DROP TABLE "user_mfa";
+DROP TABLE "login_attempt";
+DROP TABLE "api_key";
+DROP TABLE "reconcile_report";
+DROP TABLE "sync_state";
+DROP TABLE "session";
+DROP TABLE "user";
+DROP TABLE "product_category";
+DROP TABLE "category";
+DROP TABLE "product";
@@ -0,0 +1,159 @@
+-- The schema of the Postgres migrations up to 000014_constraints, in one
+-- step. Arrays and jsonb columns are JSON text and timestamps are UTC text
+-- with nanoseconds, which sorts in time order. Names are unique regardless of
+-- case, though lower() only folds ASCII letters in SQLite.
+CREATE TABLE "product" (
+    "id" integer PRIMARY KEY,
+    "name" text NOT NULL CONSTRAINT "product_name_check" CHECK (trim("name") <> ''),
+    "description" text NOT NULL DEFAULT '',
+    "price" real NOT NULL DEFAULT 0 CONSTRAINT "product_price_check" CHECK ("price" >= 0),
+    "discount_percentage" real NOT NULL DEFAULT 0 CONSTRAINT "product_discount_percentage_check" CHECK ("discount_percentage" BETWEEN 0 AND 100),
+    "rating" real NOT NULL DEFAULT 0 CONSTRAINT "product_rating_check" CHECK ("rating" BETWEEN 0 AND 5),
+    "stock" integer NOT NULL DEFAULT 0 CONSTRAINT "product_stock_check" CHECK ("stock" >= 0),
+    "brand" text NOT NULL DEFAULT '',
+    "sku" text,
+    "thumbnail" text NOT NULL DEFAULT '',
+    "images" text NOT NULL DEFAULT '[]',
+    "attributes" text NOT NULL DEFAULT '{}',
+    "source" text,
+    "external_id" text,
+    "deleted_at" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    "updated_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    CONSTRAINT "product_source_external_id_check" CHECK (("source" IS NULL) = ("external_id" IS NULL))
+);
+
+CREATE UNIQUE INDEX "product_name_lower_key" ON "product" (lower("name"));
+CREATE UNIQUE INDEX "product_sku_key" ON "product" ("sku");
+CREATE UNIQUE INDEX "product_source_external_id_key" ON "product" ("source", "external_id");
+CREATE INDEX "product_name_id_idx" ON "product" ("name", "id");
+
+CREATE TABLE "category" (
+    "id" integer PRIMARY KEY,
+    "name" text NOT NULL CONSTRAINT "category_name_check" CHECK (trim("name") <> ''),
+    "parent_id" integer REFERENCES "category" ("id") ON DELETE CASCADE,
+    "created_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    "updated_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    CONSTRAINT "category_parent_id_check" CHECK ("parent_id" <> "id")
+);
+
+CREATE UNIQUE INDEX "category_name_lower_key" ON "category" (lower("name"));
+CREATE INDEX "category_parent_id_idx" ON "category" ("parent_id");
+
+CREATE TABLE "product_category" (
+    "product_id" integer NOT NULL REFERENCES "product" ("id") ON DELETE CASCADE,
+    "category_id" integer NOT NULL REFERENCES "category" ("id") ON DELETE CASCADE,
+    PRIMARY KEY ("product_id", "category_id")
+);
+
+CREATE INDEX "product_category_category_id_idx" ON "product_category" ("category_id");
+
+CREATE TABLE "user" (
+    "id" integer PRIMARY KEY,
+    "login" text NOT NULL CONSTRAINT "user_login_check" CHECK (trim("login") <> ''),
+    "password" text NOT NULL,
+    "role" text NOT NULL DEFAULT 'viewer' CONSTRAINT "user_role_check" CHECK ("role" IN ('viewer', 'editor', 'admin')),
+    "email" text,
+    "created_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    "updated_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now'))
+);
+
+CREATE UNIQUE INDEX "user_login_lower_key" ON "user" (lower("login"));
+CREATE INDEX "user_admin_idx" ON "user" ("id") WHERE "role" = 'admin';
+
+CREATE TABLE "session" (
+    "id" integer PRIMARY KEY,
+    "token" text NOT NULL,
+    "user_id" integer NOT NULL REFERENCES "user" ("id") ON DELETE CASCADE,
+    "expiry" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    "last_used_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    "ip" text NOT NULL DEFAULT '',
+    "user_agent" text NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX "session_token_key" ON "session" ("token");
+CREATE INDEX "session_user_id_idx" ON "session" ("user_id");
+
+CREATE TABLE "sync_state" (
+    "source" text PRIMARY KEY,
+    "offset" integer NOT NULL DEFAULT 0,
+    "total" integer NOT NULL DEFAULT 0,
+    "updated_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now'))
+);
+
+CREATE TABLE "reconcile_report" (
+    "id" integer PRIMARY KEY,
+    "source" text NOT NULL,
+    "started_at" timestamp NOT NULL,
+    "finished_at" timestamp NOT NULL,
+    "report" text NOT NULL
+);
+
+CREATE INDEX "reconcile_report_source_idx" ON "reconcile_report" ("source", "id" DESC);
+
+CREATE TABLE "api_key" (
+    "id" integer PRIMARY KEY,
+    "name" text NOT NULL,
+    "prefix" text NOT NULL,
+    "hash" text NOT NULL,
+    "scopes" text NOT NULL DEFAULT '[]',
+    "created_by" integer REFERENCES "user" ("id") ON DELETE SET NULL,
+    "created_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    "expires_at" timestamp,
+    "revoked_at" timestamp,
+    "last_used_at" timestamp
+);
+
+CREATE UNIQUE INDEX "api_key_hash_key" ON "api_key" ("hash");
+
+CREATE TABLE "login_attempt" (
+    "key" text PRIMARY KEY,
+    "failures" integer NOT NULL,
+    "last_failure" timestamp NOT NULL,
+    "locked_until" timestamp
+);
+
+CREATE TABLE "user_mfa" (
+    "user_id" integer PRIMARY KEY REFERENCES "user" ("id") ON DELETE CASCADE,
+    "secret" blob NOT NULL,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "last_step" integer NOT NULL DEFAULT 0,
+    "created_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now'))
+);
+
+CREATE TABLE "recovery_code" (
+    "id" integer PRIMARY KEY,
+    "user_id" integer NOT NULL REFERENCES "user" ("id") ON DELETE CASCADE,
+    "hash" text NOT NULL,
+    "used_at" timestamp
+);
+
+CREATE UNIQUE INDEX "recovery_code_user_id_hash_key" ON "recovery_code" ("user_id", "hash");
+
+CREATE TABLE "password_reset" (
+    "hash" text PRIMARY KEY,
+    "user_id" integer NOT NULL REFERENCES "user" ("id") ON DELETE CASCADE,
+    "created_at" timestamp NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000000', 'now')),
+    "expires_at" timestamp NOT NULL,
+    "used_at" timestamp
+);
+
+CREATE INDEX "password_reset_user_id_idx" ON "password_reset" ("user_id");
+
+-- updated_at is kept by triggers so that every UPDATE sets it. The recursive
+-- triggers pragma is off, so the UPDATE of a trigger doesn't fire it again.
+CREATE TRIGGER "product_updated_at" AFTER UPDATE ON "product" FOR EACH ROW
+BEGIN
+    UPDATE "product" SET "updated_at" = strftime('%Y-%m-%d %H:%M:%f000000', 'now') WHERE "id" = NEW."id";
+END;
+
+CREATE TRIGGER "category_updated_at" AFTER UPDATE ON "category" FOR EACH ROW
+BEGIN
+    UPDATE "category" SET "updated_at" = strftime('%Y-%m-%d %H:%M:%f000000', 'now') WHERE "id" = NEW."id";
+END;
+
+CREATE TRIGGER "user_updated_at" AFTER UPDATE ON "user" FOR EACH ROW
+BEGIN
+    UPDATE "user" SET "updated_at" = strftime('%Y-%m-%d %H:%M:%f000000', 'now') WHERE "id" = NEW."id";
+END;
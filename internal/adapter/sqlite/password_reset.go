@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.PasswordResetStorage = new(passwordResetStorage)
+
+type passwordResetStorage struct {
+	db *sql.DB
+}
+
+func NewPasswordResetStorage(db *sql.DB) *passwordResetStorage {
+	return &passwordResetStorage{
+		db: db,
+	}
+}
+
+// Create stores a reset token. The other tokens of the user are deleted, so
+// that only the latest link works, and so are the expired ones.
+func (rs *passwordResetStorage) Create(ctx context.Context, reset entity.PasswordReset) error {
+	tx, err := rs.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		`DELETE FROM password_reset
+		WHERE user_id = ? OR expires_at < ?;`,
+		reset.UserID, timestamp(reset.CreatedAt),
+	)
+	if err != nil {
+		slog.Error("error deleting password resets",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO password_reset
+			(hash, user_id, created_at, expires_at)
+		VALUES
+			(?, ?, ?, ?);`,
+		reset.Hash, reset.UserID, timestamp(reset.CreatedAt), timestamp(reset.ExpiresAt),
+	)
+	if err != nil {
+		slog.Error("error adding password reset to db",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Error("error committing transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+func (rs *passwordResetStorage) Get(ctx context.Context, hash string, now time.Time) (entity.PasswordReset, error) {
+	var reset entity.PasswordReset
+	err := rs.db.QueryRowContext(
+		ctx,
+		`SELECT hash, user_id, created_at, expires_at FROM password_reset
+		WHERE hash = ? AND used_at IS NULL AND expires_at > ?;`,
+		hash, timestamp(now),
+	).Scan(&reset.Hash, &reset.UserID, &reset.CreatedAt, &reset.ExpiresAt)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.PasswordReset{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting password reset from db",
+			"error", err,
+		)
+		return entity.PasswordReset{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return reset, nil
+}
+
+func (rs *passwordResetStorage) Use(ctx context.Context, hash string, now time.Time) error {
+	c, err := rs.db.ExecContext(
+		ctx,
+		`UPDATE password_reset
+		SET used_at = ?2
+		WHERE hash = ?1 AND used_at IS NULL AND expires_at > ?2;`,
+		hash, timestamp(now),
+	)
+	if err != nil {
+		slog.Error("error using password reset",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
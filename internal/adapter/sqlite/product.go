@@ -0,0 +1,781 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.ProductStorage = new(productStorage)
+
+type productStorage struct {
+	db *sql.DB
+}
+
+func NewProductStorage(db *sql.DB) *productStorage {
+	return &productStorage{
+		db: db,
+	}
+}
+
+// AddOrUpdateProduct upserts a batch of products, creating their categories
+// as needed. A product from a source is matched by its source reference, any
+// other product by its name, ignoring case. The batch is written into a
+// staging table and merged with a few set-based statements. If a product
+// repeats, the details of its first occurrence and the category of its last
+// one are kept, and so are the stored attributes if it has none. A product
+// whose name or SKU is taken by another product is skipped and logged rather
+// than taking it over or failing the batch. Products and categories with
+// blank names are skipped as well.
+func (ps *productStorage) AddOrUpdateProduct(ctx context.Context, products ...entity.AddOrUpdateProductDTO) error {
+	if len(products) == 0 {
+		slog.Error("products slice is empty")
+		return nil
+	}
+
+	tx, err := ps.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TEMP TABLE product_staging (
+			"ord" integer NOT NULL,
+			"name" text NOT NULL,
+			"category_name" text NOT NULL,
+			"description" text NOT NULL,
+			"price" real NOT NULL,
+			"discount_percentage" real NOT NULL,
+			"rating" real NOT NULL,
+			"stock" integer NOT NULL,
+			"brand" text NOT NULL,
+			"sku" text,
+			"thumbnail" text NOT NULL,
+			"images" text NOT NULL,
+			"attributes" text NOT NULL,
+			"source" text,
+			"external_id" text
+		);`,
+	)
+	if err != nil {
+		slog.Error("error creating product staging table",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO product_staging
+			("ord", "name", "category_name", `+productDetailsColumns+`, "source", "external_id")
+		VALUES
+			`+placeholders(15)+`;`,
+	)
+	if err != nil {
+		slog.Error("error preparing staging insert",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer stmt.Close()
+
+	for i, p := range products {
+		details, err := productDetailsArgs(p.ProductDetails)
+		if err != nil {
+			slog.Error("error encoding product details",
+				"error", err,
+			)
+			return errors.NewDomainError(errors.ErrDB, "")
+		}
+		args := append([]interface{}{i, p.ProductName, p.CategoryName}, details...)
+		_, err = stmt.ExecContext(ctx, append(args, productSourceArgs(p.ProductSourceRef)...)...)
+		if err != nil {
+			slog.Error("error writing products to staging table",
+				"error", err,
+			)
+			return errors.NewDomainError(errors.ErrDB, "")
+		}
+	}
+
+	// Keep the first occurrence of every product, moved to the category of
+	// its last one.
+	_, err = tx.ExecContext(
+		ctx,
+		`DELETE FROM product_staging WHERE trim(name) = '';
+
+		UPDATE product_staging SET category_name = l.category_name
+		FROM (
+			SELECT ord,
+				row_number() OVER (PARTITION BY `+stagingProductKey+` ORDER BY ord) AS n,
+				first_value(category_name) OVER (PARTITION BY `+stagingProductKey+` ORDER BY ord DESC) AS category_name
+			FROM product_staging
+		) l
+		WHERE product_staging.ord = l.ord AND l.n = 1;
+
+		DELETE FROM product_staging
+		WHERE ord IN (
+			SELECT ord FROM (
+				SELECT ord, row_number() OVER (PARTITION BY `+stagingProductKey+` ORDER BY ord) AS n
+				FROM product_staging
+			)
+			WHERE n > 1
+		);`,
+	)
+	if err != nil {
+		slog.Error("error collapsing repeated products",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	conflicts, err := skipStagedClashes(ctx, tx, "lower(%s.name)", "product_name_lower_key")
+	if err != nil {
+		slog.Error("error skipping name clashes",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	skuConflicts, err := skipStagedClashes(ctx, tx, "%s.sku", "product_sku_key")
+	if err != nil {
+		slog.Error("error skipping SKU clashes",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	conflicts = append(conflicts, skuConflicts...)
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			category ("name")
+		SELECT DISTINCT category_name
+		FROM product_staging
+		WHERE trim(category_name) <> ''
+		ON CONFLICT(lower(name)) DO NOTHING;`,
+	)
+	if err != nil {
+		slog.Error("error inserting category",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			product ("name", `+productDetailsColumns+`, "source", "external_id")
+		SELECT
+			"name", `+productDetailsColumns+`, "source", "external_id"
+		FROM product_staging
+		WHERE source IS NOT NULL
+		ORDER BY lower(name)
+		ON CONFLICT(source, external_id)
+		DO UPDATE SET `+productUpsertSet+`;
+
+		INSERT INTO
+			product ("name", `+productDetailsColumns+`)
+		SELECT
+			"name", `+productDetailsColumns+`
+		FROM product_staging
+		WHERE source IS NULL
+		ORDER BY lower(name)
+		ON CONFLICT(lower(name))
+		DO UPDATE SET `+productUpsertSet+`;`,
+	)
+	if err != nil {
+		slog.Error("error inserting products",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			product_category ("product_id", "category_id")
+		SELECT p.id, c.id
+		FROM (
+			SELECT p.id, s.category_name
+			FROM product_staging s
+			JOIN product p ON p.source = s.source AND p.external_id = s.external_id
+			UNION ALL
+			SELECT p.id, s.category_name
+			FROM product_staging s
+			JOIN product p ON lower(p.name) = lower(s.name) AND p.source IS NULL
+			WHERE s.source IS NULL
+		) p
+		JOIN category c ON lower(c.name) = lower(p.category_name)
+		WHERE true
+		ON CONFLICT DO NOTHING;`,
+	)
+	if err != nil {
+		slog.Error("error inserting into product_category",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	_, err = tx.ExecContext(ctx, `DROP TABLE temp.product_staging;`)
+	if err != nil {
+		slog.Error("error dropping product staging table",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Error("error committing transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	if len(conflicts) > 0 {
+		slog.Warn("skipped products that clash with other products",
+			"conflicts", conflicts,
+		)
+	}
+
+	return nil
+}
+
+// skipStagedClashes removes from product_staging the products whose key,
+// formatted with a table alias, is taken by an earlier staged product or by a
+// stored product that isn't the same one, and reports them as conflicts on
+// constraint. Products with a NULL key never clash.
+func skipStagedClashes(ctx context.Context, tx *sql.Tx, key, constraint string) ([]entity.ProductConflict, error) {
+	rows, err := tx.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`DELETE FROM product_staging
+			WHERE ord IN (
+				SELECT s.ord FROM (
+					SELECT *, row_number() OVER (PARTITION BY %[1]s ORDER BY ord) AS n
+					FROM product_staging s
+				) s
+				WHERE %[1]s IS NOT NULL AND (s.n > 1 OR EXISTS (
+					SELECT 1 FROM product p
+					WHERE %[2]s = %[1]s AND NOT (
+						CASE WHEN s.source IS NULL
+						THEN p.source IS NULL AND lower(p.name) = lower(s.name)
+						ELSE p.source IS s.source AND p.external_id IS s.external_id
+						END
+					)
+				))
+			)
+			RETURNING COALESCE(external_id, ''), name;`,
+			fmt.Sprintf(key, "s"), fmt.Sprintf(key, "p"),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conflicts []entity.ProductConflict
+	for rows.Next() {
+		c := entity.ProductConflict{Reason: fmt.Sprintf("violates %s", constraint)}
+		err = rows.Scan(&c.ExternalID, &c.Name)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, c)
+	}
+	return conflicts, rows.Err()
+}
+
+func (ps *productStorage) Add(ctx context.Context, product entity.AddProductDTO) error {
+	tx, err := ps.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	exists, err := categoryExists(ctx, tx, product.CategoryID)
+	if err != nil {
+		slog.Error("error checking if category exists",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if !exists {
+		return errors.NewDomainError(errors.ErrCategoryNotFound, "")
+	}
+
+	details, err := productDetailsArgs(product.ProductDetails)
+	if err != nil {
+		slog.Error("error encoding product details",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	var id int64
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO product
+			("name", `+productDetailsColumns+`)
+		VALUES
+			`+placeholders(11)+`
+		ON CONFLICT DO NOTHING
+		RETURNING id;`,
+		append([]interface{}{product.ProductName}, details...)...,
+	).Scan(&id)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		slog.Error("error inserting into product",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO product_category
+			(product_id, category_id)
+		VALUES
+			(?, ?);`,
+		id, product.CategoryID,
+	)
+	if err != nil {
+		slog.Error("error inserting into product_category",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Error("error committing transaction",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+func (ps *productStorage) GetByID(ctx context.Context, ID int64) (entity.ProductView, error) {
+	tx, err := ps.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	var (
+		p                  entity.ProductView
+		images, attributes string
+	)
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT id, name, description, price, discount_percentage, rating, stock,
+			brand, COALESCE(sku, ''), thumbnail, images, attributes,
+			COALESCE(source, ''), COALESCE(external_id, '')
+		FROM product
+		WHERE id = ? AND deleted_at IS NULL;`,
+		ID,
+	).Scan(
+		&p.ID, &p.Name, &p.Description, &p.Price, &p.DiscountPercentage, &p.Rating, &p.Stock,
+		&p.Brand, &p.SKU, &p.Thumbnail, &images, &attributes,
+		&p.Source, &p.ExternalID,
+	)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.ProductView{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error selecting from product table",
+			"error", err,
+		)
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = json.Unmarshal([]byte(images), &p.Images)
+	if err == nil {
+		err = json.Unmarshal([]byte(attributes), &p.Attributes)
+	}
+	if err != nil {
+		slog.Error("error decoding product details",
+			"error", err,
+		)
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT c.id, c.name, c.parent_id
+		FROM category c
+		JOIN product_category pc ON pc.category_id = c.id
+		WHERE pc.product_id = ?
+		ORDER BY c.id;`,
+		ID,
+	)
+	if err != nil {
+		slog.Error("error selecting product categories",
+			"error", err,
+		)
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	p.Categories, err = scanCategories(rows)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return entity.ProductView{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return p, nil
+}
+
+func (ps *productStorage) GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error) {
+	tx, err := ps.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	exists, err := categoryExists(ctx, tx, query.CategoryID)
+	if err != nil {
+		slog.Error("error checking if category exists",
+			"error", err,
+		)
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	if !exists {
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrCategoryNotFound, "")
+	}
+
+	keyset, orderBy, args := productKeyset(query, 4)
+	args = append([]interface{}{query.CategoryID, query.NamePrefix, query.IncludeDescendants}, args...)
+	args = append(args, query.Limit+1)
+
+	// The recursive part of the CTE only runs when descendants are requested,
+	// EXISTS keeps products that sit in several subcategories from repeating.
+	rows, err := tx.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`WITH RECURSIVE tree AS (
+				SELECT id FROM category
+				WHERE id = ?1
+				UNION ALL
+				SELECT c.id FROM category c
+				JOIN tree ON c.parent_id = tree.id
+				WHERE ?3
+			)
+			SELECT p.id, p.name
+			FROM product p
+			WHERE EXISTS (
+					SELECT 1 FROM product_category pc
+					JOIN tree ON pc.category_id = tree.id
+					WHERE pc.product_id = p.id
+				)
+				AND `+hasPrefix("p.name", "?2")+`
+				AND p.deleted_at IS NULL
+				AND %s
+			ORDER BY %s
+			LIMIT ?%d;`,
+			keyset, orderBy, len(args),
+		),
+		args...,
+	)
+	if err != nil {
+		slog.Error("error selecting from product table",
+			"error", err,
+		)
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	list, err := scanProductList(rows)
+	if err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	page := entity.ProductPage{Products: list}
+	if len(list) > query.Limit {
+		page.Products = list[:query.Limit]
+		last := page.Products[query.Limit-1]
+		page.NextCursor = entity.ProductCursor{
+			Sort: query.Sort,
+			ID:   last.ID,
+			Name: last.Name,
+		}.Encode()
+	}
+
+	return page, nil
+}
+
+func (ps *productStorage) UpdateName(ctx context.Context, product entity.UpdateProductNameDTO) error {
+	c, err := ps.db.ExecContext(
+		ctx,
+		`UPDATE product
+		SET name = ?
+		WHERE id = ?;`,
+		product.NewName,
+		product.ProductID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		slog.Error("error updating product name",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return nil
+}
+
+func (ps *productStorage) UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error {
+	details, err := productDetailsArgs(product.ProductDetails)
+	if err != nil {
+		slog.Error("error encoding product details",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	c, err := ps.db.ExecContext(
+		ctx,
+		`UPDATE product
+		SET
+			description = ?2,
+			price = ?3,
+			discount_percentage = ?4,
+			rating = ?5,
+			stock = ?6,
+			brand = ?7,
+			sku = ?8,
+			thumbnail = ?9,
+			images = ?10,
+			attributes = ?11
+		WHERE id = ?1;`,
+		append([]interface{}{product.ProductID}, details...)...,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		slog.Error("error updating product details",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+	return nil
+}
+
+func (ps *productStorage) UpdateCategory(ctx context.Context, product entity.UpdateProductCategoryDTO) error {
+	c, err := ps.db.ExecContext(
+		ctx,
+		`UPDATE product_category
+		SET category_id = ?
+		WHERE product_id = ? AND category_id = ?;`,
+		product.NewCategoryID,
+		product.ProductID,
+		product.OldCategoryID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		if isForeignKeyViolation(err) {
+			return errors.NewDomainError(errors.ErrCategoryNotFound, "")
+		}
+		slog.Error("error updating product category",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
+
+func (ps *productStorage) Delete(ctx context.Context, ID int64) error {
+	c, err := ps.db.ExecContext(
+		ctx,
+		`DELETE FROM product
+		WHERE id = ?;`,
+		ID,
+	)
+	if err != nil {
+		slog.Error("error deleting from products",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
+
+func categoryExists(ctx context.Context, tx *sql.Tx, categoryID int64) (bool, error) {
+	var exists bool
+	err := tx.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM category WHERE id = ?);`,
+		categoryID,
+	).Scan(&exists)
+	return exists, err
+}
+
+// productKeyset returns the keyset condition and the ORDER BY clause for
+// query, numbering its placeholders from firstArg. Only constant SQL
+// fragments are interpolated, cursor values are passed as arguments.
+func productKeyset(query entity.ProductQuery, firstArg int) (string, string, []interface{}) {
+	switch query.Sort {
+	case entity.SortByName, entity.SortByNameDesc:
+		op, dir := ">", "ASC"
+		if query.Sort == entity.SortByNameDesc {
+			op, dir = "<", "DESC"
+		}
+		orderBy := fmt.Sprintf("p.name %s, p.id %s", dir, dir)
+		if query.After == nil {
+			return "TRUE", orderBy, nil
+		}
+		return fmt.Sprintf("(p.name, p.id) %s (?%d, ?%d)", op, firstArg, firstArg+1),
+			orderBy,
+			[]interface{}{query.After.Name, query.After.ID}
+	default:
+		if query.After == nil {
+			return "TRUE", "p.id ASC", nil
+		}
+		return fmt.Sprintf("p.id > ?%d", firstArg), "p.id ASC", []interface{}{query.After.ID}
+	}
+}
+
+// hasPrefix returns the condition that column starts with the text of the
+// placeholder arg, ignoring case.
+func hasPrefix(column, arg string) string {
+	return fmt.Sprintf("substr(lower(%s), 1, length(%s)) = lower(%s)", column, arg, arg)
+}
+
+// scanProductList reads and closes rows of id and name.
+func scanProductList(rows *sql.Rows) ([]entity.ProductCategoryListItem, error) {
+	defer rows.Close()
+
+	list := make([]entity.ProductCategoryListItem, 0)
+	for rows.Next() {
+		var product entity.ProductCategoryListItem
+		err := rows.Scan(&product.ID, &product.Name)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, product)
+	}
+	return list, rows.Err()
+}
+
+// stagingProductKey identifies a staged product: by its source reference
+// if it has one, by its name otherwise.
+const stagingProductKey = `source, external_id, CASE WHEN source IS NULL THEN lower(name) END`
+
+// productUpsertSet updates a product from the sync batch and restores it if
+// it was deleted. Attributes the batch doesn't have are kept, they may have
+// been set through the API.
+const productUpsertSet = `name=excluded.name,
+	deleted_at=NULL,
+	description=excluded.description,
+	price=excluded.price,
+	discount_percentage=excluded.discount_percentage,
+	rating=excluded.rating,
+	stock=excluded.stock,
+	brand=excluded.brand,
+	sku=excluded.sku,
+	thumbnail=excluded.thumbnail,
+	images=excluded.images,
+	attributes=COALESCE(NULLIF(excluded.attributes, '{}'), product.attributes)`
+
+const productDetailsColumns = `"description", "price", "discount_percentage", "rating", "stock",
+		"brand", "sku", "thumbnail", "images", "attributes"`
+
+// productDetailsArgs returns query arguments in productDetailsColumns order.
+// An empty SKU is stored as NULL so that it doesn't collide with other
+// products without one. Prices, discounts and ratings are rounded to cents
+// like the numeric(_,2) columns of Postgres do, images and attributes are
+// stored as JSON.
+func productDetailsArgs(d entity.ProductDetails) ([]interface{}, error) {
+	var sku *string
+	if d.SKU != "" {
+		sku = &d.SKU
+	}
+	images := d.Images
+	if images == nil {
+		images = []string{}
+	}
+	attributes := d.Attributes
+	if attributes == nil {
+		attributes = entity.ProductAttributes{}
+	}
+
+	imagesJSON, err := json.Marshal(images)
+	if err != nil {
+		return nil, err
+	}
+	attributesJSON, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{
+		d.Description, roundCents(d.Price), roundCents(d.DiscountPercentage), roundCents(d.Rating), d.Stock,
+		d.Brand, sku, d.Thumbnail, string(imagesJSON), string(attributesJSON),
+	}, nil
+}
+
+// placeholders returns a "(?, ?, ...)" tuple of count placeholders.
+func placeholders(count int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", count), ", ") + ")"
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// productSourceArgs returns the "source" and "external_id" query arguments,
+// NULL for the products not imported from an upstream source.
+func productSourceArgs(ref entity.ProductSourceRef) []interface{} {
+	if ref.Source == "" {
+		return []interface{}{nil, nil}
+	}
+	return []interface{}{ref.Source, ref.ExternalID}
+}
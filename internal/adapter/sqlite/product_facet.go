@@ -0,0 +1,327 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.ProductFacetCounter = new(productStorage)
+
+// facet names used to leave a facet's own filter out of its counts
+const (
+	facetCategory = "category"
+	facetBrand    = "brand"
+	facetPrice    = "price"
+	facetAttr     = "attribute"
+)
+
+// facetArgs collects query arguments and returns their placeholders. Lists
+// are passed as JSON arrays, to be read with json_each.
+type facetArgs []interface{}
+
+func (a *facetArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return "?" + strconv.Itoa(len(*a))
+}
+
+func (a *facetArgs) addList(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return a.add(string(b))
+}
+
+// facetConditions returns the WHERE clause for filter over product p,
+// omitting the filter of the facet named except.
+func facetConditions(filter entity.FacetFilter, except string, args *facetArgs) string {
+	conds := []string{"p.deleted_at IS NULL"}
+
+	if filter.Text != "" {
+		conds = append(conds, searchMatchSQL("p", args.addList(searchWords(filter.Text)), args.add(filter.Text)))
+	}
+	if len(filter.CategoryIDs) > 0 && except != facetCategory {
+		conds = append(conds, fmt.Sprintf(
+			`EXISTS (
+				SELECT 1 FROM product_category fpc
+				WHERE fpc.product_id = p.id AND fpc.category_id IN (SELECT value FROM json_each(%s))
+			)`, args.addList(filter.CategoryIDs),
+		))
+	}
+	if len(filter.Brands) > 0 && except != facetBrand {
+		conds = append(conds, fmt.Sprintf("p.brand IN (SELECT value FROM json_each(%s))", args.addList(filter.Brands)))
+	}
+	if len(filter.PriceBuckets) > 0 && except != facetPrice {
+		conds = append(conds, fmt.Sprintf(
+			"%s IN (SELECT value FROM json_each(%s))", priceBucketSQL(args), args.addList(filter.PriceBuckets),
+		))
+	}
+	if except != facetAttr {
+		for _, name := range sortedKeys(filter.Attributes) {
+			conds = append(conds, attributeFilterSQL(name, filter.Attributes[name], args))
+		}
+	}
+
+	return strings.Join(conds, "\n\t\t\tAND ")
+}
+
+// priceBucketSQL numbers the bucket of entity.PriceBucketBounds the price of
+// p is in, like width_bucket does.
+func priceBucketSQL(args *facetArgs) string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for i, bound := range entity.PriceBucketBounds {
+		fmt.Fprintf(&b, " WHEN p.price < %s THEN %d", args.add(bound), i)
+	}
+	fmt.Fprintf(&b, " ELSE %d END", len(entity.PriceBucketBounds))
+	return b.String()
+}
+
+// attributeTextSQL returns the value of the json_each row kv as text, the
+// way Postgres reads a value out of jsonb: booleans are true and false
+// rather than 1 and 0.
+func attributeTextSQL(kv string) string {
+	return fmt.Sprintf(
+		"CASE %[1]s.type WHEN 'true' THEN 'true' WHEN 'false' THEN 'false' ELSE CAST(%[1]s.value AS text) END", kv,
+	)
+}
+
+// attributeFilterSQL returns the condition that the attribute name of p has
+// one of values.
+func attributeFilterSQL(name string, values []string, args *facetArgs) string {
+	return fmt.Sprintf(
+		`EXISTS (
+			SELECT 1 FROM json_each(p.attributes) fa
+			WHERE fa.key = %s AND %s IN (SELECT value FROM json_each(%s))
+		)`, args.add(name), attributeTextSQL("fa"), args.addList(values),
+	)
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Facets returns a page of products matching query together with facet
+// counts computed by GROUP BY over product_category, brand, price bucket and
+// the attribute pairs. Text is matched like Search does.
+func (ps *productStorage) Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error) {
+	tx, err := ps.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	page := entity.FacetPage{
+		Facets: entity.Facets{Attributes: make(map[string][]entity.FacetCount)},
+	}
+
+	page.Products, page.NextCursor, err = facetProducts(ctx, tx, query)
+	if err != nil {
+		slog.Error("error selecting faceted products",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	var args facetArgs
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT count(*) FROM product p
+		WHERE `+facetConditions(query.FacetFilter, "", &args)+`;`,
+		args...,
+	).Scan(&page.Total)
+	if err != nil {
+		slog.Error("error counting faceted products",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	page.Facets.Categories = make([]entity.CategoryFacetCount, 0)
+	args = nil
+	err = queryRows(
+		ctx, tx,
+		`SELECT c.id, c.name, count(*)
+		FROM product p
+		JOIN product_category pc ON pc.product_id = p.id
+		JOIN category c ON c.id = pc.category_id
+		WHERE `+facetConditions(query.FacetFilter, facetCategory, &args)+`
+		GROUP BY c.id, c.name
+		ORDER BY count(*) DESC, c.id;`,
+		args,
+		func(rows *sql.Rows) error {
+			var f entity.CategoryFacetCount
+			err := rows.Scan(&f.ID, &f.Name, &f.Count)
+			page.Facets.Categories = append(page.Facets.Categories, f)
+			return err
+		},
+	)
+	if err != nil {
+		slog.Error("error counting category facet",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	page.Facets.Brands = make([]entity.FacetCount, 0)
+	args = nil
+	err = queryRows(
+		ctx, tx,
+		`SELECT p.brand, count(*)
+		FROM product p
+		WHERE p.brand <> ''
+			AND `+facetConditions(query.FacetFilter, facetBrand, &args)+`
+		GROUP BY p.brand
+		ORDER BY count(*) DESC, p.brand;`,
+		args,
+		func(rows *sql.Rows) error {
+			var f entity.FacetCount
+			err := rows.Scan(&f.Value, &f.Count)
+			page.Facets.Brands = append(page.Facets.Brands, f)
+			return err
+		},
+	)
+	if err != nil {
+		slog.Error("error counting brand facet",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	page.Facets.Prices = make([]entity.PriceFacetCount, 0)
+	args = nil
+	bucket := priceBucketSQL(&args)
+	err = queryRows(
+		ctx, tx,
+		`SELECT `+bucket+`, count(*)
+		FROM product p
+		WHERE `+facetConditions(query.FacetFilter, facetPrice, &args)+`
+		GROUP BY 1
+		ORDER BY 1;`,
+		args,
+		func(rows *sql.Rows) error {
+			var (
+				bucket int
+				count  int64
+			)
+			err := rows.Scan(&bucket, &count)
+			page.Facets.Prices = append(page.Facets.Prices, entity.NewPriceFacetCount(bucket, count))
+			return err
+		},
+	)
+	if err != nil {
+		slog.Error("error counting price facet",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	// Each attribute is counted with the filters on all other attributes, so
+	// the filter of the row's own key is switched off per row.
+	args = nil
+	conds := []string{facetConditions(query.FacetFilter, facetAttr, &args)}
+	for _, name := range sortedKeys(query.FacetFilter.Attributes) {
+		n := args.add(name)
+		conds = append(conds, fmt.Sprintf(
+			"(kv.key = %s OR %s)", n, attributeFilterSQL(name, query.FacetFilter.Attributes[name], &args),
+		))
+	}
+	value := attributeTextSQL("kv")
+	err = queryRows(
+		ctx, tx,
+		`SELECT kv.key, `+value+`, count(*)
+		FROM product p, json_each(p.attributes) kv
+		WHERE `+strings.Join(conds, "\n\t\t\tAND ")+`
+		GROUP BY kv.key, `+value+`
+		ORDER BY kv.key, count(*) DESC, `+value+`;`,
+		args,
+		func(rows *sql.Rows) error {
+			var (
+				name string
+				f    entity.FacetCount
+			)
+			err := rows.Scan(&name, &f.Value, &f.Count)
+			page.Facets.Attributes[name] = append(page.Facets.Attributes[name], f)
+			return err
+		},
+	)
+	if err != nil {
+		slog.Error("error counting attribute facets",
+			"error", err,
+		)
+		return entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return page, nil
+}
+
+func facetProducts(ctx context.Context, tx *sql.Tx, query entity.FacetQuery) ([]entity.ProductCategoryListItem, string, error) {
+	var args facetArgs
+	conds := facetConditions(query.FacetFilter, "", &args)
+
+	keyset, orderBy, keysetArgs := productKeyset(
+		entity.ProductQuery{Sort: query.Sort, After: query.After}, len(args)+1,
+	)
+	args = append(args, keysetArgs...)
+	limit := args.add(query.Limit + 1)
+
+	rows, err := tx.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT p.id, p.name FROM product p
+			WHERE %s
+				AND %s
+			ORDER BY %s
+			LIMIT %s;`,
+			conds, keyset, orderBy, limit,
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	list, err := scanProductList(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(list) <= query.Limit {
+		return list, "", nil
+	}
+	list = list[:query.Limit]
+	last := list[query.Limit-1]
+	return list, entity.ProductCursor{Sort: query.Sort, ID: last.ID, Name: last.Name}.Encode(), nil
+}
+
+// queryRows runs query and calls scan for each of its rows.
+func queryRows(ctx context.Context, tx *sql.Tx, query string, args []interface{}, scan func(*sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = scan(rows)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
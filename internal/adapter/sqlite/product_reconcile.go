@@ -0,0 +1,313 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+func (ps *productStorage) GetSourceProducts(ctx context.Context, source string) ([]entity.SourceProduct, error) {
+	rows, err := ps.db.QueryContext(
+		ctx,
+		`SELECT p.id, p.external_id, p.name, p.deleted_at IS NOT NULL,
+			(SELECT json_group_array(name) FROM (
+				SELECT c.name FROM product_category pc
+				JOIN category c ON c.id = pc.category_id
+				WHERE pc.product_id = p.id
+				ORDER BY c.name
+			))
+		FROM product p
+		WHERE p.source = ?
+		ORDER BY p.id;`,
+		source,
+	)
+	if err != nil {
+		slog.Error("error selecting source products",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer rows.Close()
+
+	products := make([]entity.SourceProduct, 0)
+	for rows.Next() {
+		var (
+			p          entity.SourceProduct
+			categories string
+		)
+		err = rows.Scan(&p.ID, &p.ExternalID, &p.Name, &p.Deleted, &categories)
+		if err == nil {
+			err = json.Unmarshal([]byte(categories), &p.Categories)
+		}
+		if err != nil {
+			slog.Error("error scanning from row",
+				"error", err,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+		products = append(products, p)
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return products, nil
+}
+
+// ApplyReconcilePlan writes a reconciliation plan in one transaction. Every
+// product is written under its own savepoint: a product whose name or SKU is
+// taken by another product is reported as a conflict instead of failing the run.
+// The category of a source product replaces all the categories it had, and a
+// product without attributes keeps its stored ones.
+func (ps *productStorage) ApplyReconcilePlan(ctx context.Context, plan entity.ReconcilePlan) ([]entity.ProductConflict, error) {
+	tx, err := ps.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	var conflicts []entity.ProductConflict
+	categoryIDs := make(map[string]int64)
+	for _, p := range plan.Upserts {
+		categoryID, err := reconcileProduct(ctx, tx, p, categoryIDs)
+		if err != nil {
+			if isUniqueViolation(err) || isCheckViolation(err) {
+				conflicts = append(conflicts, entity.ProductConflict{
+					ExternalID: p.ExternalID,
+					Name:       p.ProductName,
+					Reason:     fmt.Sprintf("violates %s", constraintName(err)),
+				})
+				continue
+			}
+			slog.Error("error reconciling product",
+				"error", err,
+				"external_id", p.ExternalID,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+		categoryIDs[p.CategoryName] = categoryID
+	}
+
+	if len(plan.Deletes) > 0 {
+		externalIDs, err := json.Marshal(plan.Deletes)
+		if err != nil {
+			slog.Error("error encoding deleted products",
+				"error", err,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+
+		query := `UPDATE product SET deleted_at = ?3
+			WHERE source = ?1 AND external_id IN (SELECT value FROM json_each(?2)) AND deleted_at IS NULL;`
+		args := []interface{}{plan.Source, string(externalIDs), timestamp(time.Now())}
+		if plan.Mode == entity.DeleteModeDelete {
+			query = `DELETE FROM product
+				WHERE source = ?1 AND external_id IN (SELECT value FROM json_each(?2));`
+			args = args[:2]
+		}
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			slog.Error("error deleting source products",
+				"error", err,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Error("error committing transaction",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return conflicts, nil
+}
+
+// reconcileProduct upserts one product under a savepoint and returns the ID
+// of its category. categoryIDs caches the categories written by the run.
+func reconcileProduct(ctx context.Context, tx *sql.Tx, p entity.AddOrUpdateProductDTO, categoryIDs map[string]int64) (categoryID int64, err error) {
+	_, err = tx.ExecContext(ctx, `SAVEPOINT reconcile_product;`)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.ExecContext(ctx, `ROLLBACK TO reconcile_product;`)
+		}
+		tx.ExecContext(ctx, `RELEASE reconcile_product;`)
+	}()
+
+	details, err := productDetailsArgs(p.ProductDetails)
+	if err != nil {
+		return 0, err
+	}
+
+	var productID int64
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO
+			product ("name", `+productDetailsColumns+`, "source", "external_id")
+		VALUES
+			`+placeholders(13)+`
+		ON CONFLICT(source, external_id)
+		DO UPDATE SET
+			name=excluded.name,
+			description=excluded.description,
+			price=excluded.price,
+			discount_percentage=excluded.discount_percentage,
+			rating=excluded.rating,
+			stock=excluded.stock,
+			brand=excluded.brand,
+			sku=excluded.sku,
+			thumbnail=excluded.thumbnail,
+			images=excluded.images,
+			attributes=COALESCE(NULLIF(excluded.attributes, '{}'), product.attributes),
+			deleted_at=NULL
+		RETURNING id;`,
+		append(append([]interface{}{p.ProductName}, details...), productSourceArgs(p.ProductSourceRef)...)...,
+	).Scan(&productID)
+	if err != nil {
+		return 0, err
+	}
+
+	categoryID, ok := categoryIDs[p.CategoryName]
+	if !ok {
+		err = tx.QueryRowContext(
+			ctx,
+			`INSERT INTO category ("name") VALUES (?)
+			ON CONFLICT(lower(name)) DO UPDATE SET name=category.name
+			RETURNING id;`,
+			p.CategoryName,
+		).Scan(&categoryID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`DELETE FROM product_category
+		WHERE product_id = ? AND category_id <> ?;`,
+		productID, categoryID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO product_category ("product_id", "category_id")
+		VALUES (?, ?)
+		ON CONFLICT DO NOTHING;`,
+		productID, categoryID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return categoryID, nil
+}
+
+var _ service.ReconcileReportStorage = new(reconcileReportStorage)
+
+type reconcileReportStorage struct {
+	db *sql.DB
+}
+
+func NewReconcileReportStorage(db *sql.DB) *reconcileReportStorage {
+	return &reconcileReportStorage{
+		db: db,
+	}
+}
+
+func (s *reconcileReportStorage) Save(ctx context.Context, report entity.ReconcileReport) (entity.ReconcileReport, error) {
+	report.ID = 0
+	b, err := json.Marshal(report)
+	if err != nil {
+		slog.Error("error marshalling reconcile report",
+			"error", err,
+		)
+		return entity.ReconcileReport{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = s.db.QueryRowContext(
+		ctx,
+		`INSERT INTO reconcile_report
+			(source, started_at, finished_at, report)
+		VALUES
+			(?, ?, ?, ?)
+		RETURNING id;`,
+		report.Source, timestamp(report.StartedAt), timestamp(report.FinishedAt), string(b),
+	).Scan(&report.ID)
+	if err != nil {
+		slog.Error("error saving reconcile report",
+			"error", err,
+		)
+		return entity.ReconcileReport{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return report, nil
+}
+
+// List returns the latest reports first, of all sources if source is empty.
+func (s *reconcileReportStorage) List(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT id, report FROM reconcile_report
+		WHERE ?1 = '' OR source = ?1
+		ORDER BY id DESC
+		LIMIT ?2;`,
+		source, limit,
+	)
+	if err != nil {
+		slog.Error("error selecting reconcile reports",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer rows.Close()
+
+	reports := make([]entity.ReconcileReport, 0)
+	for rows.Next() {
+		var (
+			report entity.ReconcileReport
+			b      string
+		)
+		err = rows.Scan(&report.ID, &b)
+		if err == nil {
+			id := report.ID
+			err = json.Unmarshal([]byte(b), &report)
+			report.ID = id
+		}
+		if err != nil {
+			slog.Error("error scanning from row",
+				"error", err,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,214 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.ProductSearcher = new(productSearcher)
+
+// highlightWords is the most words a highlight shows.
+const highlightWords = 15
+
+type productSearcher struct {
+	db *sql.DB
+}
+
+func NewProductSearcher(db *sql.DB) *productSearcher {
+	return &productSearcher{
+		db: db,
+	}
+}
+
+// Search matches the products whose name or description contain every word
+// of the text, ignoring case, and by trigram similarity of the name like
+// pg_trgm, so that typos still find the product. There is no stemming. A
+// word found in the name ranks higher than one found in the description,
+// and the similarity is added to the rank.
+func (ps *productSearcher) Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error) {
+	words := searchWords(query.Text)
+	wordsJSON, err := json.Marshal(words)
+	if err != nil {
+		slog.Error("error encoding search words",
+			"error", err,
+		)
+		return entity.ProductSearchPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	keyset, orderBy, args := searchKeyset(query, 6)
+	args = append([]interface{}{string(wordsJSON), query.CategoryID, query.IncludeDescendants, query.NamePrefix, query.Text}, args...)
+	args = append(args, query.Limit+1)
+
+	rows, err := ps.db.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`WITH RECURSIVE tree AS (
+				SELECT id FROM category
+				WHERE id = ?2
+				UNION ALL
+				SELECT c.id FROM category c
+				JOIN tree ON c.parent_id = tree.id
+				WHERE ?3
+			),
+			hits AS (
+				SELECT p.id, p.name, p.description, `+searchRankSQL("p", "?1", "?5")+` AS rank
+				FROM product p
+				WHERE `+searchMatchSQL("p", "?1", "?5")+`
+					AND (?2 = 0 OR EXISTS (
+						SELECT 1 FROM product_category pc
+						JOIN tree ON pc.category_id = tree.id
+						WHERE pc.product_id = p.id
+					))
+					AND `+hasPrefix("p.name", "?4")+`
+					AND p.deleted_at IS NULL
+			)
+			SELECT p.id, p.name, p.rank, p.description
+			FROM hits p
+			WHERE %s
+			ORDER BY %s
+			LIMIT ?%d;`,
+			keyset, orderBy, len(args),
+		),
+		args...,
+	)
+	if err != nil {
+		slog.Error("error searching products",
+			"error", err,
+		)
+		return entity.ProductSearchPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer rows.Close()
+
+	hits := make([]entity.ProductSearchHit, 0)
+	for rows.Next() {
+		var (
+			hit         entity.ProductSearchHit
+			description string
+		)
+		err = rows.Scan(&hit.ID, &hit.Name, &hit.Rank, &description)
+		if err != nil {
+			slog.Error("error scanning from row",
+				"error", err,
+			)
+			return entity.ProductSearchPage{}, errors.NewDomainError(errors.ErrDB, "")
+		}
+		hit.Highlight = highlight(hit.Name+" "+description, words)
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return entity.ProductSearchPage{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	page := entity.ProductSearchPage{Products: hits}
+	if len(hits) > query.Limit {
+		page.Products = hits[:query.Limit]
+		last := page.Products[query.Limit-1]
+		page.NextCursor = entity.ProductCursor{
+			Sort: query.Sort,
+			ID:   last.ID,
+			Name: last.Name,
+			Rank: last.Rank,
+		}.Encode()
+	}
+
+	return page, nil
+}
+
+// searchKeyset extends productKeyset with ordering by relevance, where the
+// rank descends and ID breaks ties. Ranks are rounded the same way on both
+// sides, as the cursor keeps them as float32.
+func searchKeyset(query entity.ProductSearchQuery, firstArg int) (string, string, []interface{}) {
+	if query.Sort != entity.SortByRelevance {
+		return productKeyset(query.ProductQuery, firstArg)
+	}
+
+	orderBy := "p.rank DESC, p.id ASC"
+	if query.After == nil {
+		return "TRUE", orderBy, nil
+	}
+	return fmt.Sprintf("(p.rank < round(?%d, 4) OR (p.rank = round(?%d, 4) AND p.id > ?%d))", firstArg, firstArg, firstArg+1),
+		orderBy,
+		[]interface{}{float64(query.After.Rank), query.After.ID}
+}
+
+// matchTextSQL returns the condition that every word of the JSON array arg
+// is in the name or the description of product p. Without words nothing
+// matches.
+func matchTextSQL(p, arg string) string {
+	return fmt.Sprintf(
+		`(json_array_length(%[2]s) > 0 AND NOT EXISTS (
+			SELECT 1 FROM json_each(%[2]s) w
+			WHERE instr(lower(%[1]s.name), w.value) = 0 AND instr(lower(%[1]s.description), w.value) = 0
+		))`,
+		p, arg,
+	)
+}
+
+// searchMatchSQL returns the condition that product p matches the words of
+// the JSON array wordsArg or that its name is similar to the text textArg.
+func searchMatchSQL(p, wordsArg, textArg string) string {
+	return fmt.Sprintf("(%s OR similarity(%s.name, %s) >= %v)", matchTextSQL(p, wordsArg), p, textArg, similarityThreshold)
+}
+
+// searchRankSQL ranks the match of searchMatchSQL: the average of 1 for the
+// words found in the name and 0.4 for the ones found in the description, if
+// all are found, plus the similarity of the name.
+func searchRankSQL(p, wordsArg, textArg string) string {
+	return fmt.Sprintf(
+		`round(
+			CASE WHEN %[4]s THEN (
+				SELECT avg(CASE WHEN instr(lower(%[1]s.name), w.value) > 0 THEN 1.0 ELSE 0.4 END)
+				FROM json_each(%[2]s) w
+			) ELSE 0 END + similarity(%[1]s.name, %[3]s),
+		4)`,
+		p, wordsArg, textArg, matchTextSQL(p, wordsArg),
+	)
+}
+
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// highlight HTML-escapes text and wraps its words containing a searched word
+// in <b></b>, showing at most highlightWords words from the first match on.
+func highlight(text string, words []string) string {
+	fields := strings.Fields(text)
+	first := -1
+	for i, f := range fields {
+		fields[i] = html.EscapeString(f)
+		lower := strings.ToLower(f)
+		for _, w := range words {
+			if strings.Contains(lower, w) {
+				fields[i] = "<b>" + fields[i] + "</b>"
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+
+	fields = fields[first:]
+	if len(fields) > highlightWords {
+		fields = fields[:highlightWords]
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,243 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.SessionStorage = new(sessionStorage)
+
+type sessionStorage struct {
+	db *sql.DB
+}
+
+func NewSessionStorage(db *sql.DB) *sessionStorage {
+	return &sessionStorage{
+		db: db,
+	}
+}
+
+const sessionColumns = `id, token, user_id, expiry, created_at, last_used_at, ip, user_agent`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row rowScanner) (entity.Session, error) {
+	var session entity.Session
+	err := row.Scan(
+		&session.ID, &session.Token, &session.UserID, &session.Expiry,
+		&session.CreatedAt, &session.LastUsedAt, &session.IP, &session.UserAgent,
+	)
+	return session, err
+}
+
+func (ss *sessionStorage) GetByID(ctx context.Context, ID int64) (entity.Session, error) {
+	session, err := scanSession(ss.db.QueryRowContext(
+		ctx,
+		`SELECT `+sessionColumns+` FROM session
+		WHERE id = ?;`,
+		ID,
+	))
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.Session{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting session from db",
+			"error", err,
+		)
+		return entity.Session{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return session, nil
+}
+
+func (ss *sessionStorage) Create(ctx context.Context, session entity.Session) (entity.Session, error) {
+	err := ss.db.QueryRowContext(
+		ctx,
+		`INSERT INTO session
+			("token", "user_id", "expiry", "created_at", "last_used_at", "ip", "user_agent")
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?)
+		RETURNING id;`,
+		session.Token,
+		session.UserID,
+		timestamp(session.Expiry),
+		timestamp(session.CreatedAt),
+		timestamp(session.LastUsedAt),
+		session.IP,
+		session.UserAgent,
+	).Scan(&session.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.Session{}, errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		slog.Error("error adding new session to db",
+			"error", err,
+		)
+		return entity.Session{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return session, nil
+}
+
+func (ss *sessionStorage) Delete(ctx context.Context, token string) error {
+	c, err := ss.db.ExecContext(
+		ctx,
+		`DELETE FROM session
+		WHERE "token" = ?;`,
+		token,
+	)
+	if err != nil {
+		slog.Error("error deleting session with token",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	return nil
+}
+
+// GetByUser returns the unexpired sessions of a user, most recently used first.
+func (ss *sessionStorage) GetByUser(ctx context.Context, userID int64) ([]entity.Session, error) {
+	rows, err := ss.db.QueryContext(
+		ctx,
+		`SELECT `+sessionColumns+` FROM session
+		WHERE user_id = ? AND expiry >= ?
+		ORDER BY last_used_at DESC, id DESC;`,
+		userID, timestamp(time.Now()),
+	)
+	if err != nil {
+		slog.Error("error getting user sessions from db",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer rows.Close()
+
+	sessions := make([]entity.Session, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			slog.Error("error scanning from row",
+				"error", err,
+			)
+			return nil, errors.NewDomainError(errors.ErrDB, "")
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("error scanning rows",
+			"error", err,
+		)
+		return nil, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return sessions, nil
+}
+
+// Rotate replaces the token of a session if it is still oldToken. A token
+// that was rotated meanwhile is reported as not found.
+func (ss *sessionStorage) Rotate(ctx context.Context, ID int64, oldToken, newToken string, lastUsedAt time.Time) error {
+	c, err := ss.db.ExecContext(
+		ctx,
+		`UPDATE session SET token = ?3, last_used_at = ?4
+		WHERE id = ?1 AND token = ?2;`,
+		ID, oldToken, newToken, timestamp(lastUsedAt),
+	)
+	if err != nil {
+		slog.Error("error rotating session token",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	return nil
+}
+
+// DeleteByID deletes a session of a user. Sessions of other users are
+// reported as not found.
+func (ss *sessionStorage) DeleteByID(ctx context.Context, userID, ID int64) error {
+	c, err := ss.db.ExecContext(
+		ctx,
+		`DELETE FROM session
+		WHERE id = ? AND user_id = ?;`,
+		ID, userID,
+	)
+	if err != nil {
+		slog.Error("error deleting session by id",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "no rows affected")
+	}
+
+	return nil
+}
+
+func (ss *sessionStorage) DeleteByUser(ctx context.Context, userID int64) error {
+	_, err := ss.db.ExecContext(
+		ctx,
+		`DELETE FROM session
+		WHERE user_id = ?;`,
+		userID,
+	)
+	if err != nil {
+		slog.Error("error deleting user sessions",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+// DeleteOthers deletes the sessions of a user but the one with ID keepID.
+func (ss *sessionStorage) DeleteOthers(ctx context.Context, userID, keepID int64) error {
+	_, err := ss.db.ExecContext(
+		ctx,
+		`DELETE FROM session
+		WHERE user_id = ? AND id <> ?;`,
+		userID, keepID,
+	)
+	if err != nil {
+		slog.Error("error deleting user sessions",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
+
+func (ss *sessionStorage) DeleteExpired(ctx context.Context) error {
+	_, err := ss.db.ExecContext(
+		ctx,
+		`DELETE FROM session
+		WHERE "expiry" < ?;`,
+		timestamp(time.Now()),
+	)
+	if err != nil {
+		slog.Error("error deleting expired sessions",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+// Package sqlite implements the storages on an SQLite database, for the
+// deployments that run the catalog as one binary. It keeps the semantics and
+// the error codes of the Postgres storages of package db.
+package sqlite
+
+import "time"
+
+// timeFormat is how timestamps are stored: in UTC with a fixed width, so
+// that they compare in time order as text. The schema defaults write the
+// same format.
+const timeFormat = "2006-01-02 15:04:05.000000000"
+
+// timestamp returns t as a query argument.
+func timestamp(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+// nullTimestamp returns t as a query argument, NULL if t is nil.
+func nullTimestamp(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
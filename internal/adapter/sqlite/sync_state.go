@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.SyncStateStorage = new(syncStateStorage)
+
+type syncStateStorage struct {
+	db *sql.DB
+}
+
+func NewSyncStateStorage(db *sql.DB) *syncStateStorage {
+	return &syncStateStorage{
+		db: db,
+	}
+}
+
+func (s *syncStateStorage) Get(ctx context.Context, source string) (entity.SyncState, error) {
+	var state entity.SyncState
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT source, "offset", total, updated_at FROM sync_state
+		WHERE source = ?;`,
+		source,
+	).Scan(&state.Source, &state.Offset, &state.Total, &state.UpdatedAt)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.SyncState{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting sync state from db",
+			"error", err,
+		)
+		return entity.SyncState{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return state, nil
+}
+
+func (s *syncStateStorage) Save(ctx context.Context, state entity.SyncState) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO sync_state
+			(source, "offset", total, updated_at)
+		VALUES
+			(?, ?, ?, ?)
+		ON CONFLICT (source)
+		DO UPDATE SET
+			"offset" = excluded."offset",
+			total = excluded.total,
+			updated_at = excluded.updated_at;`,
+		state.Source,
+		state.Offset,
+		state.Total,
+		timestamp(time.Now()),
+	)
+	if err != nil {
+		slog.Error("error saving sync state",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return nil
+}
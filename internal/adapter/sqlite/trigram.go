@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"strings"
+	"unicode"
+
+	"modernc.org/sqlite"
+)
+
+// similarityThreshold is the least similarity of a fuzzy match, the default
+// pg_trgm.similarity_threshold of the % operator.
+const similarityThreshold = 0.3
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("similarity", 2, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		a, _ := args[0].(string)
+		b, _ := args[1].(string)
+		return similarity(a, b), nil
+	})
+}
+
+// similarity is the similarity function of pg_trgm: the share of the
+// trigrams of a and b that they have in common.
+func similarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	common := 0
+	for t := range ta {
+		if tb[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+// trigrams returns the trigrams of the words of s the way pg_trgm takes them:
+// lower-cased, with two spaces before each word and one after.
+func trigrams(s string) map[string]bool {
+	res := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			res[string(r[i:i+3])] = true
+		}
+	}
+	return res
+}
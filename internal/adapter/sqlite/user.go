@@ -0,0 +1,183 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ service.UserStorage = new(userStorage)
+
+type userStorage struct {
+	db *sql.DB
+}
+
+func NewUserStorage(db *sql.DB) *userStorage {
+	return &userStorage{
+		db: db,
+	}
+}
+
+const userColumns = `id, login, password, role, COALESCE(email, '')`
+
+func (us *userStorage) Create(ctx context.Context, user entity.User) (entity.User, error) {
+	if user.Role == "" {
+		user.Role = entity.RoleViewer
+	}
+
+	err := us.db.QueryRowContext(
+		ctx,
+		`INSERT INTO "user"
+			(login, password, role, email)
+		VALUES
+			(?, ?, ?, NULLIF(?, ''))
+		ON CONFLICT DO NOTHING
+		RETURNING `+userColumns+`;`,
+		user.Login, user.Password, user.Role, user.Email,
+	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.Email)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.User{}, errors.NewDomainError(errors.ErrAlreadyExists, "")
+		}
+		slog.Error("error adding user to db",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "error adding user to db")
+	}
+
+	return user, nil
+}
+
+func (us *userStorage) GetByLogin(ctx context.Context, login string) (entity.User, error) {
+	return us.get(ctx, `lower(login) = lower(?)`, login)
+}
+
+func (us *userStorage) GetByID(ctx context.Context, ID int64) (entity.User, error) {
+	return us.get(ctx, `id = ?`, ID)
+}
+
+func (us *userStorage) get(ctx context.Context, where string, arg interface{}) (entity.User, error) {
+	var user entity.User
+	err := us.db.QueryRowContext(
+		ctx,
+		`SELECT `+userColumns+` FROM "user"
+		WHERE `+where+`;`,
+		arg,
+	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.Email)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.User{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting user from db",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "error getting user from db")
+	}
+
+	return user, nil
+}
+
+// SetRole changes the role of a user. The transaction holds the write lock
+// of the database from its start, so that two concurrent demotions can't
+// leave the catalog without an admin.
+func (us *userStorage) SetRole(ctx context.Context, dto entity.SetRoleDTO) (entity.User, error) {
+	tx, err := us.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("error beginning transaction",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+	defer tx.Rollback()
+
+	var (
+		user   entity.User
+		admins int
+	)
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT `+userColumns+`,
+			(SELECT count(*) FROM "user" WHERE role = 'admin')
+		FROM "user"
+		WHERE lower(login) = lower(?);`,
+		dto.Login,
+	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.Email, &admins)
+	if err != nil {
+		if stdErrors.Is(err, sql.ErrNoRows) {
+			return entity.User{}, errors.NewDomainError(errors.ErrNoDataFound, "")
+		}
+		slog.Error("error getting user from db",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	if user.Role == entity.RoleAdmin && dto.Role != entity.RoleAdmin && admins <= 1 {
+		return entity.User{}, errors.NewDomainError(errors.ErrLastAdmin, "")
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE "user" SET role = ?
+		WHERE id = ?;`,
+		dto.Role, user.ID,
+	)
+	if err != nil {
+		slog.Error("error updating user role",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Error("error committing transaction",
+			"error", err,
+		)
+		return entity.User{}, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	user.Role = dto.Role
+	return user, nil
+}
+
+func (us *userStorage) HasAdmin(ctx context.Context) (bool, error) {
+	var exists bool
+	err := us.db.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM "user" WHERE role = 'admin');`,
+	).Scan(&exists)
+	if err != nil {
+		slog.Error("error checking for admin",
+			"error", err,
+		)
+		return false, errors.NewDomainError(errors.ErrDB, "")
+	}
+
+	return exists, nil
+}
+
+func (us *userStorage) SetPassword(ctx context.Context, ID int64, password string) error {
+	c, err := us.db.ExecContext(
+		ctx,
+		`UPDATE "user" SET password = ?
+		WHERE id = ?;`,
+		password, ID,
+	)
+	if err != nil {
+		slog.Error("error updating user password",
+			"error", err,
+		)
+		return errors.NewDomainError(errors.ErrDB, "")
+	}
+	if n, _ := c.RowsAffected(); n == 0 {
+		return errors.NewDomainError(errors.ErrNoDataFound, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testAPIKey(t *testing.T, s Storages) {
+	ctx := context.Background()
+	user, err := s.User.Create(ctx, entity.User{Login: "login1", Password: "hash1"})
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	key := entity.APIKey{
+		Name:      "importer",
+		Prefix:    "pck_aaaaaaaa",
+		Hash:      "hash1",
+		Scopes:    []entity.Permission{entity.PermissionCatalogWrite, entity.PermissionSyncManage},
+		CreatedBy: user.ID,
+		CreatedAt: time.Now().Truncate(time.Millisecond),
+		ExpiresAt: &expiresAt,
+	}
+
+	created, err := s.APIKey.Create(ctx, key)
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+	require.Equal(t, key.Scopes, created.Scopes)
+	require.Equal(t, user.ID, created.CreatedBy)
+	_, err = s.APIKey.Create(ctx, key)
+	requireCode(t, errors.ErrAlreadyExists, err)
+
+	got, err := s.APIKey.GetByHash(ctx, "hash1")
+	require.NoError(t, err)
+	require.Equal(t, created.ID, got.ID)
+	require.Equal(t, "importer", got.Name)
+	require.True(t, got.ExpiresAt.Equal(expiresAt))
+	require.Nil(t, got.LastUsedAt)
+	require.Nil(t, got.RevokedAt)
+	_, err = s.APIKey.GetByHash(ctx, "hash2")
+	requireCode(t, errors.ErrNoDataFound, err)
+
+	require.NoError(t, s.APIKey.Touch(ctx, created.ID, time.Now()))
+
+	// A key is revoked once.
+	require.NoError(t, s.APIKey.Revoke(ctx, created.ID, time.Now()))
+	requireCode(t, errors.ErrNoDataFound, s.APIKey.Revoke(ctx, created.ID, time.Now()))
+	requireCode(t, errors.ErrNoDataFound, s.APIKey.Revoke(ctx, missingID, time.Now()))
+
+	keys, err := s.APIKey.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.NotNil(t, keys[0].RevokedAt)
+	require.NotNil(t, keys[0].LastUsedAt)
+}
+
+func testMFA(t *testing.T, s Storages) {
+	ctx := context.Background()
+	user, err := s.User.Create(ctx, entity.User{Login: "login1", Password: "hash1"})
+	require.NoError(t, err)
+
+	_, err = s.MFA.Get(ctx, user.ID)
+	requireCode(t, errors.ErrNoDataFound, err)
+
+	require.NoError(t, s.MFA.Save(ctx, entity.MFA{UserID: user.ID, Secret: []byte("secret1")}, []string{"hash1"}))
+	// A pending enrollment is replaced, along with its codes.
+	require.NoError(t, s.MFA.Save(ctx, entity.MFA{UserID: user.ID, Secret: []byte("secret2")}, []string{"hash2", "hash3"}))
+
+	mfa, err := s.MFA.Get(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.MFA{UserID: user.ID, Secret: []byte("secret2")}, mfa)
+	requireCode(t, errors.ErrNoDataFound, s.MFA.UseRecoveryCode(ctx, user.ID, "hash1", time.Now()))
+
+	// An enabled enrollment isn't replaced.
+	require.NoError(t, s.MFA.Enable(ctx, user.ID))
+	requireCode(t, errors.ErrAlreadyExists, s.MFA.Save(ctx, entity.MFA{UserID: user.ID, Secret: []byte("secret3")}, nil))
+
+	// A step is accepted once, and no earlier one after it.
+	require.NoError(t, s.MFA.UseStep(ctx, user.ID, 100))
+	requireCode(t, errors.ErrNoDataFound, s.MFA.UseStep(ctx, user.ID, 100))
+	requireCode(t, errors.ErrNoDataFound, s.MFA.UseStep(ctx, user.ID, 99))
+
+	require.NoError(t, s.MFA.UseRecoveryCode(ctx, user.ID, "hash2", time.Now()))
+	requireCode(t, errors.ErrNoDataFound, s.MFA.UseRecoveryCode(ctx, user.ID, "hash2", time.Now()))
+
+	mfa, err = s.MFA.Get(ctx, user.ID)
+	require.NoError(t, err)
+	require.True(t, mfa.Enabled)
+	require.Equal(t, int64(100), mfa.LastStep)
+
+	// Deleting the enrollment deletes its codes.
+	require.NoError(t, s.MFA.Delete(ctx, user.ID))
+	_, err = s.MFA.Get(ctx, user.ID)
+	requireCode(t, errors.ErrNoDataFound, err)
+	requireCode(t, errors.ErrNoDataFound, s.MFA.UseRecoveryCode(ctx, user.ID, "hash3", time.Now()))
+}
+
+func testPasswordReset(t *testing.T, s Storages) {
+	ctx := context.Background()
+	user, err := s.User.Create(ctx, entity.User{Login: "login1", Password: "hash1", Email: "user@example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "user@example.com", user.Email)
+
+	now := time.Now().Truncate(time.Millisecond)
+	require.NoError(t, s.PasswordReset.Create(ctx, entity.PasswordReset{
+		Hash: "hash1", UserID: user.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	}))
+	// A new token replaces the previous one.
+	require.NoError(t, s.PasswordReset.Create(ctx, entity.PasswordReset{
+		Hash: "hash2", UserID: user.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	}))
+
+	_, err = s.PasswordReset.Get(ctx, "hash1", now)
+	requireCode(t, errors.ErrNoDataFound, err)
+	reset, err := s.PasswordReset.Get(ctx, "hash2", now)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, reset.UserID)
+
+	// An expired token can't be used.
+	_, err = s.PasswordReset.Get(ctx, "hash2", now.Add(2*time.Hour))
+	requireCode(t, errors.ErrNoDataFound, err)
+	requireCode(t, errors.ErrNoDataFound, s.PasswordReset.Use(ctx, "hash2", now.Add(2*time.Hour)))
+
+	// A token is used once.
+	require.NoError(t, s.PasswordReset.Use(ctx, "hash2", now))
+	requireCode(t, errors.ErrNoDataFound, s.PasswordReset.Use(ctx, "hash2", now))
+	_, err = s.PasswordReset.Get(ctx, "hash2", now)
+	requireCode(t, errors.ErrNoDataFound, err)
+}
+
+func testLoginAttempt(t *testing.T, s Storages) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+
+	_, err := s.LoginAttempt.Get(ctx, "login:login1")
+	requireCode(t, errors.ErrNoDataFound, err)
+
+	attempts, err := s.LoginAttempt.RecordFailure(ctx, "login:login1", now, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts.Failures)
+	attempts, err = s.LoginAttempt.RecordFailure(ctx, "login:login1", now.Add(time.Second), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts.Failures)
+	attempts, err = s.LoginAttempt.RecordFailure(ctx, "ip:10.0.0.1", now, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts.Failures)
+
+	// A lock is only ever extended.
+	require.NoError(t, s.LoginAttempt.Lock(ctx, "login:login1", now.Add(time.Hour)))
+	require.NoError(t, s.LoginAttempt.Lock(ctx, "login:login1", now.Add(time.Minute)))
+
+	attempts, err = s.LoginAttempt.Get(ctx, "login:login1")
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts.Failures)
+	require.True(t, attempts.LockedUntil.Equal(now.Add(time.Hour)))
+
+	// The count starts over once the last failure is out of the window.
+	attempts, err = s.LoginAttempt.RecordFailure(ctx, "login:login1", now.Add(2*time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts.Failures)
+
+	require.NoError(t, s.LoginAttempt.Reset(ctx, "login:login1"))
+	_, err = s.LoginAttempt.Get(ctx, "login:login1")
+	requireCode(t, errors.ErrNoDataFound, err)
+	_, err = s.LoginAttempt.Get(ctx, "ip:10.0.0.1")
+	require.NoError(t, err)
+}
@@ -0,0 +1,741 @@
+// Package storagetest checks that a storage backend behaves like the others:
+// the same unique keys, the same cascades and the same error codes. Every
+// backend runs Run from its own tests.
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/service"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// missingID is the ID of nothing in an empty backend.
+const missingID = int64(1) << 40
+
+// Storages are the storages of one backend, sharing its data.
+type Storages struct {
+	Product         service.ProductStorage
+	Category        service.CategoryStorage
+	User            service.UserStorage
+	Session         service.SessionStorage
+	SyncState       service.SyncStateStorage
+	ReconcileReport service.ReconcileReportStorage
+	APIKey          service.APIKeyStorage
+	MFA             service.MFAStorage
+	PasswordReset   service.PasswordResetStorage
+	LoginAttempt    service.LoginAttemptStorage
+}
+
+// Factory returns the storages of a backend holding no data.
+type Factory func(t *testing.T) Storages
+
+// Run runs the suite against the backend newStorages builds, afresh for
+// every test.
+func Run(t *testing.T, newStorages Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s Storages)
+	}{
+		{"category uniqueness", testCategoryUniqueness},
+		{"category tree", testCategoryTree},
+		{"category move", testCategoryMove},
+		{"category delete cascade", testCategoryDeleteCascade},
+		{"product uniqueness", testProductUniqueness},
+		{"product not found", testProductNotFound},
+		{"product categories", testProductCategories},
+		{"product sync", testProductSync},
+		{"product reconcile", testProductReconcile},
+		{"reconcile report", testReconcileReport},
+		{"user", testUser},
+		{"session", testSession},
+		{"sync state", testSyncState},
+		{"api key", testAPIKey},
+		{"mfa", testMFA},
+		{"password reset", testPasswordReset},
+		{"login attempt", testLoginAttempt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStorages(t))
+		})
+	}
+}
+
+func testCategoryUniqueness(t *testing.T, s Storages) {
+	ctx := context.Background()
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "phone"}))
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "laptop"}))
+	laptopID := categoryID(t, s, "laptop")
+
+	requireCode(t, errors.ErrAlreadyExists, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "phone"}))
+	requireCode(t, errors.ErrAlreadyExists, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "PHONE"}))
+	requireCode(t, errors.ErrCategoryNotFound, s.Category.Add(ctx, entity.AddCategoryDTO{
+		Name:     "android",
+		ParentID: id(missingID),
+	}))
+
+	requireCode(t, errors.ErrAlreadyExists, s.Category.UpdateName(ctx, entity.UpdateCategoryNameDTO{
+		CategoryID: laptopID,
+		NewName:    "Phone",
+	}))
+	requireCode(t, errors.ErrNoDataFound, s.Category.UpdateName(ctx, entity.UpdateCategoryNameDTO{
+		CategoryID: missingID,
+		NewName:    "tablet",
+	}))
+
+	// A category may change the case of its own name.
+	require.NoError(t, s.Category.UpdateName(ctx, entity.UpdateCategoryNameDTO{
+		CategoryID: laptopID,
+		NewName:    "Laptop",
+	}))
+	categoryID(t, s, "Laptop")
+}
+
+func testCategoryTree(t *testing.T, s Storages) {
+	ctx := context.Background()
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "electronics"}))
+	electronicsID := categoryID(t, s, "electronics")
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "phone", ParentID: id(electronicsID)}))
+	phoneID := categoryID(t, s, "phone")
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "android", ParentID: id(phoneID)}))
+	androidID := categoryID(t, s, "android")
+
+	subtree, err := s.Category.GetSubtree(ctx, electronicsID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"electronics", "phone", "android"}, categoryNames(subtree))
+
+	ancestors, err := s.Category.GetAncestors(ctx, androidID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"electronics", "phone", "android"}, categoryNames(ancestors))
+
+	_, err = s.Category.GetSubtree(ctx, missingID)
+	requireCode(t, errors.ErrNoDataFound, err)
+	_, err = s.Category.GetAncestors(ctx, missingID)
+	requireCode(t, errors.ErrNoDataFound, err)
+}
+
+func testCategoryMove(t *testing.T, s Storages) {
+	ctx := context.Background()
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "electronics"}))
+	electronicsID := categoryID(t, s, "electronics")
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "phone", ParentID: id(electronicsID)}))
+	phoneID := categoryID(t, s, "phone")
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "android", ParentID: id(phoneID)}))
+	androidID := categoryID(t, s, "android")
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "appliances"}))
+	appliancesID := categoryID(t, s, "appliances")
+
+	requireCode(t, errors.ErrCategoryCycle, s.Category.Move(ctx, entity.MoveCategoryDTO{
+		CategoryID:  electronicsID,
+		NewParentID: id(androidID),
+	}))
+	requireCode(t, errors.ErrCategoryCycle, s.Category.Move(ctx, entity.MoveCategoryDTO{
+		CategoryID:  phoneID,
+		NewParentID: id(phoneID),
+	}))
+	requireCode(t, errors.ErrNoDataFound, s.Category.Move(ctx, entity.MoveCategoryDTO{
+		CategoryID:  missingID,
+		NewParentID: id(phoneID),
+	}))
+	requireCode(t, errors.ErrCategoryNotFound, s.Category.Move(ctx, entity.MoveCategoryDTO{
+		CategoryID:  phoneID,
+		NewParentID: id(missingID),
+	}))
+
+	// A failed move leaves the tree as it was.
+	subtree, err := s.Category.GetSubtree(ctx, electronicsID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"electronics", "phone", "android"}, categoryNames(subtree))
+
+	// A category moves along with its subtree.
+	require.NoError(t, s.Category.Move(ctx, entity.MoveCategoryDTO{
+		CategoryID:  phoneID,
+		NewParentID: id(appliancesID),
+	}))
+	ancestors, err := s.Category.GetAncestors(ctx, androidID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"appliances", "phone", "android"}, categoryNames(ancestors))
+	subtree, err = s.Category.GetSubtree(ctx, electronicsID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"electronics"}, categoryNames(subtree))
+
+	// Without a new parent a category becomes a root.
+	require.NoError(t, s.Category.Move(ctx, entity.MoveCategoryDTO{CategoryID: androidID}))
+	ancestors, err = s.Category.GetAncestors(ctx, androidID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"android"}, categoryNames(ancestors))
+}
+
+func testCategoryDeleteCascade(t *testing.T, s Storages) {
+	ctx := context.Background()
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "phone"}))
+	phoneID := categoryID(t, s, "phone")
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "android", ParentID: id(phoneID)}))
+	androidID := categoryID(t, s, "android")
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx,
+		entity.AddOrUpdateProductDTO{ProductName: "Pixel", CategoryName: "android"},
+		entity.AddOrUpdateProductDTO{ProductName: "Galaxy", CategoryName: "android"},
+	))
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx,
+		entity.AddOrUpdateProductDTO{ProductName: "Pixel", CategoryName: "google"},
+	))
+	pixelID := productID(t, s, androidID, "Pixel")
+	galaxyID := productID(t, s, androidID, "Galaxy")
+
+	// Deleting a category deletes its subtree and the memberships in it,
+	// but not the products.
+	require.NoError(t, s.Category.Delete(ctx, phoneID))
+	requireCode(t, errors.ErrNoDataFound, s.Category.Delete(ctx, androidID))
+
+	categories, err := s.Category.GetAll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"google"}, categoryNames(categories))
+
+	pixel, err := s.Product.GetByID(ctx, pixelID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"google"}, categoryNames(pixel.Categories))
+
+	galaxy, err := s.Product.GetByID(ctx, galaxyID)
+	require.NoError(t, err)
+	require.Empty(t, galaxy.Categories)
+
+	// The names of the deleted categories are free again.
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "Android"}))
+}
+
+func testProductUniqueness(t *testing.T, s Storages) {
+	ctx := context.Background()
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "phone"}))
+	phoneID := categoryID(t, s, "phone")
+	require.NoError(t, s.Product.Add(ctx, entity.AddProductDTO{
+		ProductName:    "iPhone",
+		CategoryID:     phoneID,
+		ProductDetails: entity.ProductDetails{SKU: "APL-1"},
+	}))
+	require.NoError(t, s.Product.Add(ctx, entity.AddProductDTO{ProductName: "Pixel", CategoryID: phoneID}))
+	pixelID := productID(t, s, phoneID, "Pixel")
+
+	requireCode(t, errors.ErrAlreadyExists, s.Product.Add(ctx, entity.AddProductDTO{
+		ProductName: "IPHONE",
+		CategoryID:  phoneID,
+	}))
+	requireCode(t, errors.ErrAlreadyExists, s.Product.Add(ctx, entity.AddProductDTO{
+		ProductName:    "Galaxy",
+		CategoryID:     phoneID,
+		ProductDetails: entity.ProductDetails{SKU: "APL-1"},
+	}))
+	requireCode(t, errors.ErrCategoryNotFound, s.Product.Add(ctx, entity.AddProductDTO{
+		ProductName: "Galaxy",
+		CategoryID:  missingID,
+	}))
+	requireCode(t, errors.ErrAlreadyExists, s.Product.UpdateName(ctx, entity.UpdateProductNameDTO{
+		ProductID: pixelID,
+		NewName:   "iphone",
+	}))
+	requireCode(t, errors.ErrAlreadyExists, s.Product.UpdateDetails(ctx, entity.UpdateProductDetailsDTO{
+		ProductID:      pixelID,
+		ProductDetails: entity.ProductDetails{SKU: "APL-1"},
+	}))
+
+	// Upserting a name in another case updates the product, name included.
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx, entity.AddOrUpdateProductDTO{
+		ProductName:    "PIXEL",
+		CategoryName:   "phone",
+		ProductDetails: entity.ProductDetails{Brand: "Google"},
+	}))
+	pixel, err := s.Product.GetByID(ctx, pixelID)
+	require.NoError(t, err)
+	require.Equal(t, "PIXEL", pixel.Name)
+	require.Equal(t, "Google", pixel.Brand)
+}
+
+func testProductNotFound(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	_, err := s.Product.GetByID(ctx, missingID)
+	requireCode(t, errors.ErrNoDataFound, err)
+	_, err = s.Product.GetByCategory(ctx, entity.ProductQuery{CategoryID: missingID, Limit: 10})
+	requireCode(t, errors.ErrCategoryNotFound, err)
+	requireCode(t, errors.ErrNoDataFound, s.Product.UpdateName(ctx, entity.UpdateProductNameDTO{
+		ProductID: missingID,
+		NewName:   "Pixel",
+	}))
+	requireCode(t, errors.ErrNoDataFound, s.Product.UpdateDetails(ctx, entity.UpdateProductDetailsDTO{
+		ProductID: missingID,
+	}))
+	requireCode(t, errors.ErrNoDataFound, s.Product.Delete(ctx, missingID))
+}
+
+func testProductCategories(t *testing.T, s Storages) {
+	ctx := context.Background()
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx,
+		entity.AddOrUpdateProductDTO{ProductName: "Pixel", CategoryName: "phone"},
+		entity.AddOrUpdateProductDTO{ProductName: "Vostro", CategoryName: "laptop"},
+	))
+	// A product upserted into another category is in both.
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx,
+		entity.AddOrUpdateProductDTO{ProductName: "Pixel", CategoryName: "google"},
+	))
+	phoneID := categoryID(t, s, "phone")
+	laptopID := categoryID(t, s, "laptop")
+	googleID := categoryID(t, s, "google")
+	pixelID := productID(t, s, phoneID, "Pixel")
+	require.Equal(t, pixelID, productID(t, s, googleID, "Pixel"))
+
+	pixel, err := s.Product.GetByID(ctx, pixelID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"phone", "google"}, categoryNames(pixel.Categories))
+
+	requireCode(t, errors.ErrNoDataFound, s.Product.UpdateCategory(ctx, entity.UpdateProductCategoryDTO{
+		ProductID:     pixelID,
+		OldCategoryID: laptopID,
+		NewCategoryID: phoneID,
+	}))
+	requireCode(t, errors.ErrAlreadyExists, s.Product.UpdateCategory(ctx, entity.UpdateProductCategoryDTO{
+		ProductID:     pixelID,
+		OldCategoryID: phoneID,
+		NewCategoryID: googleID,
+	}))
+	requireCode(t, errors.ErrCategoryNotFound, s.Product.UpdateCategory(ctx, entity.UpdateProductCategoryDTO{
+		ProductID:     pixelID,
+		OldCategoryID: phoneID,
+		NewCategoryID: missingID,
+	}))
+	require.NoError(t, s.Product.UpdateCategory(ctx, entity.UpdateProductCategoryDTO{
+		ProductID:     pixelID,
+		OldCategoryID: phoneID,
+		NewCategoryID: laptopID,
+	}))
+
+	pixel, err = s.Product.GetByID(ctx, pixelID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"laptop", "google"}, categoryNames(pixel.Categories))
+
+	page, err := s.Product.GetByCategory(ctx, entity.ProductQuery{
+		CategoryID: laptopID,
+		Limit:      10,
+		Sort:       entity.SortByName,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Pixel", "Vostro"}, productNames(page.Products))
+
+	page, err = s.Product.GetByCategory(ctx, entity.ProductQuery{CategoryID: phoneID, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, page.Products)
+
+	// Deleting a product deletes its memberships.
+	require.NoError(t, s.Product.Delete(ctx, pixelID))
+	page, err = s.Product.GetByCategory(ctx, entity.ProductQuery{CategoryID: googleID, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, page.Products)
+	requireCode(t, errors.ErrNoDataFound, s.Product.Delete(ctx, pixelID))
+}
+
+func testProductSync(t *testing.T, s Storages) {
+	ctx := context.Background()
+	require.NoError(t, s.Category.Add(ctx, entity.AddCategoryDTO{Name: "phone"}))
+	phoneID := categoryID(t, s, "phone")
+	require.NoError(t, s.Product.Add(ctx, entity.AddProductDTO{
+		ProductName:    "Pixel",
+		CategoryID:     phoneID,
+		ProductDetails: entity.ProductDetails{Brand: "Google"},
+	}))
+	pixelID := productID(t, s, phoneID, "Pixel")
+
+	synced := func(externalID, name string) entity.AddOrUpdateProductDTO {
+		return entity.AddOrUpdateProductDTO{
+			ProductName:      name,
+			CategoryName:     "phone",
+			ProductDetails:   entity.ProductDetails{Brand: "Feed"},
+			ProductSourceRef: entity.ProductSourceRef{Source: "feed", ExternalID: externalID},
+		}
+	}
+
+	// A source product whose name is taken is skipped, the rest of the batch
+	// is written.
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx, synced("1", "PIXEL"), synced("2", "Galaxy")))
+	pixel, err := s.Product.GetByID(ctx, pixelID)
+	require.NoError(t, err)
+	require.Equal(t, "Pixel", pixel.Name)
+	require.Equal(t, "Google", pixel.Brand)
+	galaxyID := productID(t, s, phoneID, "Galaxy")
+
+	// A source product renamed upstream keeps its row.
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx, synced("2", "Galaxy S")))
+	require.Equal(t, galaxyID, productID(t, s, phoneID, "Galaxy S"))
+
+	// Another source can't take the product over by its name.
+	other := synced("2", "galaxy s")
+	other.Source = "other"
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx, other))
+	products, err := s.Product.GetSourceProducts(ctx, "feed")
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	require.Equal(t, galaxyID, products[0].ID)
+	require.Equal(t, "Galaxy S", products[0].Name)
+	products, err = s.Product.GetSourceProducts(ctx, "other")
+	require.NoError(t, err)
+	require.Empty(t, products)
+
+	// A product whose SKU is taken is skipped as well, and a product synced
+	// without attributes keeps the ones set through the API.
+	require.NoError(t, s.Product.UpdateDetails(ctx, entity.UpdateProductDetailsDTO{
+		ProductID: galaxyID,
+		ProductDetails: entity.ProductDetails{
+			SKU:        "GLX",
+			Attributes: entity.ProductAttributes{"color": "black"},
+		},
+	}))
+	galaxy, nexus := synced("2", "Galaxy S"), synced("3", "Nexus")
+	galaxy.SKU, nexus.SKU = "GLX", "GLX"
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx, galaxy, nexus, synced("4", "Nokia")))
+	products, err = s.Product.GetSourceProducts(ctx, "feed")
+	require.NoError(t, err)
+	names := make([]string, len(products))
+	for i, p := range products {
+		names[i] = p.Name
+	}
+	require.ElementsMatch(t, []string{"Galaxy S", "Nokia"}, names)
+
+	stored, err := s.Product.GetByID(ctx, galaxyID)
+	require.NoError(t, err)
+	require.Equal(t, "Feed", stored.Brand)
+	require.Equal(t, entity.ProductAttributes{"color": "black"}, stored.Attributes)
+
+	// A product repeated in a batch keeps the details of its first
+	// occurrence and the category of its last one only.
+	first, last := synced("5", "Moto"), synced("5", "moto")
+	first.Brand, last.CategoryName = "Motorola", "android"
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx,
+		first,
+		entity.AddOrUpdateProductDTO{ProductName: " ", CategoryName: "phone"},
+		last,
+	))
+	moto, err := s.Product.GetByID(ctx, productID(t, s, categoryID(t, s, "android"), "Moto"))
+	require.NoError(t, err)
+	require.Equal(t, "Motorola", moto.Brand)
+	require.Equal(t, []string{"android"}, categoryNames(moto.Categories))
+}
+
+func testProductReconcile(t *testing.T, s Storages) {
+	ctx := context.Background()
+	product := func(externalID, name, category string) entity.AddOrUpdateProductDTO {
+		return entity.AddOrUpdateProductDTO{
+			ProductName:      name,
+			CategoryName:     category,
+			ProductSourceRef: entity.ProductSourceRef{Source: "dummyjson", ExternalID: externalID},
+		}
+	}
+	require.NoError(t, s.Product.AddOrUpdateProduct(ctx,
+		product("1", "redmi", "phones"),
+		product("2", "iphone", "phones"),
+		product("3", "nokia", "phones"),
+		entity.AddOrUpdateProductDTO{ProductName: "manual", CategoryName: "phones"},
+	))
+	phonesID := categoryID(t, s, "phones")
+	manualID := productID(t, s, phonesID, "manual")
+
+	current, err := s.Product.GetSourceProducts(ctx, "dummyjson")
+	require.NoError(t, err)
+	require.Len(t, current, 3)
+	require.Equal(t, []string{"phones"}, current[0].Categories)
+	_, err = s.Product.GetSourceProducts(ctx, "warehouse")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		plan          entity.ReconcilePlan
+		wantConflicts []entity.ProductConflict
+		want          []entity.SourceProduct
+	}{
+		{
+			// A product that can't be written is rolled back alone, the
+			// one taking a name as well as the one failing after it was
+			// written.
+			name: "rename, move, tombstone and conflicts",
+			plan: entity.ReconcilePlan{
+				Source: "dummyjson",
+				Mode:   entity.DeleteModeTombstone,
+				Upserts: []entity.AddOrUpdateProductDTO{
+					product("1", "redmi note", "phones"),
+					product("2", "iphone", "tablets"),
+					product("4", "MANUAL", "android"),
+					product("5", "nexus", " "),
+				},
+				Deletes: []string{"3"},
+			},
+			wantConflicts: []entity.ProductConflict{
+				{ExternalID: "4", Name: "MANUAL", Reason: "violates product_name_lower_key"},
+				{ExternalID: "5", Name: "nexus", Reason: "violates category_name_check"},
+			},
+			want: []entity.SourceProduct{
+				{ExternalID: "1", Name: "redmi note", Categories: []string{"phones"}},
+				{ExternalID: "2", Name: "iphone", Categories: []string{"tablets"}},
+				{ExternalID: "3", Name: "nokia", Categories: []string{"phones"}, Deleted: true},
+			},
+		},
+		{
+			name: "restore and delete",
+			plan: entity.ReconcilePlan{
+				Source: "dummyjson",
+				Mode:   entity.DeleteModeDelete,
+				Upserts: []entity.AddOrUpdateProductDTO{
+					product("3", "nokia", "phones"),
+				},
+				Deletes: []string{"1", "2"},
+			},
+			want: []entity.SourceProduct{
+				{ExternalID: "3", Name: "nokia", Categories: []string{"phones"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts, err := s.Product.ApplyReconcilePlan(ctx, tt.plan)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantConflicts, conflicts)
+
+			got, err := s.Product.GetSourceProducts(ctx, "dummyjson")
+			require.NoError(t, err)
+			for i := range got {
+				got[i].ID = 0
+			}
+			require.ElementsMatch(t, tt.want, got)
+		})
+	}
+
+	// The conflicting products left nothing behind.
+	categories, err := s.Category.GetAll(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"phones", "tablets"}, categoryNames(categories))
+	manual, err := s.Product.GetByID(ctx, manualID)
+	require.NoError(t, err)
+	require.Equal(t, "manual", manual.Name)
+	require.Equal(t, entity.ProductSourceRef{}, manual.ProductSourceRef)
+	page, err := s.Product.GetByCategory(ctx, entity.ProductQuery{
+		CategoryID: phonesID,
+		Limit:      10,
+		Sort:       entity.SortByName,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"manual", "nokia"}, productNames(page.Products))
+}
+
+func testReconcileReport(t *testing.T, s Storages) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	for _, source := range []string{"dummyjson", "warehouse", "dummyjson"} {
+		_, err := s.ReconcileReport.Save(ctx, entity.ReconcileReport{
+			Source:     source,
+			Mode:       entity.DeleteModeTombstone,
+			StartedAt:  now,
+			FinishedAt: now,
+			Added:      []entity.ReconciledProduct{{ExternalID: "1", Name: "redmi"}},
+			Conflicts:  []entity.ProductConflict{{ExternalID: "2", Name: "iphone", Reason: "violates product_sku_key"}},
+		})
+		require.NoError(t, err)
+	}
+
+	reports, err := s.ReconcileReport.List(ctx, "dummyjson", 10)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Greater(t, reports[0].ID, reports[1].ID)
+	require.Equal(t, entity.DeleteModeTombstone, reports[0].Mode)
+	require.True(t, reports[0].StartedAt.Equal(now))
+	require.Equal(t, []entity.ReconciledProduct{{ExternalID: "1", Name: "redmi"}}, reports[0].Added)
+	require.Equal(t, []entity.ProductConflict{{ExternalID: "2", Name: "iphone", Reason: "violates product_sku_key"}}, reports[0].Conflicts)
+
+	reports, err = s.ReconcileReport.List(ctx, "", 1)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, "dummyjson", reports[0].Source)
+
+	reports, err = s.ReconcileReport.List(ctx, "erp", 10)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+}
+
+func testUser(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	user, err := s.User.Create(ctx, entity.User{Login: "Login1", Password: "hash1"})
+	require.NoError(t, err)
+	require.Equal(t, entity.RoleViewer, user.Role)
+	_, err = s.User.Create(ctx, entity.User{Login: "login1", Password: "hash2"})
+	requireCode(t, errors.ErrAlreadyExists, err)
+
+	got, err := s.User.GetByLogin(ctx, "LOGIN1")
+	require.NoError(t, err)
+	require.Equal(t, user, got)
+	_, err = s.User.GetByLogin(ctx, "login2")
+	requireCode(t, errors.ErrNoDataFound, err)
+	_, err = s.User.GetByID(ctx, missingID)
+	requireCode(t, errors.ErrNoDataFound, err)
+
+	require.NoError(t, s.User.SetPassword(ctx, user.ID, "hash3"))
+	requireCode(t, errors.ErrNoDataFound, s.User.SetPassword(ctx, missingID, "hash3"))
+	got, err = s.User.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "hash3", got.Password)
+
+	hasAdmin, err := s.User.HasAdmin(ctx)
+	require.NoError(t, err)
+	require.False(t, hasAdmin)
+
+	_, err = s.User.SetRole(ctx, entity.SetRoleDTO{Login: "login1", Role: entity.RoleAdmin})
+	require.NoError(t, err)
+	_, err = s.User.SetRole(ctx, entity.SetRoleDTO{Login: "login1", Role: entity.RoleViewer})
+	requireCode(t, errors.ErrLastAdmin, err)
+	_, err = s.User.SetRole(ctx, entity.SetRoleDTO{Login: "login2", Role: entity.RoleAdmin})
+	requireCode(t, errors.ErrNoDataFound, err)
+}
+
+func testSession(t *testing.T, s Storages) {
+	ctx := context.Background()
+	now := time.Now()
+
+	user, err := s.User.Create(ctx, entity.User{Login: "login1"})
+	require.NoError(t, err)
+	other, err := s.User.Create(ctx, entity.User{Login: "login2"})
+	require.NoError(t, err)
+
+	session, err := s.Session.Create(ctx, entity.Session{
+		Token: "token1", UserID: user.ID, Expiry: now.Add(time.Hour), LastUsedAt: now,
+	})
+	require.NoError(t, err)
+	_, err = s.Session.Create(ctx, entity.Session{
+		Token: "token1", UserID: other.ID, Expiry: now.Add(time.Hour), LastUsedAt: now,
+	})
+	requireCode(t, errors.ErrAlreadyExists, err)
+	_, err = s.Session.Create(ctx, entity.Session{
+		Token: "token2", UserID: user.ID, Expiry: now.Add(-time.Hour), LastUsedAt: now,
+	})
+	require.NoError(t, err)
+
+	sessions, err := s.Session.GetByUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session.ID, sessions[0].ID)
+
+	got, err := s.Session.GetByID(ctx, session.ID)
+	require.NoError(t, err)
+	require.Equal(t, "token1", got.Token)
+	require.Equal(t, user.ID, got.UserID)
+	_, err = s.Session.GetByID(ctx, missingID)
+	requireCode(t, errors.ErrNoDataFound, err)
+
+	// A rotated token can't be rotated again, and a token can't be taken
+	// from another session.
+	later := now.Add(time.Minute)
+	require.NoError(t, s.Session.Rotate(ctx, session.ID, "token1", "token3", later))
+	requireCode(t, errors.ErrNoDataFound, s.Session.Rotate(ctx, session.ID, "token1", "token4", later))
+	requireCode(t, errors.ErrNoDataFound, s.Session.Rotate(ctx, missingID, "token3", "token4", later))
+	requireCode(t, errors.ErrDB, s.Session.Rotate(ctx, session.ID, "token3", "token2", later))
+	got, err = s.Session.GetByID(ctx, session.ID)
+	require.NoError(t, err)
+	require.Equal(t, "token3", got.Token)
+	require.WithinDuration(t, later, got.LastUsedAt, time.Millisecond)
+
+	// The old token is free again.
+	kept, err := s.Session.Create(ctx, entity.Session{
+		Token: "token1", UserID: user.ID, Expiry: now.Add(time.Hour), LastUsedAt: now,
+	})
+	require.NoError(t, err)
+
+	requireCode(t, errors.ErrNoDataFound, s.Session.DeleteByID(ctx, other.ID, session.ID))
+	require.NoError(t, s.Session.DeleteExpired(ctx))
+	require.NoError(t, s.Session.DeleteOthers(ctx, user.ID, kept.ID))
+	_, err = s.Session.GetByID(ctx, session.ID)
+	requireCode(t, errors.ErrNoDataFound, err)
+
+	sessions, err = s.Session.GetByUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, kept.ID, sessions[0].ID)
+
+	require.NoError(t, s.Session.DeleteByID(ctx, user.ID, kept.ID))
+	require.NoError(t, s.Session.DeleteByUser(ctx, user.ID))
+	sessions, err = s.Session.GetByUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func testSyncState(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	_, err := s.SyncState.Get(ctx, "dummyjson")
+	requireCode(t, errors.ErrNoDataFound, err)
+
+	require.NoError(t, s.SyncState.Save(ctx, entity.SyncState{Source: "dummyjson", Offset: 10, Total: 100}))
+	require.NoError(t, s.SyncState.Save(ctx, entity.SyncState{Source: "dummyjson", Offset: 20, Total: 100}))
+
+	state, err := s.SyncState.Get(ctx, "dummyjson")
+	require.NoError(t, err)
+	require.Equal(t, 20, state.Offset)
+	require.Equal(t, 100, state.Total)
+	require.False(t, state.UpdatedAt.IsZero())
+}
+
+func requireCode(t *testing.T, code errors.ErrorCode, err error) {
+	t.Helper()
+	require.Error(t, err)
+	require.Equal(t, code, errors.Code(err))
+}
+
+// categoryID returns the ID of the category named name.
+func categoryID(t *testing.T, s Storages, name string) int64 {
+	t.Helper()
+	categories, err := s.Category.GetAll(context.Background())
+	require.NoError(t, err)
+	for _, c := range categories {
+		if c.Name == name {
+			return c.ID
+		}
+	}
+	t.Fatalf("no category %q", name)
+	return 0
+}
+
+// productID returns the ID of the product named name in the category with
+// ID categoryID.
+func productID(t *testing.T, s Storages, categoryID int64, name string) int64 {
+	t.Helper()
+	page, err := s.Product.GetByCategory(context.Background(), entity.ProductQuery{
+		CategoryID: categoryID,
+		NamePrefix: name,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	for _, p := range page.Products {
+		if p.Name == name {
+			return p.ID
+		}
+	}
+	t.Fatalf("no product %q", name)
+	return 0
+}
+
+func categoryNames(categories []entity.Category) []string {
+	names := make([]string, len(categories))
+	for i, c := range categories {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func productNames(products []entity.ProductCategoryListItem) []string {
+	names := make([]string, len(products))
+	for i, p := range products {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func id(v int64) *int64 {
+	return &v
+}
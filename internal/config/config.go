@@ -1,6 +1,11 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"log/slog"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/num30/config"
@@ -9,14 +14,37 @@ import (
 type Config struct {
 	RunAddress            string        `default:":8080" envvar:"RUN_ADDR"`
 	LogLevel              string        `default:"info" flag:"loglevel" envvar:"LOGLEVEL"`
-	TokenTTL              time.Duration `default:"24h"`
+	TokenTTL              time.Duration `default:"24h" envvar:"TOKEN_TTL"`
+	AccessTokenTTL        time.Duration `default:"15m" envvar:"ACCESS_TOKEN_TTL"`
+	TokenSigningKey       string        `envvar:"TOKEN_SIGNING_KEY"`
 	ProductUpdateInterval time.Duration `default:"1h" envvar:"UPDATE_INTERVAL"`
 	DummyJSONAddress      string        `default:"https://dummyjson.com"`
-	DB                    Database      `default:"{}"`
-	DebugMode             bool          `flag:"debug"`
+	SyncPageSize          int           `default:"10" envvar:"SYNC_PAGE_SIZE"`
+	FullResync            bool          `flag:"fullresync" envvar:"FULL_RESYNC"`
+	Reconcile             bool          `flag:"reconcile" envvar:"RECONCILE"`
+	ReconcileDeleteMode   string        `default:"tombstone" envvar:"RECONCILE_DELETE_MODE" validate:"oneof=tombstone delete"`
+	SyncRetryDelay        time.Duration `default:"1s" envvar:"SYNC_RETRY_DELAY"`
+	SyncRetryMaxDelay     time.Duration `default:"5m" envvar:"SYNC_RETRY_MAX_DELAY"`
+	SyncBreakerThreshold  int           `default:"5" envvar:"SYNC_BREAKER_THRESHOLD"`
+	SyncBreakerCooldown   time.Duration `default:"1m" envvar:"SYNC_BREAKER_COOLDOWN"`
+	ProductSources        []ProductSource
+	MigrateOnBoot         bool           `flag:"migrateonboot" envvar:"MIGRATE_ON_BOOT"`
+	Storage               string         `default:"db" envvar:"STORAGE" validate:"oneof=db memory"`
+	DB                    Database       `default:"{}"`
+	Admin                 Admin          `default:"{}"`
+	LoginThrottle         LoginThrottle  `default:"{}"`
+	PasswordPolicy        PasswordPolicy `default:"{}"`
+	MFA                   MFA            `default:"{}"`
+	PasswordReset         PasswordReset  `default:"{}"`
+	Notifier              Notifier       `default:"{}"`
+	DebugMode             bool           `flag:"debug"`
 }
 
+// Database is the Postgres database, or with Driver sqlite the SQLite file
+// at Path.
 type Database struct {
+	Driver   string `default:"postgres" envvar:"DB_DRIVER" validate:"oneof=postgres sqlite"`
+	Path     string `default:"catalog.db" envvar:"DB_PATH"`
 	Host     string `default:"localhost" validate:"required" envvar:"DB_HOST"`
 	Port     int    `default:"5434" envvar:"DB_PORT"`
 	Password string `default:"catalog_db" validate:"required" envvar:"DB_PASS"`
@@ -24,6 +52,136 @@ type Database struct {
 	Username string `default:"catalog_db" envvar:"DB_USERNAME"`
 }
 
+// Admin is the user created as the first admin on boot if the catalog has
+// none. Nothing is created if Login is empty.
+type Admin struct {
+	Login    string `envvar:"ADMIN_LOGIN"`
+	Password string `envvar:"ADMIN_PASSWORD"`
+}
+
+// MFA configures two-factor authentication. Issuer names the catalog in
+// authenticator apps.
+type MFA struct {
+	Issuer       string        `default:"Product Catalog" envvar:"MFA_ISSUER"`
+	ChallengeTTL time.Duration `default:"5m" envvar:"MFA_CHALLENGE_TTL"`
+}
+
+// PasswordReset configures the reset links: how long they are valid and the
+// page they lead to.
+type PasswordReset struct {
+	TTL time.Duration `default:"1h" envvar:"PASSWORD_RESET_TTL"`
+	URL string        `default:"http://localhost:8080/password/reset" envvar:"PASSWORD_RESET_URL"`
+}
+
+// Notifier selects how notifications reach users: "log" writes them to
+// LogFile, or to the standard error if it is empty, and "smtp" mails them.
+type Notifier struct {
+	Kind    string `default:"log" envvar:"NOTIFIER" validate:"oneof=log smtp"`
+	LogFile string `envvar:"NOTIFIER_LOG_FILE"`
+	SMTP    SMTP   `default:"{}"`
+}
+
+type SMTP struct {
+	Addr     string `envvar:"SMTP_ADDR"`
+	Username string `envvar:"SMTP_USERNAME"`
+	Password string `envvar:"SMTP_PASSWORD"`
+	From     string `envvar:"SMTP_FROM"`
+}
+
+// LoginThrottle configures the throttling of failed logins, kept in Store:
+// "postgres" to share it between replicas, or "memory".
+type LoginThrottle struct {
+	Store         string        `default:"postgres" envvar:"LOGIN_ATTEMPT_STORE" validate:"oneof=postgres memory"`
+	MaxFailures   int           `default:"5" envvar:"LOGIN_MAX_FAILURES"`
+	MaxIPFailures int           `default:"50" envvar:"LOGIN_MAX_IP_FAILURES"`
+	Window        time.Duration `default:"15m" envvar:"LOGIN_FAILURE_WINDOW"`
+	Delay         time.Duration `default:"1s" envvar:"LOGIN_DELAY"`
+	MaxDelay      time.Duration `default:"30s" envvar:"LOGIN_MAX_DELAY"`
+	Lockout       time.Duration `default:"15m" envvar:"LOGIN_LOCKOUT"`
+}
+
+// PasswordPolicy configures the passwords accepted on registration.
+// BannedListFile lists banned passwords, one per line.
+type PasswordPolicy struct {
+	MinLength      int    `default:"8" envvar:"PASSWORD_MIN_LENGTH"`
+	BannedListFile string `envvar:"PASSWORD_BANNED_LIST"`
+}
+
+// BannedPasswords reads the banned password list, if there is one.
+func (p PasswordPolicy) BannedPasswords() ([]string, error) {
+	if p.BannedListFile == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(p.BannedListFile)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(string(b), "\n"), nil
+}
+
+// ProductSource configures an upstream product source. Type selects the
+// provider: "dummyjson" and "http" read URL, "dir" reads the JSON files of Dir.
+// Fields maps product fields to paths in the upstream records for the
+// "http" and "dir" providers.
+type ProductSource struct {
+	Name        string
+	Type        string
+	URL         string
+	Dir         string
+	ItemsPath   string
+	TotalPath   string
+	OffsetParam string
+	LimitParam  string
+	Fields      map[string]string
+}
+
+// Sources returns the configured product sources, dummyjson at
+// DummyJSONAddress if there are none.
+func (c *Config) Sources() []ProductSource {
+	if len(c.ProductSources) > 0 {
+		return c.ProductSources
+	}
+	return []ProductSource{{
+		Name: "dummyjson",
+		Type: "dummyjson",
+		URL:  c.DummyJSONAddress,
+	}}
+}
+
+// SigningKey returns the key that signs access and refresh tokens, decoded
+// from the base64 of TokenSigningKey. Without one a random key is used, so the
+// tokens issued before a restart stop working.
+func (c *Config) SigningKey() ([]byte, error) {
+	if c.TokenSigningKey != "" {
+		return base64.StdEncoding.DecodeString(c.TokenSigningKey)
+	}
+
+	slog.Warn("no token signing key configured, using a random one")
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	return key, err
+}
+
+// LogValue logs the config with its secrets redacted.
+func (c Config) LogValue() slog.Value {
+	c.TokenSigningKey = redact(c.TokenSigningKey)
+	c.DB.Password = redact(c.DB.Password)
+	c.Admin.Password = redact(c.Admin.Password)
+	c.Notifier.SMTP.Password = redact(c.Notifier.SMTP.Password)
+
+	// config has no LogValue, so slog logs its fields.
+	type config Config
+	return slog.AnyValue(config(c))
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
 func MustBuild(cfgFile string) *Config {
 	var conf Config
 	err := config.NewConfReader(cfgFile).Read(&conf)
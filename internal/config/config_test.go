@@ -0,0 +1,67 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMustBuild_envVars(t *testing.T) {
+	// The flags are parsed from os.Args, which holds the flags of go test.
+	args := os.Args
+	os.Args = []string{"catalog"}
+	t.Cleanup(func() {
+		os.Args = args
+	})
+
+	tests := []struct {
+		name   string
+		envVar string
+		value  string
+		got    func(c *Config) interface{}
+		want   interface{}
+	}{
+		{
+			name:   "migrate on boot",
+			envVar: "MIGRATE_ON_BOOT",
+			value:  "true",
+			got:    func(c *Config) interface{} { return c.MigrateOnBoot },
+			want:   true,
+		},
+		{
+			name:   "full resync",
+			envVar: "FULL_RESYNC",
+			value:  "true",
+			got:    func(c *Config) interface{} { return c.FullResync },
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.envVar, tt.value)
+
+			c := MustBuild("catalog")
+			require.Equal(t, tt.want, tt.got(c))
+		})
+	}
+}
+
+func TestConfig_LogValue(t *testing.T) {
+	c := &Config{
+		TokenSigningKey: "signing-key",
+		DB:              Database{Password: "db-password"},
+		Admin:           Admin{Login: "admin", Password: "admin-password"},
+		Notifier:        Notifier{SMTP: SMTP{Password: "smtp-password"}},
+	}
+
+	var b strings.Builder
+	slog.New(slog.NewTextHandler(&b, nil)).Info("config", "struct", c)
+
+	require.Contains(t, b.String(), "[REDACTED]")
+	for _, secret := range []string{"signing-key", "db-password", "admin-password", "smtp-password"} {
+		require.NotContains(t, b.String(), secret)
+	}
+}
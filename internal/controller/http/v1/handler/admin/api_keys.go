@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	apiKeysURL = "/api/v1/admin/apiKeys"
+)
+
+type APIKeysUsecase interface {
+	APIKeys(ctx context.Context) ([]entity.APIKey, error)
+}
+
+type apiKeysHandler struct {
+	usecase     APIKeysUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewAPIKeysHandler(usecase APIKeysUsecase) *apiKeysHandler {
+	return &apiKeysHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *apiKeysHandler) AddToRouter(r *chi.Mux) {
+	r.Route(apiKeysURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *apiKeysHandler) Middlewares(md ...func(http.Handler) http.Handler) *apiKeysHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP lists all API keys, revoked ones included.
+func (h *apiKeysHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	keys, err := h.usecase.APIKeys(r.Context())
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(keys)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_apiKeysHandler_ServeHTTP(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	keys := []entity.APIKey{
+		{ID: 2, Name: "exporter", Prefix: "pck_bbbbbbbb", Scopes: []entity.Permission{entity.PermissionSyncManage}, CreatedAt: now},
+		{ID: 1, Name: "importer", Prefix: "pck_aaaaaaaa", Scopes: []entity.Permission{entity.PermissionCatalogWrite}, CreatedAt: now, RevokedAt: &now},
+	}
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockAPIKeysUsecase := mocks.NewMockAPIKeysUsecase(ctrl)
+	NewAPIKeysHandler(mockAPIKeysUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		code    int
+		want    []entity.APIKey
+		prepare func()
+	}{
+		{
+			name: "positive",
+			code: 200,
+			want: keys,
+			prepare: func() {
+				mockAPIKeysUsecase.EXPECT().APIKeys(gomock.Any()).Return(keys, nil)
+			},
+		},
+		{
+			name: "db error",
+			code: 500,
+			prepare: func() {
+				mockAPIKeysUsecase.EXPECT().APIKeys(gomock.Any()).Return(nil, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "GET", "/api/v1/admin/apiKeys", nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code != 200 {
+				return
+			}
+
+			var got []entity.APIKey
+			require.NoError(t, json.Unmarshal([]byte(body), &got))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
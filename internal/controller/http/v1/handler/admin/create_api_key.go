@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	createAPIKeyURL = "/api/v1/admin/apiKeys/create"
+)
+
+type CreateAPIKeyUsecase interface {
+	CreateAPIKey(ctx context.Context, dto entity.CreateAPIKeyDTO) (entity.NewAPIKey, error)
+}
+
+type createAPIKeyHandler struct {
+	usecase     CreateAPIKeyUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewCreateAPIKeyHandler(usecase CreateAPIKeyUsecase) *createAPIKeyHandler {
+	return &createAPIKeyHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *createAPIKeyHandler) AddToRouter(r *chi.Mux) {
+	r.Route(createAPIKeyURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *createAPIKeyHandler) Middlewares(md ...func(http.Handler) http.Handler) *createAPIKeyHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP creates an API key and responds with it. The plain key is in the
+// response only, it can't be read later.
+func (h *createAPIKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var dto entity.CreateAPIKeyDTO
+	defer r.Body.Close()
+
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	dto.CreatedBy, _ = r.Context().Value(middleware.Key("userID")).(int64)
+
+	key, err := h.usecase.CreateAPIKey(r.Context(), dto)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(key)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_createAPIKeyHandler_ServeHTTP(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	dto := entity.CreateAPIKeyDTO{
+		Name:      "importer",
+		Scopes:    []entity.Permission{entity.PermissionCatalogWrite},
+		ExpiresAt: &expiresAt,
+	}
+	validRequestBody, err := json.Marshal(dto)
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Hour)
+	expiredRequestBody, err := json.Marshal(entity.CreateAPIKeyDTO{
+		Name:      "importer",
+		Scopes:    []entity.Permission{entity.PermissionCatalogWrite},
+		ExpiresAt: &past,
+	})
+	require.NoError(t, err)
+
+	unknownScopeRequestBody, err := json.Marshal(entity.CreateAPIKeyDTO{
+		Name:   "importer",
+		Scopes: []entity.Permission{"catalog:*"},
+	})
+	require.NoError(t, err)
+
+	newKey := entity.NewAPIKey{
+		APIKey: entity.APIKey{ID: 1, Name: "importer", Prefix: "pck_abcdefgh", Hash: "hash", Scopes: dto.Scopes},
+		Key:    "pck_abcdefghsecret",
+	}
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockCreateAPIKeyUsecase := mocks.NewMockCreateAPIKeyUsecase(ctrl)
+	NewCreateAPIKeyHandler(mockCreateAPIKeyUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		reqBody json.RawMessage
+		code    int
+		prepare func()
+	}{
+		{
+			name:    "positive",
+			reqBody: validRequestBody,
+			code:    200,
+			prepare: func() {
+				mockCreateAPIKeyUsecase.EXPECT().CreateAPIKey(gomock.Any(), dto).Return(newKey, nil)
+			},
+		},
+		{
+			name:    "no name",
+			reqBody: []byte(`{"Scopes":["catalog:write"]}`),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "no scopes",
+			reqBody: []byte(`{"Name":"importer"}`),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "unknown scope",
+			reqBody: unknownScopeRequestBody,
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "expired",
+			reqBody: expiredRequestBody,
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "db error",
+			reqBody: validRequestBody,
+			code:    500,
+			prepare: func() {
+				mockCreateAPIKeyUsecase.EXPECT().CreateAPIKey(gomock.Any(), dto).
+					Return(entity.NewAPIKey{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "POST", "/api/v1/admin/apiKeys/create", tt.reqBody)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code != 200 {
+				return
+			}
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal([]byte(body), &got))
+			require.Equal(t, newKey.Key, got["key"])
+			require.Equal(t, newKey.Prefix, got["prefix"])
+			require.NotContains(t, got, "Hash")
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	grantRoleURL = "/api/v1/admin/grantRole"
+)
+
+type GrantRoleUsecase interface {
+	GrantRole(ctx context.Context, dto entity.SetRoleDTO) error
+}
+
+type grantRoleHandler struct {
+	usecase     GrantRoleUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewGrantRoleHandler(usecase GrantRoleUsecase) *grantRoleHandler {
+	return &grantRoleHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *grantRoleHandler) AddToRouter(r *chi.Mux) {
+	r.Route(grantRoleURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *grantRoleHandler) Middlewares(md ...func(http.Handler) http.Handler) *grantRoleHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *grantRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var dto entity.SetRoleDTO
+	defer r.Body.Close()
+
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	err := h.usecase.GrantRole(r.Context(), dto)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_grantRoleHandler_ServeHTTP(t *testing.T) {
+	dto := entity.SetRoleDTO{Login: "login1", Role: entity.RoleEditor}
+	validRequestBody, err := json.Marshal(dto)
+	require.NoError(t, err)
+
+	invalidRoleRequestBody, err := json.Marshal(entity.SetRoleDTO{Login: "login1", Role: "owner"})
+	require.NoError(t, err)
+
+	noLoginRequestBody, err := json.Marshal(entity.SetRoleDTO{Role: entity.RoleEditor})
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockGrantRoleUsecase := mocks.NewMockGrantRoleUsecase(ctrl)
+	NewGrantRoleHandler(mockGrantRoleUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		reqBody json.RawMessage
+		code    int
+		prepare func()
+	}{
+		{
+			name:    "positive",
+			reqBody: validRequestBody,
+			code:    200,
+			prepare: func() {
+				mockGrantRoleUsecase.EXPECT().GrantRole(gomock.Any(), dto).Return(nil)
+			},
+		},
+		{
+			name:    "invalid body",
+			reqBody: []byte("sdfasd"),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "unknown role",
+			reqBody: invalidRoleRequestBody,
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "no login",
+			reqBody: noLoginRequestBody,
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "user not found",
+			reqBody: validRequestBody,
+			code:    404,
+			prepare: func() {
+				mockGrantRoleUsecase.EXPECT().GrantRole(gomock.Any(), dto).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:    "last admin",
+			reqBody: validRequestBody,
+			code:    409,
+			prepare: func() {
+				mockGrantRoleUsecase.EXPECT().GrantRole(gomock.Any(), dto).
+					Return(errors.NewDomainError(errors.ErrLastAdmin, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, "", server, "POST", "/api/v1/admin/grantRole", tt.reqBody)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	revokeAPIKeyURL = "/api/v1/admin/apiKeys/revoke/{id}"
+)
+
+type RevokeAPIKeyUsecase interface {
+	RevokeAPIKey(ctx context.Context, ID int64) error
+}
+
+type revokeAPIKeyHandler struct {
+	usecase     RevokeAPIKeyUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewRevokeAPIKeyHandler(usecase RevokeAPIKeyUsecase) *revokeAPIKeyHandler {
+	return &revokeAPIKeyHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *revokeAPIKeyHandler) AddToRouter(r *chi.Mux) {
+	r.Route(revokeAPIKeyURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *revokeAPIKeyHandler) Middlewares(md ...func(http.Handler) http.Handler) *revokeAPIKeyHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *revokeAPIKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	stringID := chi.URLParam(r, "id")
+	ID, err := strconv.ParseInt(stringID, 10, 64)
+	if err != nil {
+		slog.Error("error parsing id from param to int64", "error", err)
+		problem.Invalid(w, r, problem.FieldError{Field: "id", Message: "should be an integer"})
+		return
+	}
+
+	err = h.usecase.RevokeAPIKey(r.Context(), ID)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_revokeAPIKeyHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockRevokeAPIKeyUsecase := mocks.NewMockRevokeAPIKeyUsecase(ctrl)
+	NewRevokeAPIKeyHandler(mockRevokeAPIKeyUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		prepare func()
+	}{
+		{
+			name: "positive",
+			path: "/api/v1/admin/apiKeys/revoke/1",
+			code: 200,
+			prepare: func() {
+				mockRevokeAPIKeyUsecase.EXPECT().RevokeAPIKey(gomock.Any(), int64(1)).Return(nil)
+			},
+		},
+		{
+			name:    "invalid id",
+			path:    "/api/v1/admin/apiKeys/revoke/abc",
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name: "not found",
+			path: "/api/v1/admin/apiKeys/revoke/2",
+			code: 404,
+			prepare: func() {
+				mockRevokeAPIKeyUsecase.EXPECT().RevokeAPIKey(gomock.Any(), int64(2)).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, "", server, "POST", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	revokeRoleURL = "/api/v1/admin/revokeRole"
+)
+
+type RevokeRoleUsecase interface {
+	RevokeRole(ctx context.Context, login string) error
+}
+
+type revokeRoleHandler struct {
+	usecase     RevokeRoleUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewRevokeRoleHandler(usecase RevokeRoleUsecase) *revokeRoleHandler {
+	return &revokeRoleHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *revokeRoleHandler) AddToRouter(r *chi.Mux) {
+	r.Route(revokeRoleURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *revokeRoleHandler) Middlewares(md ...func(http.Handler) http.Handler) *revokeRoleHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP takes the granted role away from the user, leaving them a viewer.
+func (h *revokeRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var dto struct {
+		Login string `validate:"required,max=255,nocontrol" mod:"trim"`
+	}
+	defer r.Body.Close()
+
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	err := h.usecase.RevokeRole(r.Context(), dto.Login)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
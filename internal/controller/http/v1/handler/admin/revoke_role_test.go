@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_revokeRoleHandler_ServeHTTP(t *testing.T) {
+	validRequestBody := []byte(`{"Login":"login1"}`)
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockRevokeRoleUsecase := mocks.NewMockRevokeRoleUsecase(ctrl)
+	NewRevokeRoleHandler(mockRevokeRoleUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		reqBody json.RawMessage
+		code    int
+		prepare func()
+	}{
+		{
+			name:    "positive",
+			reqBody: validRequestBody,
+			code:    200,
+			prepare: func() {
+				mockRevokeRoleUsecase.EXPECT().RevokeRole(gomock.Any(), "login1").Return(nil)
+			},
+		},
+		{
+			name:    "no login",
+			reqBody: []byte(`{}`),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "user not found",
+			reqBody: validRequestBody,
+			code:    404,
+			prepare: func() {
+				mockRevokeRoleUsecase.EXPECT().RevokeRole(gomock.Any(), "login1").
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:    "last admin",
+			reqBody: validRequestBody,
+			code:    409,
+			prepare: func() {
+				mockRevokeRoleUsecase.EXPECT().RevokeRole(gomock.Any(), "login1").
+					Return(errors.NewDomainError(errors.ErrLastAdmin, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, "", server, "POST", "/api/v1/admin/revokeRole", tt.reqBody)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -47,21 +47,15 @@ func (h *deleteCategoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	ID, err := strconv.ParseInt(stringID, 10, 64)
 	if err != nil {
 		slog.Error("error parsing id from param to int64", "error", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Invalid(w, r, problem.FieldError{Field: "id", Message: "should be an integer"})
 		return
 	}
 
 	err = h.usecase.Delete(r.Context(), ID)
 	if err != nil {
 		slog.Error(err.Error())
-		switch errors.Code(err) {
-		case errors.ErrNoDataFound:
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+		problem.Error(w, r, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/go-chi/chi/v5"
 )
@@ -14,6 +15,7 @@ const getAllCategoriesURL = "/api/v1/category/getAll"
 
 type GetAllCategoriesUsecase interface {
 	GetAll(ctx context.Context) ([]entity.Category, error)
+	GetTree(ctx context.Context) ([]entity.CategoryNode, error)
 }
 
 type getAllCategoriesHandler struct {
@@ -43,22 +45,30 @@ func (h *getAllCategoriesHandler) Middlewares(md ...func(http.Handler) http.Hand
 
 func (h *getAllCategoriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
-	categories, err := h.usecase.GetAll(r.Context())
+	var (
+		categories interface{}
+		err        error
+	)
+	if r.URL.Query().Get("tree") == "true" {
+		categories, err = h.usecase.GetTree(r.Context())
+	} else {
+		categories, err = h.usecase.GetAll(r.Context())
+	}
 	if err != nil {
 		slog.Error(err.Error())
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Error(w, r, err)
 		return
 	}
 
 	body, err := json.Marshal(categories)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Error(w, r, err)
 		return
 	}
 
 	_, err = w.Write(body)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Error(w, r, err)
 		return
 	}
 }
@@ -60,3 +60,34 @@ func Test_getAllCategoriesHandler_ServeHTTP_UnexpectedError(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 }
+
+func Test_getAllCategoriesHandler_ServeHTTP_Tree(t *testing.T) {
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockGetAllCategoriesUsecase := mocks.NewMockGetAllCategoriesUsecase(ctrl)
+	handler := NewGetAllCategoriesHandler(mockGetAllCategoriesUsecase)
+	handler.AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	parentID := int64(1)
+	tree := []entity.CategoryNode{
+		{
+			Category: entity.Category{ID: 1, Name: "electronics"},
+			Children: []entity.CategoryNode{
+				{Category: entity.Category{ID: 2, ParentID: &parentID, Name: "laptop"}, Children: []entity.CategoryNode{}},
+			},
+		},
+	}
+	expectedBody, err := json.Marshal(tree)
+	require.NoError(t, err)
+
+	mockGetAllCategoriesUsecase.EXPECT().GetTree(gomock.Any()).Return(tree, nil)
+
+	resp, body := v1.TestRequest(t, "", server, "GET", "/api/v1/category/getAll?tree=true", nil)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, string(expectedBody), body)
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const getCategoryAncestorsURL = "/api/v1/category/getAncestors/{id}"
+
+type GetCategoryAncestorsUsecase interface {
+	GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error)
+}
+
+type getCategoryAncestorsHandler struct {
+	usecase     GetCategoryAncestorsUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewGetCategoryAncestorsHandler(usecase GetCategoryAncestorsUsecase) *getCategoryAncestorsHandler {
+	return &getCategoryAncestorsHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *getCategoryAncestorsHandler) AddToRouter(r *chi.Mux) {
+	r.Route(getCategoryAncestorsURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *getCategoryAncestorsHandler) Middlewares(md ...func(http.Handler) http.Handler) *getCategoryAncestorsHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *getCategoryAncestorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	stringID := chi.URLParam(r, "id")
+	ID, err := strconv.ParseInt(stringID, 10, 64)
+	if err != nil {
+		slog.Error("error parsing id from param to int64", "error", err)
+		problem.Invalid(w, r, problem.FieldError{Field: "id", Message: "should be an integer"})
+		return
+	}
+
+	ancestors, err := h.usecase.GetAncestors(r.Context(), ID)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(ancestors)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getCategoryAncestorsHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockGetAncestorsUsecase := mocks.NewMockGetCategoryAncestorsUsecase(ctrl)
+	handler := NewGetCategoryAncestorsHandler(mockGetAncestorsUsecase)
+	handler.AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	electronicsID, phonesID := int64(1), int64(2)
+	breadcrumbs := []entity.Category{
+		{ID: 1, Name: "electronics"},
+		{ID: 2, ParentID: &electronicsID, Name: "phones"},
+		{ID: 3, ParentID: &phonesID, Name: "smartphones"},
+	}
+	expectedBody, err := json.Marshal(breadcrumbs)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		body    string
+		prepare func()
+	}{
+		{
+			name: "positive",
+			path: "/api/v1/category/getAncestors/3",
+			code: http.StatusOK,
+			body: string(expectedBody),
+			prepare: func() {
+				mockGetAncestorsUsecase.EXPECT().GetAncestors(gomock.Any(), int64(3)).Return(breadcrumbs, nil)
+			},
+		},
+		{
+			name:    "invalid id",
+			path:    "/api/v1/category/getAncestors/abc",
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name: "category not found",
+			path: "/api/v1/category/getAncestors/5",
+			code: http.StatusNotFound,
+			prepare: func() {
+				mockGetAncestorsUsecase.EXPECT().GetAncestors(gomock.Any(), int64(5)).
+					Return(nil, errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "GET", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code == http.StatusOK {
+				require.Equal(t, tt.body, body)
+			}
+		})
+	}
+}
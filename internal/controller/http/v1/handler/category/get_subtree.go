@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const getCategorySubtreeURL = "/api/v1/category/getSubtree/{id}"
+
+type GetCategorySubtreeUsecase interface {
+	GetSubtree(ctx context.Context, ID int64) (entity.CategoryNode, error)
+}
+
+type getCategorySubtreeHandler struct {
+	usecase     GetCategorySubtreeUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewGetCategorySubtreeHandler(usecase GetCategorySubtreeUsecase) *getCategorySubtreeHandler {
+	return &getCategorySubtreeHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *getCategorySubtreeHandler) AddToRouter(r *chi.Mux) {
+	r.Route(getCategorySubtreeURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *getCategorySubtreeHandler) Middlewares(md ...func(http.Handler) http.Handler) *getCategorySubtreeHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *getCategorySubtreeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	stringID := chi.URLParam(r, "id")
+	ID, err := strconv.ParseInt(stringID, 10, 64)
+	if err != nil {
+		slog.Error("error parsing id from param to int64", "error", err)
+		problem.Invalid(w, r, problem.FieldError{Field: "id", Message: "should be an integer"})
+		return
+	}
+
+	subtree, err := h.usecase.GetSubtree(r.Context(), ID)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(subtree)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
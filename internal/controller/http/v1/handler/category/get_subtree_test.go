@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getCategorySubtreeHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockGetSubtreeUsecase := mocks.NewMockGetCategorySubtreeUsecase(ctrl)
+	handler := NewGetCategorySubtreeHandler(mockGetSubtreeUsecase)
+	handler.AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	electronicsID := int64(1)
+	subtree := entity.CategoryNode{
+		Category: entity.Category{ID: 1, Name: "electronics"},
+		Children: []entity.CategoryNode{
+			{Category: entity.Category{ID: 2, ParentID: &electronicsID, Name: "phones"}, Children: []entity.CategoryNode{}},
+		},
+	}
+	expectedBody, err := json.Marshal(subtree)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		body    string
+		prepare func()
+	}{
+		{
+			name: "positive",
+			path: "/api/v1/category/getSubtree/1",
+			code: http.StatusOK,
+			body: string(expectedBody),
+			prepare: func() {
+				mockGetSubtreeUsecase.EXPECT().GetSubtree(gomock.Any(), int64(1)).Return(subtree, nil)
+			},
+		},
+		{
+			name:    "invalid id",
+			path:    "/api/v1/category/getSubtree/abc",
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name: "category not found",
+			path: "/api/v1/category/getSubtree/5",
+			code: http.StatusNotFound,
+			prepare: func() {
+				mockGetSubtreeUsecase.EXPECT().GetSubtree(gomock.Any(), int64(5)).
+					Return(entity.CategoryNode{}, errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name: "unexpected error",
+			path: "/api/v1/category/getSubtree/1",
+			code: http.StatusInternalServerError,
+			prepare: func() {
+				mockGetSubtreeUsecase.EXPECT().GetSubtree(gomock.Any(), int64(1)).
+					Return(entity.CategoryNode{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "GET", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code == http.StatusOK {
+				require.Equal(t, tt.body, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const moveCategoryURL = "/api/v1/category/move"
+
+type MoveCategoryUsecase interface {
+	Move(ctx context.Context, category entity.MoveCategoryDTO) error
+}
+
+type moveCategoryHandler struct {
+	usecase     MoveCategoryUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewMoveCategoryHandler(usecase MoveCategoryUsecase) *moveCategoryHandler {
+	return &moveCategoryHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *moveCategoryHandler) AddToRouter(r *chi.Mux) {
+	r.Route(moveCategoryURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *moveCategoryHandler) Middlewares(md ...func(http.Handler) http.Handler) *moveCategoryHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *moveCategoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var dto entity.MoveCategoryDTO
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	err := h.usecase.Move(r.Context(), dto)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_moveCategoryHandler_ServeHTTP(t *testing.T) {
+	newParentID := int64(2)
+	dto := entity.MoveCategoryDTO{
+		CategoryID:  1,
+		NewParentID: &newParentID,
+	}
+	validRequestBody, err := json.Marshal(dto)
+	require.NoError(t, err)
+
+	invalidRequestBody, err := json.Marshal(entity.MoveCategoryDTO{
+		NewParentID: &newParentID,
+	})
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockMoveCategoryUsecase := mocks.NewMockMoveCategoryUsecase(ctrl)
+	handler := NewMoveCategoryHandler(mockMoveCategoryUsecase)
+	handler.AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		reqBody json.RawMessage
+		code    int
+		prepare func()
+	}{
+		{
+			name:    "positive",
+			reqBody: validRequestBody,
+			code:    http.StatusOK,
+			prepare: func() {
+				mockMoveCategoryUsecase.EXPECT().Move(gomock.Any(), gomock.Eq(dto)).Return(nil)
+			},
+		},
+		{
+			name:    "invalid body",
+			reqBody: invalidRequestBody,
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name:    "cycle",
+			reqBody: validRequestBody,
+			code:    http.StatusBadRequest,
+			prepare: func() {
+				mockMoveCategoryUsecase.EXPECT().Move(gomock.Any(), gomock.Eq(dto)).
+					Return(errors.NewDomainError(errors.ErrCategoryCycle, ""))
+			},
+		},
+		{
+			name:    "category not found",
+			reqBody: validRequestBody,
+			code:    http.StatusNotFound,
+			prepare: func() {
+				mockMoveCategoryUsecase.EXPECT().Move(gomock.Any(), gomock.Eq(dto)).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:    "unexpected error",
+			reqBody: validRequestBody,
+			code:    http.StatusInternalServerError,
+			prepare: func() {
+				mockMoveCategoryUsecase.EXPECT().Move(gomock.Any(), gomock.Eq(dto)).
+					Return(errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, "", server, "POST", "/api/v1/category/move", tt.reqBody)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
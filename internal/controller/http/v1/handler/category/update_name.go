@@ -2,12 +2,12 @@ package v1
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
-	"github.com/The-Gleb/product_catalog/internal/errors"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -45,32 +45,15 @@ func (h *updateCategoryNameHandler) Middlewares(md ...func(http.Handler) http.Ha
 func (h *updateCategoryNameHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	var dto entity.UpdateCategoryNameDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		slog.Error("error decoding json request body")
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if !v1.DecodeJSON(w, r, &dto) {
 		return
 	}
 
-	if dto.CategoryID == 0 || dto.NewName == "" {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	err = h.usecase.UpdateName(r.Context(), dto)
+	err := h.usecase.UpdateName(r.Context(), dto)
 	if err != nil {
 		slog.Error(err.Error())
-		switch errors.Code(err) {
-		case errors.ErrAlreadyExists:
-			http.Error(w, err.Error(), http.StatusConflict)
-			return
-		case errors.ErrNoDataFound:
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+		problem.Error(w, r, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
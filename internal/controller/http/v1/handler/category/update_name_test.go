@@ -67,7 +67,7 @@ func Test_updateCategoryNameHandler_ServeHTTP(t *testing.T) {
 		{
 			name:    "negative, category with that ID not found",
 			reqBody: validRequestBody,
-			code:    400,
+			code:    404,
 			prepare: func() {
 				mockUpdateNameUsecase.
 					EXPECT().
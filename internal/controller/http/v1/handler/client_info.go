@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+// maxUserAgentLen matches the size of session.user_agent.
+const maxUserAgentLen = 512
+
+// ClientInfo returns the address and user agent of the client that sent r.
+func ClientInfo(r *http.Request) entity.ClientInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLen {
+		userAgent = userAgent[:maxUserAgentLen]
+	}
+
+	return entity.ClientInfo{IP: ip, UserAgent: userAgent}
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/pkg/validate"
+)
+
+// MaxBodySize caps the request bodies read by DecodeJSON.
+const MaxBodySize = 1 << 20
+
+// DecodeJSON reads the JSON body of r into dto, a pointer to a struct, trims
+// the fields tagged mod:"trim" and checks dto against its validate tags.
+// Bodies over MaxBodySize and unknown fields are refused. If the body can't
+// be used DecodeJSON answers with the problem and returns false.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dto interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(dto)
+	if err == nil && dec.Decode(&json.RawMessage{}) != io.EOF {
+		err = fmt.Errorf("request body should hold a single JSON value")
+	}
+	if err != nil {
+		slog.Error("error decoding json request body", "error", err)
+		writeDecodeProblem(w, r, err)
+		return false
+	}
+
+	validate.Trim(dto)
+	err = validate.Struct(dto)
+	if err != nil {
+		var invalid validate.Errors
+		if !stdErrors.As(err, &invalid) {
+			problem.Error(w, r, err)
+			return false
+		}
+
+		fields := make([]problem.FieldError, len(invalid))
+		for i, f := range invalid {
+			fields[i] = problem.FieldError{Field: f.Field, Message: f.Message}
+		}
+		problem.Invalid(w, r, fields...)
+		return false
+	}
+
+	return true
+}
+
+func writeDecodeProblem(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		tooLarge  *http.MaxBytesError
+		syntax    *json.SyntaxError
+		wrongType *json.UnmarshalTypeError
+	)
+
+	switch {
+	case stdErrors.As(err, &tooLarge):
+		problem.Write(w, r, http.StatusRequestEntityTooLarge, problem.CodeRequestTooLarge,
+			fmt.Sprintf("request body should be at most %d bytes", tooLarge.Limit))
+	case stdErrors.Is(err, io.EOF):
+		problem.BadRequest(w, r, "request body is empty")
+	case stdErrors.As(err, &syntax), stdErrors.Is(err, io.ErrUnexpectedEOF):
+		problem.BadRequest(w, r, "request body is not valid JSON")
+	case stdErrors.As(err, &wrongType) && wrongType.Field != "":
+		problem.Invalid(w, r, problem.FieldError{Field: wrongType.Field, Message: "should be " + jsonType(wrongType.Type)})
+	case stdErrors.As(err, &wrongType):
+		problem.BadRequest(w, r, "request body should be "+jsonType(wrongType.Type))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no error type for unknown fields.
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		problem.Invalid(w, r, problem.FieldError{Field: field, Message: "is not a known field"})
+	default:
+		problem.BadRequest(w, r, err.Error())
+	}
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	case reflect.Pointer:
+		return jsonType(t.Elem())
+	}
+	return "an object"
+}
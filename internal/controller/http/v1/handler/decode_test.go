@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		code    int
+		problem problem.Code
+		fields  []problem.FieldError
+		want    entity.AddProductDTO
+	}{
+		{
+			name: "valid, names trimmed",
+			body: `{"ProductName": "  phone ", "CategoryID": 2, "price": 10, "brand": " acme "}`,
+			code: http.StatusOK,
+			want: entity.AddProductDTO{
+				ProductName:    "phone",
+				CategoryID:     2,
+				ProductDetails: entity.ProductDetails{Price: 10, Brand: "acme"},
+			},
+		},
+		{
+			name:    "empty body",
+			code:    http.StatusBadRequest,
+			problem: problem.CodeInvalidRequest,
+		},
+		{
+			name:    "not json",
+			body:    `{"ProductName": `,
+			code:    http.StatusBadRequest,
+			problem: problem.CodeInvalidRequest,
+		},
+		{
+			name:    "trailing data",
+			body:    `{"ProductName": "phone", "CategoryID": 2} {}`,
+			code:    http.StatusBadRequest,
+			problem: problem.CodeInvalidRequest,
+		},
+		{
+			name:    "unknown field",
+			body:    `{"ProductName": "phone", "CategoryID": 2, "color": "red"}`,
+			code:    http.StatusBadRequest,
+			problem: problem.CodeValidationFailed,
+			fields:  []problem.FieldError{{Field: "color", Message: "is not a known field"}},
+		},
+		{
+			name:    "wrong type",
+			body:    `{"ProductName": "phone", "CategoryID": "two"}`,
+			code:    http.StatusBadRequest,
+			problem: problem.CodeValidationFailed,
+			fields:  []problem.FieldError{{Field: "CategoryID", Message: "should be an integer"}},
+		},
+		{
+			name:    "invalid fields",
+			body:    `{"ProductName": " ", "price": -1, "attributes": {"size": [1]}}`,
+			code:    http.StatusBadRequest,
+			problem: problem.CodeValidationFailed,
+			fields: []problem.FieldError{
+				{Field: "ProductName", Message: "should not be empty"},
+				{Field: "CategoryID", Message: "should be set"},
+				{Field: "price", Message: "should not be negative"},
+				{Field: "attributes[size]", Message: "should be a string, number or boolean"},
+			},
+		},
+		{
+			name:    "name too long",
+			body:    `{"ProductName": "` + strings.Repeat("я", 256) + `", "CategoryID": 2}`,
+			code:    http.StatusBadRequest,
+			problem: problem.CodeValidationFailed,
+			fields:  []problem.FieldError{{Field: "ProductName", Message: "should be at most 255 characters"}},
+		},
+		{
+			name:    "too large",
+			body:    `{"description": "` + strings.Repeat("a", MaxBodySize) + `"}`,
+			code:    http.StatusRequestEntityTooLarge,
+			problem: problem.CodeRequestTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			var dto entity.AddProductDTO
+			ok := DecodeJSON(w, r, &dto)
+
+			require.Equal(t, tt.code == http.StatusOK, ok)
+			require.Equal(t, tt.code, w.Code)
+			if ok {
+				require.Equal(t, tt.want, dto)
+				return
+			}
+
+			var p problem.Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+			require.Equal(t, tt.problem, p.Code)
+			require.Equal(t, tt.fields, p.Errors)
+		})
+	}
+}
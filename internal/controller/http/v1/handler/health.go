@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	healthURL = "/api/v1/health"
+)
+
+type HealthUsecase interface {
+	Health(ctx context.Context) entity.Health
+}
+
+type healthHandler struct {
+	middlewares []func(http.Handler) http.Handler
+	usecase     HealthUsecase
+}
+
+func NewHealthHandler(usecase HealthUsecase) *healthHandler {
+	return &healthHandler{usecase: usecase, middlewares: make([]func(http.Handler) http.Handler, 0)}
+}
+
+func (h *healthHandler) AddToRouter(r *chi.Mux) {
+
+	r.Route(healthURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+}
+
+func (h *healthHandler) Middlewares(md ...func(http.Handler) http.Handler) *healthHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP responds with 200 for a degraded service too: the API is still
+// able to serve requests, only the upstream sync is failing.
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	health := h.usecase.Health(r.Context())
+
+	b, err := json.Marshal(health)
+	if err != nil {
+		slog.Error("error marshalling health", "error", err)
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.Write(b)
+
+}
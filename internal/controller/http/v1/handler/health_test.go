@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_healthHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockHealthUsecase := mocks.NewMockHealthUsecase(ctrl)
+	NewHealthHandler(mockHealthUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name   string
+		health entity.Health
+	}{
+		{
+			name: "ok",
+			health: entity.Health{
+				Status: entity.HealthOK,
+				Sync:   entity.SyncHealth{Status: entity.HealthOK, Breakers: map[string]string{"dummyjson": "closed"}},
+			},
+		},
+		{
+			name: "degraded",
+			health: entity.Health{
+				Status: entity.HealthDegraded,
+				Sync: entity.SyncHealth{
+					Status:              entity.HealthDegraded,
+					Breakers:            map[string]string{"dummyjson": "open"},
+					ConsecutiveFailures: 3,
+					LastError:           "dummyjson responded with status 502",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHealthUsecase.EXPECT().Health(gomock.Any()).Return(tt.health)
+
+			resp, body := TestRequest(t, "", server, "GET", "/api/v1/health", nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, 200, resp.StatusCode)
+
+			var health entity.Health
+			require.NoError(t, json.Unmarshal([]byte(body), &health))
+			require.Equal(t, tt.health, health)
+		})
+	}
+}
@@ -3,9 +3,14 @@ package v1
 import (
 	"context"
 	"encoding/json"
+	stdErrors "errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/The-Gleb/product_catalog/internal/errors"
 	"github.com/go-chi/chi/v5"
@@ -16,7 +21,7 @@ const (
 )
 
 type LoginUsecase interface {
-	Login(ctx context.Context, credentials entity.Credentials) (entity.Session, error)
+	Login(ctx context.Context, credentials entity.Credentials, client entity.ClientInfo) (entity.LoginResult, error)
 }
 
 type loginHandler struct {
@@ -41,45 +46,59 @@ func (h *loginHandler) Middlewares(md ...func(http.Handler) http.Handler) *login
 	return h
 }
 
+// Login opens a session, or responds with 202 Accepted and a challenge to
+// answer at /api/v1/login/mfa if the user enrolled in two-factor
+// authentication.
 func (h *loginHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	var dto entity.Credentials
 	defer r.Body.Close()
 
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		slog.Error("error parsing json request body to dto", "error", err)
-		http.Error(w, "error parsing json request body to dto", http.StatusBadRequest)
-		return
-	}
-
-	if dto.Login == "" || dto.Password == "" {
-		http.Error(w, "login and password should not be empty", http.StatusBadRequest)
+	if !DecodeJSON(w, r, &dto) {
 		return
 	}
 
-	s, err := h.usecase.Login(r.Context(), dto)
+	result, err := h.usecase.Login(r.Context(), dto, ClientInfo(r))
 	if err != nil {
 		slog.Error(err.Error())
 
 		switch errors.Code(err) {
-		case errors.ErrUnauthorized:
-			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case errors.ErrTooManyAttempts:
+			writeTooManyAttempts(w, r, err)
 			return
 		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			problem.Error(w, r, err)
 			return
 		}
 	}
-	c := http.Cookie{
-		Name:    "sessionToken",
-		Value:   s.Token,
-		Expires: s.Expiry,
-		Path:    "/",
+
+	if result.Challenge != nil {
+		body, err := json.Marshal(result.Challenge)
+		if err != nil {
+			problem.Error(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, err = w.Write(body)
+		if err != nil {
+			slog.Error("error writing response", "error", err)
+		}
+		return
 	}
 
-	http.SetCookie(w, &c)
+	WriteTokens(w, r, result.Tokens)
 
-	w.WriteHeader(http.StatusOK)
+}
 
+// writeTooManyAttempts refuses a throttled login, telling the client when
+// to try again.
+func writeTooManyAttempts(w http.ResponseWriter, r *http.Request, err error) {
+	var lockout *entity.LockoutError
+	if stdErrors.As(errors.Unwrap(err), &lockout) {
+		retryAfter := int(math.Ceil(time.Until(lockout.Until).Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+	}
+	problem.Error(w, r, err)
 }
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	loginMFAURL = "/api/v1/login/mfa"
+)
+
+type LoginMFAUsecase interface {
+	LoginMFA(ctx context.Context, dto entity.MFALoginDTO, client entity.ClientInfo) (entity.TokenPair, error)
+}
+
+type loginMFAHandler struct {
+	middlewares []func(http.Handler) http.Handler
+	usecase     LoginMFAUsecase
+}
+
+func NewLoginMFAHandler(usecase LoginMFAUsecase) *loginMFAHandler {
+	return &loginMFAHandler{usecase: usecase, middlewares: make([]func(http.Handler) http.Handler, 0)}
+}
+
+func (h *loginMFAHandler) AddToRouter(r *chi.Mux) {
+
+	r.Route(loginMFAURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.LoginMFA)
+	})
+}
+
+func (h *loginMFAHandler) Middlewares(md ...func(http.Handler) http.Handler) *loginMFAHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// LoginMFA completes a login with the challenge it returned and a code of
+// the user's authenticator or a recovery code.
+func (h *loginMFAHandler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+
+	var dto entity.MFALoginDTO
+	defer r.Body.Close()
+
+	if !DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	tokens, err := h.usecase.LoginMFA(r.Context(), dto, ClientInfo(r))
+	if err != nil {
+		slog.Error(err.Error())
+
+		switch errors.Code(err) {
+		case errors.ErrTooManyAttempts:
+			writeTooManyAttempts(w, r, err)
+			return
+		default:
+			problem.Error(w, r, err)
+			return
+		}
+	}
+
+	WriteTokens(w, r, tokens)
+
+}
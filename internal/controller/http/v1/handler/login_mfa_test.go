@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loginMFAHandler_LoginMFA(t *testing.T) {
+	validReqBody, err := json.Marshal(entity.MFALoginDTO{
+		Challenge: "challenge",
+		Code:      "123456",
+	})
+	require.NoError(t, err)
+
+	invalidReqBody, err := json.Marshal(entity.MFALoginDTO{
+		Challenge: "challenge",
+	})
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockLoginMFAUsecase := mocks.NewMockLoginMFAUsecase(ctrl)
+	NewLoginMFAHandler(mockLoginMFAUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	dto := entity.MFALoginDTO{Challenge: "challenge", Code: "123456"}
+
+	tests := []struct {
+		name    string
+		reqBody json.RawMessage
+		code    int
+		prepare func()
+	}{
+		{
+			name:    "positive",
+			reqBody: validReqBody,
+			code:    200,
+			prepare: func() {
+				mockLoginMFAUsecase.
+					EXPECT().
+					LoginMFA(gomock.Any(), gomock.Eq(dto), gomock.Any()).
+					Return(entity.TokenPair{
+						AccessToken:   "access",
+						AccessExpiry:  time.Now().Add(time.Minute),
+						RefreshToken:  "refresh",
+						RefreshExpiry: time.Now().Add(time.Hour),
+					}, nil)
+			},
+		},
+		{
+			name:    "negative, body with no code",
+			reqBody: invalidReqBody,
+			code:    400,
+			prepare: func() {
+			},
+		},
+		{
+			name:    "negative, wrong code",
+			reqBody: validReqBody,
+			code:    401,
+			prepare: func() {
+				mockLoginMFAUsecase.
+					EXPECT().
+					LoginMFA(gomock.Any(), gomock.Eq(dto), gomock.Any()).
+					Return(entity.TokenPair{}, errors.NewDomainError(errors.ErrUnauthorized, ""))
+			},
+		},
+		{
+			name:    "negative, too many attempts",
+			reqBody: validReqBody,
+			code:    429,
+			prepare: func() {
+				mockLoginMFAUsecase.
+					EXPECT().
+					LoginMFA(gomock.Any(), gomock.Eq(dto), gomock.Any()).
+					Return(entity.TokenPair{}, errors.WrapIntoDomainError(
+						&entity.LockoutError{Until: time.Now().Add(time.Minute)},
+						errors.ErrTooManyAttempts, "",
+					))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			tt.prepare()
+
+			resp, body := TestRequest(t, "", server, "POST", "/api/v1/login/mfa", tt.reqBody)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+
+			if tt.code != 200 {
+				return
+			}
+
+			var tokens entity.TokenPair
+			require.NoError(t, json.Unmarshal([]byte(body), &tokens))
+			require.Equal(t, "access", tokens.AccessToken)
+		})
+	}
+}
@@ -36,10 +36,11 @@ func Test_loginHandler_Login(t *testing.T) {
 	server := httptest.NewServer(r)
 
 	tests := []struct {
-		name    string
-		reqBody json.RawMessage
-		code    int
-		prepare func()
+		name       string
+		reqBody    json.RawMessage
+		code       int
+		retryAfter string
+		prepare    func()
 	}{
 		{
 			name:    "positive",
@@ -51,12 +52,33 @@ func Test_loginHandler_Login(t *testing.T) {
 					Login(gomock.Any(), gomock.Eq(entity.Credentials{
 						Login:    "login1",
 						Password: "password1",
+					}), gomock.Eq(entity.ClientInfo{
+						IP:        "127.0.0.1",
+						UserAgent: "Go-http-client/1.1",
 					})).
-					Return(entity.Session{
-						UserID: 1,
-						Token:  "123",
-						Expiry: time.Now().Add(time.Hour),
-					}, nil)
+					Return(entity.LoginResult{Tokens: entity.TokenPair{
+						AccessToken:   "access",
+						AccessExpiry:  time.Now().Add(time.Minute),
+						RefreshToken:  "refresh",
+						RefreshExpiry: time.Now().Add(time.Hour),
+					}}, nil)
+			},
+		},
+		{
+			name:    "positive, two-factor authentication",
+			reqBody: validLoginReqBody,
+			code:    202,
+			prepare: func() {
+				mockLoginUsecase.
+					EXPECT().
+					Login(gomock.Any(), gomock.Eq(entity.Credentials{
+						Login:    "login1",
+						Password: "password1",
+					}), gomock.Any()).
+					Return(entity.LoginResult{Challenge: &entity.MFAChallenge{
+						Challenge: "challenge",
+						Expiry:    time.Now().Add(5 * time.Minute),
+					}}, nil)
 			},
 		},
 		{
@@ -83,11 +105,29 @@ func Test_loginHandler_Login(t *testing.T) {
 					Login(gomock.Any(), gomock.Eq(entity.Credentials{
 						Login:    "login1",
 						Password: "password1",
-					})).
-					Return(entity.Session{}, errors.NewDomainError(errors.ErrUnauthorized, ""))
+					}), gomock.Any()).
+					Return(entity.LoginResult{}, errors.NewDomainError(errors.ErrUnauthorized, ""))
 
 			},
 		},
+		{
+			name:       "negative, too many attempts",
+			reqBody:    validLoginReqBody,
+			code:       429,
+			retryAfter: "30",
+			prepare: func() {
+				mockLoginUsecase.
+					EXPECT().
+					Login(gomock.Any(), gomock.Eq(entity.Credentials{
+						Login:    "login1",
+						Password: "password1",
+					}), gomock.Any()).
+					Return(entity.LoginResult{}, errors.WrapIntoDomainError(
+						&entity.LockoutError{Until: time.Now().Add(30 * time.Second)},
+						errors.ErrTooManyAttempts, "",
+					))
+			},
+		},
 		{
 			name:    "negative, some db err",
 			reqBody: validLoginReqBody,
@@ -98,8 +138,8 @@ func Test_loginHandler_Login(t *testing.T) {
 					Login(gomock.Any(), gomock.Eq(entity.Credentials{
 						Login:    "login1",
 						Password: "password1",
-					})).
-					Return(entity.Session{}, errors.NewDomainError(errors.ErrDB, ""))
+					}), gomock.Any()).
+					Return(entity.LoginResult{}, errors.NewDomainError(errors.ErrDB, ""))
 			},
 		},
 	}
@@ -108,21 +148,37 @@ func Test_loginHandler_Login(t *testing.T) {
 
 			tt.prepare()
 
-			resp, _ := TestRequest(t, "", server, "POST", "/api/v1/login", tt.reqBody)
+			resp, body := TestRequest(t, "", server, "POST", "/api/v1/login", tt.reqBody)
 			defer resp.Body.Close()
 
 			require.Equal(t, tt.code, resp.StatusCode)
+			require.Equal(t, tt.retryAfter, resp.Header.Get("Retry-After"))
+
+			if tt.code == 202 {
+				require.Empty(t, resp.Cookies())
 
+				var challenge entity.MFAChallenge
+				require.NoError(t, json.Unmarshal([]byte(body), &challenge))
+				require.Equal(t, "challenge", challenge.Challenge)
+				return
+			}
 			if tt.code != 200 {
 				return
 			}
 
 			cookies := resp.Cookies()
-			require.NotEqual(t, "0", len(cookies))
+			require.Len(t, cookies, 2)
 			require.Equal(t, "sessionToken", cookies[0].Name)
-			require.NotEmpty(t, cookies[0].Value)
+			require.Equal(t, "access", cookies[0].Value)
+			require.Equal(t, "refreshToken", cookies[1].Name)
+			require.Equal(t, "refresh", cookies[1].Value)
 			slog.Debug("received cookie", "cookie", cookies[0])
 
+			var tokens entity.TokenPair
+			require.NoError(t, json.Unmarshal([]byte(body), &tokens))
+			require.Equal(t, "access", tokens.AccessToken)
+			require.Equal(t, "refresh", tokens.RefreshToken)
+
 		})
 	}
 }
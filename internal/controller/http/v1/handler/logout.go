@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	logoutURL = "/api/v1/logout"
+)
+
+type LogoutUsecase interface {
+	Logout(ctx context.Context, userID, sessionID int64) error
+}
+
+type logoutHandler struct {
+	middlewares []func(http.Handler) http.Handler
+	usecase     LogoutUsecase
+}
+
+func NewLogoutHandler(usecase LogoutUsecase) *logoutHandler {
+	return &logoutHandler{usecase: usecase, middlewares: make([]func(http.Handler) http.Handler, 0)}
+}
+
+func (h *logoutHandler) AddToRouter(r *chi.Mux) {
+
+	r.Route(logoutURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.Logout)
+	})
+}
+
+func (h *logoutHandler) Middlewares(md ...func(http.Handler) http.Handler) *logoutHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *logoutHandler) Logout(w http.ResponseWriter, r *http.Request) {
+
+	userID, ok := r.Context().Value(middleware.Key("userID")).(int64)
+	if !ok {
+		problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
+		return
+	}
+	sessionID, _ := r.Context().Value(middleware.Key("sessionID")).(int64)
+
+	err := h.usecase.Logout(r.Context(), userID, sessionID)
+	if err != nil && errors.Code(err) != errors.ErrNoDataFound {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	ClearSessionCookie(w)
+
+	w.WriteHeader(http.StatusOK)
+
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_logoutHandler_Logout(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockLogoutUsecase := mocks.NewMockLogoutUsecase(ctrl)
+	NewLogoutHandler(mockLogoutUsecase).
+		Middlewares(middleware.NewAuthMiddleware(mockAuthUsecase).Do).
+		AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		token   string
+		code    int
+		prepare func()
+	}{
+		{
+			name:  "positive",
+			token: "123",
+			code:  200,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 1, UserID: 1, Token: "123"}, nil)
+				mockLogoutUsecase.EXPECT().Logout(gomock.Any(), int64(1), int64(1)).Return(nil)
+			},
+		},
+		{
+			name:  "session already gone",
+			token: "123",
+			code:  200,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 1, UserID: 1, Token: "123"}, nil)
+				mockLogoutUsecase.EXPECT().Logout(gomock.Any(), int64(1), int64(1)).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:    "not logged in",
+			code:    401,
+			prepare: func() {},
+		},
+		{
+			name:  "db error",
+			token: "123",
+			code:  500,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 1, UserID: 1, Token: "123"}, nil)
+				mockLogoutUsecase.EXPECT().Logout(gomock.Any(), int64(1), int64(1)).
+					Return(errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := TestRequest(t, tt.token, server, "POST", "/api/v1/logout", nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code == 200 {
+				cookies := resp.Cookies()
+				require.Len(t, cookies, 2)
+				for _, c := range cookies {
+					require.Empty(t, c.Value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	confirmMFAURL = "/api/v1/mfa/confirm"
+)
+
+type ConfirmMFAUsecase interface {
+	ConfirmMFA(ctx context.Context, userID int64, code string) error
+}
+
+type confirmMFAHandler struct {
+	usecase     ConfirmMFAUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewConfirmMFAHandler(usecase ConfirmMFAUsecase) *confirmMFAHandler {
+	return &confirmMFAHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *confirmMFAHandler) AddToRouter(r *chi.Mux) {
+	r.Route(confirmMFAURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *confirmMFAHandler) Middlewares(md ...func(http.Handler) http.Handler) *confirmMFAHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP enables two-factor authentication of the current user with a
+// first code of the enrolled authenticator.
+func (h *confirmMFAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	userID, ok := r.Context().Value(middleware.Key("userID")).(int64)
+	if !ok {
+		problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
+		return
+	}
+
+	var dto entity.MFACodeDTO
+	defer r.Body.Close()
+
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	err := h.usecase.ConfirmMFA(r.Context(), userID, dto.Code)
+	if err != nil {
+		slog.Error(err.Error())
+
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_confirmMFAHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockConfirmMFAUsecase := mocks.NewMockConfirmMFAUsecase(ctrl)
+	NewConfirmMFAHandler(mockConfirmMFAUsecase).
+		Middlewares(middleware.NewAuthMiddleware(mockAuthUsecase).Do).
+		AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		token   string
+		body    []byte
+		code    int
+		prepare func()
+	}{
+		{
+			name:  "positive",
+			token: "123",
+			body:  []byte(`{"Code":"123456"}`),
+			code:  200,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockConfirmMFAUsecase.EXPECT().ConfirmMFA(gomock.Any(), int64(1), "123456").Return(nil)
+			},
+		},
+		{
+			name:    "not logged in",
+			body:    []byte(`{"Code":"123456"}`),
+			code:    401,
+			prepare: func() {},
+		},
+		{
+			name:  "no code",
+			token: "123",
+			body:  []byte(`{}`),
+			code:  400,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+			},
+		},
+		{
+			name:  "wrong code",
+			token: "123",
+			body:  []byte(`{"Code":"123456"}`),
+			code:  401,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockConfirmMFAUsecase.EXPECT().ConfirmMFA(gomock.Any(), int64(1), "123456").
+					Return(errors.NewDomainError(errors.ErrUnauthorized, ""))
+			},
+		},
+		{
+			name:  "not enrolled",
+			token: "123",
+			body:  []byte(`{"Code":"123456"}`),
+			code:  404,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockConfirmMFAUsecase.EXPECT().ConfirmMFA(gomock.Any(), int64(1), "123456").
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, tt.token, server, "POST", "/api/v1/mfa/confirm", tt.body)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
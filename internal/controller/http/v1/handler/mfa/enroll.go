@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	enrollMFAURL = "/api/v1/mfa/enroll"
+)
+
+type EnrollMFAUsecase interface {
+	EnrollMFA(ctx context.Context, userID int64) (entity.MFAEnrollment, error)
+}
+
+type enrollMFAHandler struct {
+	usecase     EnrollMFAUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewEnrollMFAHandler(usecase EnrollMFAUsecase) *enrollMFAHandler {
+	return &enrollMFAHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *enrollMFAHandler) AddToRouter(r *chi.Mux) {
+	r.Route(enrollMFAURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *enrollMFAHandler) Middlewares(md ...func(http.Handler) http.Handler) *enrollMFAHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP starts the enrollment of the current user and responds with the
+// secret, its provisioning URI and the recovery codes. They are not shown
+// again; the enrollment takes effect once confirmed.
+func (h *enrollMFAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	userID, ok := r.Context().Value(middleware.Key("userID")).(int64)
+	if !ok {
+		problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
+		return
+	}
+
+	enrollment, err := h.usecase.EnrollMFA(r.Context(), userID)
+	if err != nil {
+		slog.Error(err.Error())
+
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(enrollment)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
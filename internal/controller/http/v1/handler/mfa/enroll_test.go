@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_enrollMFAHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockEnrollMFAUsecase := mocks.NewMockEnrollMFAUsecase(ctrl)
+	NewEnrollMFAHandler(mockEnrollMFAUsecase).
+		Middlewares(middleware.NewAuthMiddleware(mockAuthUsecase).Do).
+		AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	enrollment := entity.MFAEnrollment{
+		Secret:        "SECRET",
+		URI:           "otpauth://totp/Product%20Catalog:login1?secret=SECRET",
+		RecoveryCodes: []string{"aaaaa-bbbbb"},
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		code    int
+		prepare func()
+	}{
+		{
+			name:  "positive",
+			token: "123",
+			code:  200,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockEnrollMFAUsecase.EXPECT().EnrollMFA(gomock.Any(), int64(1)).Return(enrollment, nil)
+			},
+		},
+		{
+			name:    "not logged in",
+			code:    401,
+			prepare: func() {},
+		},
+		{
+			name:  "viewer",
+			token: "123",
+			code:  403,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockEnrollMFAUsecase.EXPECT().EnrollMFA(gomock.Any(), int64(1)).
+					Return(entity.MFAEnrollment{}, errors.NewDomainError(errors.ErrForbidden, ""))
+			},
+		},
+		{
+			name:  "already enabled",
+			token: "123",
+			code:  409,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockEnrollMFAUsecase.EXPECT().EnrollMFA(gomock.Any(), int64(1)).
+					Return(entity.MFAEnrollment{}, errors.NewDomainError(errors.ErrAlreadyExists, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, tt.token, server, "POST", "/api/v1/mfa/enroll", nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code != 200 {
+				return
+			}
+
+			var got entity.MFAEnrollment
+			require.NoError(t, json.Unmarshal([]byte(body), &got))
+			require.Equal(t, enrollment, got)
+		})
+	}
+}
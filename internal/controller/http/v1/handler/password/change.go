@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	changePasswordURL = "/api/v1/password/change"
+)
+
+type ChangePasswordUsecase interface {
+	ChangePassword(ctx context.Context, userID, sessionID int64, dto entity.ChangePasswordDTO, client entity.ClientInfo) error
+}
+
+type changePasswordHandler struct {
+	usecase     ChangePasswordUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewChangePasswordHandler(usecase ChangePasswordUsecase) *changePasswordHandler {
+	return &changePasswordHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *changePasswordHandler) AddToRouter(r *chi.Mux) {
+	r.Route(changePasswordURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *changePasswordHandler) Middlewares(md ...func(http.Handler) http.Handler) *changePasswordHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP changes the password of the current user and logs out their
+// other sessions.
+func (h *changePasswordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	userID, ok := r.Context().Value(middleware.Key("userID")).(int64)
+	if !ok {
+		problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
+		return
+	}
+	sessionID, _ := r.Context().Value(middleware.Key("sessionID")).(int64)
+
+	var dto entity.ChangePasswordDTO
+	defer r.Body.Close()
+
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	err := h.usecase.ChangePassword(r.Context(), userID, sessionID, dto, v1.ClientInfo(r))
+	if err != nil {
+		slog.Error(err.Error())
+
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_changePasswordHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockChangePasswordUsecase := mocks.NewMockChangePasswordUsecase(ctrl)
+	NewChangePasswordHandler(mockChangePasswordUsecase).
+		Middlewares(middleware.NewAuthMiddleware(mockAuthUsecase).Do).
+		AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	body := []byte(`{"OldPassword":"password1","NewPassword":"correct horse"}`)
+	dto := entity.ChangePasswordDTO{OldPassword: "password1", NewPassword: "correct horse"}
+
+	tests := []struct {
+		name    string
+		token   string
+		body    []byte
+		code    int
+		prepare func()
+	}{
+		{
+			name:  "positive",
+			token: "123",
+			body:  body,
+			code:  200,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockChangePasswordUsecase.EXPECT().ChangePassword(gomock.Any(), int64(1), int64(2), dto, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "not logged in",
+			body:    body,
+			code:    401,
+			prepare: func() {},
+		},
+		{
+			name:  "no new password",
+			token: "123",
+			body:  []byte(`{"OldPassword":"password1"}`),
+			code:  400,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+			},
+		},
+		{
+			name:  "wrong password",
+			token: "123",
+			body:  body,
+			code:  401,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockChangePasswordUsecase.EXPECT().ChangePassword(gomock.Any(), int64(1), int64(2), dto, gomock.Any()).
+					Return(errors.NewDomainError(errors.ErrUnauthorized, ""))
+			},
+		},
+		{
+			name:  "weak password",
+			token: "123",
+			body:  body,
+			code:  400,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockChangePasswordUsecase.EXPECT().ChangePassword(gomock.Any(), int64(1), int64(2), dto, gomock.Any()).
+					Return(errors.NewDomainError(errors.ErrWeakPassword, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, tt.token, server, "POST", "/api/v1/password/change", tt.body)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
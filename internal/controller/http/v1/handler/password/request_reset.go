@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	requestPasswordResetURL = "/api/v1/password/requestReset"
+)
+
+type RequestPasswordResetUsecase interface {
+	RequestPasswordReset(ctx context.Context, dto entity.RequestPasswordResetDTO) error
+}
+
+type requestPasswordResetHandler struct {
+	usecase     RequestPasswordResetUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewRequestPasswordResetHandler(usecase RequestPasswordResetUsecase) *requestPasswordResetHandler {
+	return &requestPasswordResetHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *requestPasswordResetHandler) AddToRouter(r *chi.Mux) {
+	r.Route(requestPasswordResetURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *requestPasswordResetHandler) Middlewares(md ...func(http.Handler) http.Handler) *requestPasswordResetHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP sends a reset link to the owner of the login. It responds with
+// 202 Accepted whether the login exists or not.
+func (h *requestPasswordResetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var dto entity.RequestPasswordResetDTO
+	defer r.Body.Close()
+
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	err := h.usecase.RequestPasswordReset(r.Context(), dto)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
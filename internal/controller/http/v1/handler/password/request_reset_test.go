@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_requestPasswordResetHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockRequestPasswordResetUsecase := mocks.NewMockRequestPasswordResetUsecase(ctrl)
+	NewRequestPasswordResetHandler(mockRequestPasswordResetUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		body    []byte
+		code    int
+		prepare func()
+	}{
+		{
+			name: "positive",
+			body: []byte(`{"Login":"login1"}`),
+			code: 202,
+			prepare: func() {
+				mockRequestPasswordResetUsecase.EXPECT().
+					RequestPasswordReset(gomock.Any(), entity.RequestPasswordResetDTO{Login: "login1"}).
+					Return(nil)
+			},
+		},
+		{
+			name:    "no login",
+			body:    []byte(`{}`),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name: "notifier error",
+			body: []byte(`{"Login":"login1"}`),
+			code: 500,
+			prepare: func() {
+				mockRequestPasswordResetUsecase.EXPECT().
+					RequestPasswordReset(gomock.Any(), entity.RequestPasswordResetDTO{Login: "login1"}).
+					Return(errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, "", server, "POST", "/api/v1/password/requestReset", tt.body)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
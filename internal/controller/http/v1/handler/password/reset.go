@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	resetPasswordURL = "/api/v1/password/reset"
+)
+
+type ResetPasswordUsecase interface {
+	ResetPassword(ctx context.Context, dto entity.ResetPasswordDTO) error
+}
+
+type resetPasswordHandler struct {
+	usecase     ResetPasswordUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewResetPasswordHandler(usecase ResetPasswordUsecase) *resetPasswordHandler {
+	return &resetPasswordHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *resetPasswordHandler) AddToRouter(r *chi.Mux) {
+	r.Route(resetPasswordURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *resetPasswordHandler) Middlewares(md ...func(http.Handler) http.Handler) *resetPasswordHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP sets a new password with a reset token.
+func (h *resetPasswordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var dto entity.ResetPasswordDTO
+	defer r.Body.Close()
+
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	err := h.usecase.ResetPassword(r.Context(), dto)
+	if err != nil {
+		slog.Error(err.Error())
+
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
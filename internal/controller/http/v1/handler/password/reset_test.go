@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_resetPasswordHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockResetPasswordUsecase := mocks.NewMockResetPasswordUsecase(ctrl)
+	NewResetPasswordHandler(mockResetPasswordUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	body := []byte(`{"Token":"abc","NewPassword":"correct horse"}`)
+	dto := entity.ResetPasswordDTO{Token: "abc", NewPassword: "correct horse"}
+
+	tests := []struct {
+		name    string
+		body    []byte
+		code    int
+		prepare func()
+	}{
+		{
+			name: "positive",
+			body: body,
+			code: 200,
+			prepare: func() {
+				mockResetPasswordUsecase.EXPECT().ResetPassword(gomock.Any(), dto).Return(nil)
+			},
+		},
+		{
+			name:    "no token",
+			body:    []byte(`{"NewPassword":"correct horse"}`),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name: "invalid token",
+			body: body,
+			code: 401,
+			prepare: func() {
+				mockResetPasswordUsecase.EXPECT().ResetPassword(gomock.Any(), dto).
+					Return(errors.NewDomainError(errors.ErrUnauthorized, ""))
+			},
+		},
+		{
+			name: "weak password",
+			body: body,
+			code: 400,
+			prepare: func() {
+				mockResetPasswordUsecase.EXPECT().ResetPassword(gomock.Any(), dto).
+					Return(errors.NewDomainError(errors.ErrWeakPassword, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, "", server, "POST", "/api/v1/password/reset", tt.body)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
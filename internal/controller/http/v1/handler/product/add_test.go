@@ -18,6 +18,15 @@ func Test_addProductHandler_ServeHTTP(t *testing.T) {
 	dto := entity.AddProductDTO{
 		ProductName: "redmi",
 		CategoryID:  1,
+		ProductDetails: entity.ProductDetails{
+			Description: "budget phone",
+			Price:       199.99,
+			Stock:       12,
+			Brand:       "Xiaomi",
+			SKU:         "XM-REDMI-13",
+			Images:      []string{"https://cdn.example.com/redmi.png"},
+			Attributes:  entity.ProductAttributes{"color": "black", "weight": 0.19, "nfc": true},
+		},
 	}
 	validRequestBody, err := json.Marshal(dto)
 	require.NoError(t, err)
@@ -27,6 +36,18 @@ func Test_addProductHandler_ServeHTTP(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	invalidDetailsRequestBody, err := json.Marshal(entity.AddProductDTO{
+		ProductName: "redmi",
+		CategoryID:  1,
+		ProductDetails: entity.ProductDetails{
+			Price:      -1,
+			Attributes: entity.ProductAttributes{"color": "black"},
+		},
+	})
+	require.NoError(t, err)
+
+	invalidAttributesRequestBody := []byte(`{"ProductName":"redmi","CategoryID":1,"attributes":{"size":[1,2]}}`)
+
 	r := chi.NewRouter()
 
 	ctrl := gomock.NewController(t)
@@ -64,6 +85,18 @@ func Test_addProductHandler_ServeHTTP(t *testing.T) {
 			code:    400,
 			prepare: func() {},
 		},
+		{
+			name:    "negative price",
+			reqBody: invalidDetailsRequestBody,
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "non scalar attribute",
+			reqBody: invalidAttributesRequestBody,
+			code:    400,
+			prepare: func() {},
+		},
 		{
 			name:    "positive",
 			reqBody: validRequestBody,
@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const productFacetsURL = "/api/v1/product/facets"
+
+// attributeParamPrefix marks attribute filters, e.g. attr.color=black.
+const attributeParamPrefix = "attr."
+
+type ProductFacetsUsecase interface {
+	Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error)
+}
+
+type productFacetsHandler struct {
+	usecase     ProductFacetsUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewProductFacetsHandler(usecase ProductFacetsUsecase) *productFacetsHandler {
+	return &productFacetsHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *productFacetsHandler) AddToRouter(r *chi.Mux) {
+	r.Route(productFacetsURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *productFacetsHandler) Middlewares(md ...func(http.Handler) http.Handler) *productFacetsHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *productFacetsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	query, err := parseProductQuery(r, entity.SortByID, entity.SortByName, entity.SortByNameDesc)
+	if err != nil {
+		slog.Error("error parsing query params", "error", err)
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+
+	filter, err := parseFacetFilter(r)
+	if err != nil {
+		slog.Error("error parsing facet filters", "error", err)
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+
+	page, err := h.usecase.Facets(r.Context(), entity.FacetQuery{
+		FacetFilter: filter,
+		Limit:       query.Limit,
+		Sort:        query.Sort,
+		After:       query.After,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
+
+// parseFacetFilter reads the selected facet values. Params may repeat to
+// select several values of one facet: ?brand=Apple&brand=Samsung.
+func parseFacetFilter(r *http.Request) (entity.FacetFilter, error) {
+	params := r.URL.Query()
+	filter := entity.FacetFilter{
+		Text:       strings.TrimSpace(params.Get("q")),
+		Brands:     params["brand"],
+		Attributes: make(map[string][]string),
+	}
+
+	for _, s := range params["category"] {
+		ID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return entity.FacetFilter{}, fmt.Errorf("invalid category id %q", s)
+		}
+		filter.CategoryIDs = append(filter.CategoryIDs, ID)
+	}
+
+	for _, s := range params["price"] {
+		bucket, err := strconv.Atoi(s)
+		if err != nil || bucket < 0 || bucket > len(entity.PriceBucketBounds) {
+			return entity.FacetFilter{}, fmt.Errorf("invalid price bucket %q", s)
+		}
+		filter.PriceBuckets = append(filter.PriceBuckets, bucket)
+	}
+
+	for key, values := range params {
+		name, ok := strings.CutPrefix(key, attributeParamPrefix)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			return entity.FacetFilter{}, fmt.Errorf("attribute name should not be empty")
+		}
+		filter.Attributes[name] = values
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_productFacetsHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockFacetsUsecase := mocks.NewMockProductFacetsUsecase(ctrl)
+	handler := NewProductFacetsHandler(mockFacetsUsecase)
+	handler.AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	page := entity.FacetPage{
+		Products: []entity.ProductCategoryListItem{{ID: 1, Name: "iphone"}},
+		Total:    1,
+		Facets: entity.Facets{
+			Categories: []entity.CategoryFacetCount{{ID: 1, Name: "phone", Count: 1}},
+			Brands:     []entity.FacetCount{{Value: "Apple", Count: 1}, {Value: "Samsung", Count: 4}},
+			Prices:     []entity.PriceFacetCount{entity.NewPriceFacetCount(4, 1)},
+			Attributes: map[string][]entity.FacetCount{"color": {{Value: "black", Count: 1}}},
+		},
+	}
+	expectedBody, err := json.Marshal(page)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		body    string
+		prepare func()
+	}{
+		{
+			name: "no filters",
+			path: "/api/v1/product/facets",
+			code: http.StatusOK,
+			body: string(expectedBody),
+			prepare: func() {
+				mockFacetsUsecase.EXPECT().Facets(gomock.Any(), entity.FacetQuery{
+					FacetFilter: entity.FacetFilter{Attributes: map[string][]string{}},
+					Sort:        entity.SortByID,
+				}).Return(page, nil)
+			},
+		},
+		{
+			name: "selected facets",
+			path: "/api/v1/product/facets?q=phone&category=1&category=2&brand=Apple&price=4&attr.color=black&limit=10",
+			code: http.StatusOK,
+			body: string(expectedBody),
+			prepare: func() {
+				mockFacetsUsecase.EXPECT().Facets(gomock.Any(), entity.FacetQuery{
+					FacetFilter: entity.FacetFilter{
+						Text:         "phone",
+						CategoryIDs:  []int64{1, 2},
+						Brands:       []string{"Apple"},
+						PriceBuckets: []int{4},
+						Attributes:   map[string][]string{"color": {"black"}},
+					},
+					Limit: 10,
+					Sort:  entity.SortByID,
+				}).Return(page, nil)
+			},
+		},
+		{
+			name:    "invalid category",
+			path:    "/api/v1/product/facets?category=abc",
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name:    "invalid price bucket",
+			path:    "/api/v1/product/facets?price=9",
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name: "unexpected error",
+			path: "/api/v1/product/facets",
+			code: http.StatusInternalServerError,
+			prepare: func() {
+				mockFacetsUsecase.EXPECT().Facets(gomock.Any(), gomock.Any()).
+					Return(entity.FacetPage{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "GET", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code == http.StatusOK {
+				require.Equal(t, tt.body, body)
+			}
+		})
+	}
+}
@@ -3,19 +3,21 @@ package v1
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
-	"github.com/The-Gleb/product_catalog/internal/errors"
 	"github.com/go-chi/chi/v5"
 )
 
 const getProductsByCategoryURL = "/api/v1/product/get/{categoryId}"
 
 type GetProductsByCategoryUsecase interface {
-	GetByCategory(ctx context.Context, categoryID int64) ([]entity.ProductCategoryListItem, error)
+	GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error)
 }
 
 type getProductsByCategoryHandler struct {
@@ -49,32 +51,81 @@ func (h *getProductsByCategoryHandler) ServeHTTP(w http.ResponseWriter, r *http.
 	ID, err := strconv.ParseInt(stringID, 10, 64)
 	if err != nil {
 		slog.Error("error parsing id from param to int64", "error", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Invalid(w, r, problem.FieldError{Field: "categoryId", Message: "should be an integer"})
 		return
 	}
 
-	products, err := h.usecase.GetByCategory(r.Context(), ID)
+	query, err := parseProductQuery(r, entity.SortByID, entity.SortByName, entity.SortByNameDesc)
+	if err != nil {
+		slog.Error("error parsing query params", "error", err)
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+	query.CategoryID = ID
+
+	page, err := h.usecase.GetByCategory(r.Context(), query)
 	if err != nil {
 		slog.Error(err.Error())
-		switch errors.Code(err) {
-		case errors.ErrCategoryNotFound:
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+		problem.Error(w, r, err)
+		return
 	}
 
-	body, err := json.Marshal(products)
+	body, err := json.Marshal(page)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Error(w, r, err)
 		return
 	}
 
 	_, err = w.Write(body)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Error(w, r, err)
 		return
 	}
 }
+
+// parseProductQuery reads the limit, cursor, sort, prefix and descendants
+// query params shared by the product listings. The first of sorts is the
+// default, the rest are the other orders the listing supports.
+func parseProductQuery(r *http.Request, sorts ...entity.ProductSort) (entity.ProductQuery, error) {
+	params := r.URL.Query()
+	query := entity.ProductQuery{
+		Sort:       sorts[0],
+		NamePrefix: params.Get("prefix"),
+	}
+
+	if s := params.Get("sort"); s != "" {
+		query.Sort = entity.ProductSort(s)
+		if !slices.Contains(sorts, query.Sort) {
+			return entity.ProductQuery{}, fmt.Errorf("unknown sort %q", s)
+		}
+	}
+
+	if s := params.Get("descendants"); s != "" {
+		descendants, err := strconv.ParseBool(s)
+		if err != nil {
+			return entity.ProductQuery{}, fmt.Errorf("descendants should be a boolean")
+		}
+		query.IncludeDescendants = descendants
+	}
+
+	if s := params.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit <= 0 {
+			return entity.ProductQuery{}, fmt.Errorf("limit should be a positive integer")
+		}
+		query.Limit = limit
+	}
+
+	if s := params.Get("cursor"); s != "" {
+		cursor, err := entity.DecodeProductCursor(s)
+		if err != nil {
+			return entity.ProductQuery{}, err
+		}
+		if cursor.Sort != query.Sort {
+			return entity.ProductQuery{}, fmt.Errorf("cursor doesn't match sort %q", query.Sort)
+		}
+		query.After = &cursor
+	}
+
+	return query, nil
+}
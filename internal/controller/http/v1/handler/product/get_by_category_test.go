@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -28,9 +29,11 @@ func Test_getProductByCategoryHandler_ServeHTTP_Success(t *testing.T) {
 	id := int64(1)
 	stringID := strconv.FormatInt(id, 10)
 
-	mockGetByCategoryUsecase.EXPECT().GetByCategory(gomock.Any(), id).
-		Return([]entity.ProductCategoryListItem{
-			{ID: 1, Name: "iphone"},
+	mockGetByCategoryUsecase.EXPECT().GetByCategory(gomock.Any(), entity.ProductQuery{CategoryID: id, Sort: entity.SortByID}).
+		Return(entity.ProductPage{
+			Products: []entity.ProductCategoryListItem{
+				{ID: 1, Name: "iphone"},
+			},
 		}, nil)
 
 	resp, _ := v1.TestRequest(t, "", server, "GET", "/api/v1/product/get/"+stringID, nil)
@@ -68,8 +71,8 @@ func Test_getProductByCategoryHandler_ServeHTTP_IDNotFound(t *testing.T) {
 	id := int64(1)
 	stringID := strconv.FormatInt(id, 10)
 
-	mockGetByCategoryUsecase.EXPECT().GetByCategory(gomock.Any(), id).
-		Return(nil, errors.NewDomainError(errors.ErrCategoryNotFound, ""))
+	mockGetByCategoryUsecase.EXPECT().GetByCategory(gomock.Any(), entity.ProductQuery{CategoryID: id, Sort: entity.SortByID}).
+		Return(entity.ProductPage{}, errors.NewDomainError(errors.ErrCategoryNotFound, ""))
 
 	resp, _ := v1.TestRequest(t, "", server, "GET", "/api/v1/product/get/"+stringID, nil)
 	defer resp.Body.Close()
@@ -90,11 +93,77 @@ func Test_getProductByCategoryHandler_ServeHTTP_UnexpectedError(t *testing.T) {
 	id := int64(1)
 	stringID := strconv.FormatInt(id, 10)
 
-	mockGetByCategoryUsecase.EXPECT().GetByCategory(gomock.Any(), id).
-		Return(nil, errors.NewDomainError(errors.ErrDB, ""))
+	mockGetByCategoryUsecase.EXPECT().GetByCategory(gomock.Any(), entity.ProductQuery{CategoryID: id, Sort: entity.SortByID}).
+		Return(entity.ProductPage{}, errors.NewDomainError(errors.ErrDB, ""))
 
 	resp, _ := v1.TestRequest(t, "", server, "GET", "/api/v1/product/get/"+stringID, nil)
 	defer resp.Body.Close()
 
 	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 }
+
+func Test_getProductByCategoryHandler_ServeHTTP_Pagination(t *testing.T) {
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockGetByCategoryUsecase := mocks.NewMockGetProductsByCategoryUsecase(ctrl)
+	handler := NewGetProductsByCategoryHandler(mockGetByCategoryUsecase)
+	handler.AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	cursor := entity.ProductCursor{Sort: entity.SortByName, ID: 3, Name: "iphone"}
+	mockGetByCategoryUsecase.EXPECT().GetByCategory(gomock.Any(), entity.ProductQuery{
+		CategoryID: 1,
+		Limit:      2,
+		Sort:       entity.SortByName,
+		NamePrefix: "i",
+		After:      &cursor,
+	}).Return(entity.ProductPage{
+		Products: []entity.ProductCategoryListItem{
+			{ID: 4, Name: "ipad"},
+			{ID: 5, Name: "ipod"},
+		},
+		NextCursor: entity.ProductCursor{Sort: entity.SortByName, ID: 5, Name: "ipod"}.Encode(),
+	}, nil)
+
+	resp, body := v1.TestRequest(t, "", server, "GET",
+		"/api/v1/product/get/1?limit=2&sort=name&prefix=i&cursor="+cursor.Encode(), nil)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var page entity.ProductPage
+	require.NoError(t, json.Unmarshal([]byte(body), &page))
+	require.Len(t, page.Products, 2)
+
+	next, err := entity.DecodeProductCursor(page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), next.ID)
+}
+
+func Test_getProductByCategoryHandler_ServeHTTP_InvalidQuery(t *testing.T) {
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockGetByCategoryUsecase := mocks.NewMockGetProductsByCategoryUsecase(ctrl)
+	handler := NewGetProductsByCategoryHandler(mockGetByCategoryUsecase)
+	handler.AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	idCursor := entity.ProductCursor{Sort: entity.SortByID, ID: 3}.Encode()
+
+	for _, query := range []string{
+		"?sort=price",
+		"?limit=0",
+		"?limit=abc",
+		"?cursor=not-a-cursor",
+		"?sort=name&cursor=" + idCursor,
+	} {
+		resp, _ := v1.TestRequest(t, "", server, "GET", "/api/v1/product/get/1"+query, nil)
+		resp.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
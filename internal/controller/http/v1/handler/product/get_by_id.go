@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const getProductByIDURL = "/api/v1/product/{id}"
+
+type GetProductByIDUsecase interface {
+	GetByID(ctx context.Context, ID int64) (entity.ProductView, error)
+}
+
+type getProductByIDHandler struct {
+	usecase     GetProductByIDUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewGetProductByIDHandler(usecase GetProductByIDUsecase) *getProductByIDHandler {
+	return &getProductByIDHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *getProductByIDHandler) AddToRouter(r *chi.Mux) {
+	r.Route(getProductByIDURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *getProductByIDHandler) Middlewares(md ...func(http.Handler) http.Handler) *getProductByIDHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *getProductByIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	stringID := chi.URLParam(r, "id")
+	ID, err := strconv.ParseInt(stringID, 10, 64)
+	if err != nil {
+		slog.Error("error parsing id from param to int64", "error", err)
+		problem.Invalid(w, r, problem.FieldError{Field: "id", Message: "should be an integer"})
+		return
+	}
+
+	product, err := h.usecase.GetByID(r.Context(), ID)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(product)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
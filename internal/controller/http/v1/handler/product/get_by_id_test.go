@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getProductByIDHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockGetByIDUsecase := mocks.NewMockGetProductByIDUsecase(ctrl)
+	handler := NewGetProductByIDHandler(mockGetByIDUsecase)
+	handler.AddToRouter(r)
+	// registered alongside the static product routes to make sure they don't clash
+	NewGetProductsByCategoryHandler(mocks.NewMockGetProductsByCategoryUsecase(ctrl)).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	product := entity.ProductView{
+		ID:   1,
+		Name: "iphone",
+		Categories: []entity.Category{
+			{ID: 1, Name: "phone"},
+			{ID: 2, Name: "apple"},
+		},
+		ProductDetails: entity.ProductDetails{
+			Price: 999,
+			Brand: "Apple",
+		},
+	}
+	expectedBody, err := json.Marshal(product)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		body    string
+		prepare func()
+	}{
+		{
+			name: "positive",
+			path: "/api/v1/product/1",
+			code: http.StatusOK,
+			body: string(expectedBody),
+			prepare: func() {
+				mockGetByIDUsecase.EXPECT().GetByID(gomock.Any(), int64(1)).Return(product, nil)
+			},
+		},
+		{
+			name:    "invalid id",
+			path:    "/api/v1/product/abc",
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name: "product not found",
+			path: "/api/v1/product/5",
+			code: http.StatusNotFound,
+			prepare: func() {
+				mockGetByIDUsecase.EXPECT().GetByID(gomock.Any(), int64(5)).
+					Return(entity.ProductView{}, errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name: "unexpected error",
+			path: "/api/v1/product/1",
+			code: http.StatusInternalServerError,
+			prepare: func() {
+				mockGetByIDUsecase.EXPECT().GetByID(gomock.Any(), int64(1)).
+					Return(entity.ProductView{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "GET", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code == http.StatusOK {
+				require.Equal(t, tt.body, body)
+			}
+		})
+	}
+}
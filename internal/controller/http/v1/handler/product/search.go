@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const searchProductsURL = "/api/v1/product/search"
+
+type SearchProductsUsecase interface {
+	Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error)
+}
+
+type searchProductsHandler struct {
+	usecase     SearchProductsUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewSearchProductsHandler(usecase SearchProductsUsecase) *searchProductsHandler {
+	return &searchProductsHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *searchProductsHandler) AddToRouter(r *chi.Mux) {
+	r.Route(searchProductsURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *searchProductsHandler) Middlewares(md ...func(http.Handler) http.Handler) *searchProductsHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *searchProductsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	text := strings.TrimSpace(r.URL.Query().Get("q"))
+	if text == "" {
+		problem.Invalid(w, r, problem.FieldError{Field: "q", Message: "should not be empty"})
+		return
+	}
+
+	query, err := parseProductQuery(r, entity.SortByRelevance, entity.SortByID, entity.SortByName, entity.SortByNameDesc)
+	if err != nil {
+		slog.Error("error parsing query params", "error", err)
+		problem.BadRequest(w, r, err.Error())
+		return
+	}
+
+	if s := r.URL.Query().Get("category"); s != "" {
+		query.CategoryID, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			slog.Error("error parsing category id to int64", "error", err)
+			problem.Invalid(w, r, problem.FieldError{Field: "category", Message: "should be an integer"})
+			return
+		}
+	}
+
+	page, err := h.usecase.Search(r.Context(), entity.ProductSearchQuery{Text: text, ProductQuery: query})
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_searchProductsHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockSearchUsecase := mocks.NewMockSearchProductsUsecase(ctrl)
+	handler := NewSearchProductsHandler(mockSearchUsecase)
+	handler.AddToRouter(r)
+	NewGetProductByIDHandler(mocks.NewMockGetProductByIDUsecase(ctrl)).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	page := entity.ProductSearchPage{
+		Products: []entity.ProductSearchHit{
+			{ID: 1, Name: "iphone 15", Rank: 0.8, Highlight: "<b>iphone</b> 15"},
+		},
+	}
+	expectedBody, err := json.Marshal(page)
+	require.NoError(t, err)
+
+	cursor := entity.ProductCursor{Sort: entity.SortByRelevance, ID: 7, Rank: 0.5}
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		body    string
+		prepare func()
+	}{
+		{
+			name: "positive",
+			path: "/api/v1/product/search?q=iphone",
+			code: http.StatusOK,
+			body: string(expectedBody),
+			prepare: func() {
+				mockSearchUsecase.EXPECT().Search(gomock.Any(), entity.ProductSearchQuery{
+					Text:         "iphone",
+					ProductQuery: entity.ProductQuery{Sort: entity.SortByRelevance},
+				}).Return(page, nil)
+			},
+		},
+		{
+			name: "category scope and pagination",
+			path: "/api/v1/product/search?q=iphne&category=3&descendants=true&limit=5&cursor=" + cursor.Encode(),
+			code: http.StatusOK,
+			body: string(expectedBody),
+			prepare: func() {
+				mockSearchUsecase.EXPECT().Search(gomock.Any(), entity.ProductSearchQuery{
+					Text: "iphne",
+					ProductQuery: entity.ProductQuery{
+						CategoryID:         3,
+						IncludeDescendants: true,
+						Limit:              5,
+						Sort:               entity.SortByRelevance,
+						After:              &cursor,
+					},
+				}).Return(page, nil)
+			},
+		},
+		{
+			name:    "empty query",
+			path:    "/api/v1/product/search?q=%20",
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name:    "invalid category",
+			path:    "/api/v1/product/search?q=iphone&category=abc",
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name:    "invalid sort",
+			path:    "/api/v1/product/search?q=iphone&sort=price",
+			code:    http.StatusBadRequest,
+			prepare: func() {},
+		},
+		{
+			name: "unexpected error",
+			path: "/api/v1/product/search?q=iphone",
+			code: http.StatusInternalServerError,
+			prepare: func() {
+				mockSearchUsecase.EXPECT().Search(gomock.Any(), gomock.Any()).
+					Return(entity.ProductSearchPage{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "GET", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code == http.StatusOK {
+				require.Equal(t, tt.body, body)
+			}
+		})
+	}
+}
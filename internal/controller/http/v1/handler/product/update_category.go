@@ -2,10 +2,11 @@ package v1
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/go-chi/chi/v5"
 )
@@ -44,21 +45,14 @@ func (h *updateProductCategoryHandler) Middlewares(md ...func(http.Handler) http
 func (h *updateProductCategoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	var dto entity.UpdateProductCategoryDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		slog.Error("error decoding json request body")
-		http.Error(w, err.Error(), http.StatusBadRequest)
-	}
-
-	if dto.NewCategoryID == 0 || dto.OldCategoryID == 0 || dto.ProductID == 0 {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if !v1.DecodeJSON(w, r, &dto) {
 		return
 	}
 
-	err = h.usecase.UpdateCategory(r.Context(), dto)
+	err := h.usecase.UpdateCategory(r.Context(), dto)
 	if err != nil {
 		slog.Error(err.Error())
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Error(w, r, err)
 		return
 	}
 
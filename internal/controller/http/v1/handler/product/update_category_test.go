@@ -79,7 +79,7 @@ func Test_updateProductCategoryHandler_ServeHTTP(t *testing.T) {
 		{
 			name:    "negative NoDataFound",
 			reqBody: validRequestBody,
-			code:    400,
+			code:    404,
 			prepare: func() {
 				mockUpdateCategoryUsecase.
 					EXPECT().
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const updateProductDetailsURL = "/api/v1/product/updateDetails"
+
+type UpdateProductDetailsUsecase interface {
+	UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error
+}
+
+type updateProductDetailsHandler struct {
+	usecase     UpdateProductDetailsUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewUpdateProductDetailsHandler(usecase UpdateProductDetailsUsecase) *updateProductDetailsHandler {
+	return &updateProductDetailsHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *updateProductDetailsHandler) AddToRouter(r *chi.Mux) {
+	r.Route(updateProductDetailsURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *updateProductDetailsHandler) Middlewares(md ...func(http.Handler) http.Handler) *updateProductDetailsHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+func (h *updateProductDetailsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var dto entity.UpdateProductDetailsDTO
+	if !v1.DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	err := h.usecase.UpdateDetails(r.Context(), dto)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_updateProductDetailsHandler_ServeHTTP(t *testing.T) {
+	dto := entity.UpdateProductDetailsDTO{
+		ProductID: 1,
+		ProductDetails: entity.ProductDetails{
+			Description: "flagship phone",
+			Price:       999,
+			Rating:      4.5,
+			Brand:       "Apple",
+			Attributes:  entity.ProductAttributes{"color": "silver"},
+		},
+	}
+	validRequestBody, err := json.Marshal(dto)
+	require.NoError(t, err)
+
+	invalidRequestBody, err := json.Marshal(entity.UpdateProductDetailsDTO{
+		ProductDetails: entity.ProductDetails{Price: 999},
+	})
+	require.NoError(t, err)
+
+	invalidDetailsRequestBody, err := json.Marshal(entity.UpdateProductDetailsDTO{
+		ProductID:      1,
+		ProductDetails: entity.ProductDetails{Rating: 7},
+	})
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockUpdateDetailsUsecase := mocks.NewMockUpdateProductDetailsUsecase(ctrl)
+	handler := NewUpdateProductDetailsHandler(mockUpdateDetailsUsecase)
+	handler.AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		reqBody json.RawMessage
+		code    int
+		prepare func()
+	}{
+		{
+			name:    "positive",
+			reqBody: validRequestBody,
+			code:    200,
+			prepare: func() {
+				mockUpdateDetailsUsecase.
+					EXPECT().
+					UpdateDetails(gomock.Any(), gomock.Eq(dto)).
+					Return(nil)
+			},
+		},
+		{
+			name:    "invalid body",
+			reqBody: invalidRequestBody,
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "invalid body 2",
+			reqBody: []byte("sdfasd"),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "rating out of range",
+			reqBody: invalidDetailsRequestBody,
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "negative, sku not unique",
+			reqBody: validRequestBody,
+			code:    409,
+			prepare: func() {
+				mockUpdateDetailsUsecase.
+					EXPECT().
+					UpdateDetails(gomock.Any(), gomock.Eq(dto)).
+					Return(errors.NewDomainError(errors.ErrAlreadyExists, ""))
+			},
+		},
+		{
+			name:    "negative NoDataFound",
+			reqBody: validRequestBody,
+			code:    404,
+			prepare: func() {
+				mockUpdateDetailsUsecase.
+					EXPECT().
+					UpdateDetails(gomock.Any(), gomock.Eq(dto)).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, "", server, "POST", "/api/v1/product/updateDetails", tt.reqBody)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
@@ -78,7 +78,7 @@ func Test_updateProductNameHandler_ServeHTTP(t *testing.T) {
 		{
 			name:    "negative NoDataFound",
 			reqBody: validRequestBody,
-			code:    400,
+			code:    404,
 			prepare: func() {
 				mockUpdateNameUsecase.
 					EXPECT().
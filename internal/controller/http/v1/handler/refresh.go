@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	refreshURL = "/api/v1/refresh"
+)
+
+type RefreshUsecase interface {
+	Refresh(ctx context.Context, refreshToken string) (entity.TokenPair, error)
+}
+
+type refreshHandler struct {
+	middlewares []func(http.Handler) http.Handler
+	usecase     RefreshUsecase
+}
+
+func NewRefreshHandler(usecase RefreshUsecase) *refreshHandler {
+	return &refreshHandler{usecase: usecase, middlewares: make([]func(http.Handler) http.Handler, 0)}
+}
+
+func (h *refreshHandler) AddToRouter(r *chi.Mux) {
+
+	r.Route(refreshURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.Refresh)
+	})
+}
+
+func (h *refreshHandler) Middlewares(md ...func(http.Handler) http.Handler) *refreshHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// Refresh exchanges the refresh token of the refreshToken cookie or of the
+// request body for a new token pair.
+func (h *refreshHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+
+	var dto struct {
+		RefreshToken string `validate:"required,max=1024"`
+	}
+	defer r.Body.Close()
+
+	if c, err := r.Cookie(refreshTokenCookie); err == nil && c.Value != "" {
+		dto.RefreshToken = c.Value
+	} else if !DecodeJSON(w, r, &dto) {
+		return
+	}
+
+	tokens, err := h.usecase.Refresh(r.Context(), dto.RefreshToken)
+	if err != nil {
+		slog.Error(err.Error())
+
+		switch errors.Code(err) {
+		case errors.ErrUnauthorized, errors.ErrSessionExpired:
+			ClearSessionCookie(w)
+			problem.Error(w, r, err)
+			return
+		default:
+			problem.Error(w, r, err)
+			return
+		}
+	}
+
+	WriteTokens(w, r, tokens)
+
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_refreshHandler_Refresh(t *testing.T) {
+	tokens := entity.TokenPair{
+		AccessToken:   "access2",
+		AccessExpiry:  time.Now().Add(time.Minute),
+		RefreshToken:  "refresh2",
+		RefreshExpiry: time.Now().Add(time.Hour),
+	}
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockRefreshUsecase := mocks.NewMockRefreshUsecase(ctrl)
+	NewRefreshHandler(mockRefreshUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		reqBody json.RawMessage
+		cookie  string
+		code    int
+		prepare func()
+	}{
+		{
+			name:    "token in body",
+			reqBody: []byte(`{"RefreshToken":"refresh1"}`),
+			code:    200,
+			prepare: func() {
+				mockRefreshUsecase.EXPECT().Refresh(gomock.Any(), "refresh1").Return(tokens, nil)
+			},
+		},
+		{
+			name:   "token in cookie",
+			cookie: "refresh1",
+			code:   200,
+			prepare: func() {
+				mockRefreshUsecase.EXPECT().Refresh(gomock.Any(), "refresh1").Return(tokens, nil)
+			},
+		},
+		{
+			name:    "no token",
+			reqBody: []byte(`{}`),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "invalid body",
+			reqBody: []byte("sdfasd"),
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name:    "reused token",
+			reqBody: []byte(`{"RefreshToken":"refresh1"}`),
+			code:    401,
+			prepare: func() {
+				mockRefreshUsecase.EXPECT().Refresh(gomock.Any(), "refresh1").
+					Return(entity.TokenPair{}, errors.NewDomainError(errors.ErrUnauthorized, "refresh token reuse"))
+			},
+		},
+		{
+			name:    "expired session",
+			reqBody: []byte(`{"RefreshToken":"refresh1"}`),
+			code:    401,
+			prepare: func() {
+				mockRefreshUsecase.EXPECT().Refresh(gomock.Any(), "refresh1").
+					Return(entity.TokenPair{}, errors.NewDomainError(errors.ErrSessionExpired, ""))
+			},
+		},
+		{
+			name:    "db error",
+			reqBody: []byte(`{"RefreshToken":"refresh1"}`),
+			code:    500,
+			prepare: func() {
+				mockRefreshUsecase.EXPECT().Refresh(gomock.Any(), "refresh1").
+					Return(entity.TokenPair{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/refresh", bytes.NewReader(tt.reqBody))
+			require.NoError(t, err)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "refreshToken", Value: tt.cookie})
+			}
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code != 200 {
+				return
+			}
+
+			var got entity.TokenPair
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+			require.Equal(t, "refresh2", got.RefreshToken)
+			require.Len(t, resp.Cookies(), 2)
+		})
+	}
+}
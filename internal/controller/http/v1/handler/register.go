@@ -2,12 +2,11 @@ package v1
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
-	"github.com/The-Gleb/product_catalog/internal/errors"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -16,7 +15,7 @@ const (
 )
 
 type RegisterUsecase interface {
-	Register(ctx context.Context, credentials entity.Credentials) (entity.Session, error)
+	Register(ctx context.Context, credentials entity.Credentials, client entity.ClientInfo) (entity.TokenPair, error)
 }
 
 type registerHandler struct {
@@ -46,41 +45,18 @@ func (h *registerHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var dto entity.Credentials
 	defer r.Body.Close()
 
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		slog.Error("error parsing json request body to dto", "error", err)
-		http.Error(w, "error parsing json request body to dto", http.StatusBadRequest)
-		return
-	}
-
-	if dto.Login == "" || dto.Password == "" {
-		http.Error(w, "login and password should not be empty", http.StatusBadRequest)
+	if !DecodeJSON(w, r, &dto) {
 		return
 	}
 
-	s, err := h.usecase.Register(r.Context(), dto)
+	tokens, err := h.usecase.Register(r.Context(), dto, ClientInfo(r))
 	if err != nil {
 		slog.Error(err.Error())
 
-		switch errors.Code(err) {
-		case errors.ErrAlreadyExists:
-			http.Error(w, err.Error(), http.StatusConflict)
-			return
-		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-	}
-
-	c := http.Cookie{
-		Name:    "sessionToken",
-		Value:   s.Token,
-		Expires: s.Expiry,
-		Path:    "/",
+		problem.Error(w, r, err)
+		return
 	}
 
-	http.SetCookie(w, &c)
-
-	w.WriteHeader(http.StatusOK)
+	WriteTokens(w, r, tokens)
 
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/The-Gleb/product_catalog/internal/errors"
 	"github.com/The-Gleb/product_catalog/internal/mocks"
@@ -40,6 +41,7 @@ func Test_registerHandler_Register(t *testing.T) {
 		name    string
 		reqBody json.RawMessage
 		code    int
+		problem problem.Code
 		prepare func()
 	}{
 		{
@@ -52,11 +54,12 @@ func Test_registerHandler_Register(t *testing.T) {
 					Register(gomock.Any(), gomock.Eq(entity.Credentials{
 						Login:    "login1",
 						Password: "password1",
-					})).
-					Return(entity.Session{
-						UserID: 1,
-						Token:  "123",
-						Expiry: time.Now().Add(time.Hour),
+					}), gomock.Any()).
+					Return(entity.TokenPair{
+						AccessToken:   "access",
+						AccessExpiry:  time.Now().Add(time.Minute),
+						RefreshToken:  "refresh",
+						RefreshExpiry: time.Now().Add(time.Hour),
 					}, nil)
 			},
 		},
@@ -64,6 +67,7 @@ func Test_registerHandler_Register(t *testing.T) {
 			name:    "negative, body with no username",
 			reqBody: invalidRegisterReqBody,
 			code:    400,
+			problem: problem.CodeValidationFailed,
 			prepare: func() {
 			},
 		},
@@ -71,6 +75,7 @@ func Test_registerHandler_Register(t *testing.T) {
 			name:    "negative, invalid body",
 			reqBody: []byte("domasldkfjdf"),
 			code:    400,
+			problem: problem.CodeInvalidRequest,
 			prepare: func() {
 			},
 		},
@@ -78,28 +83,45 @@ func Test_registerHandler_Register(t *testing.T) {
 			name:    "negative, wrong login/password",
 			reqBody: validRegisterReqBody,
 			code:    409,
+			problem: problem.CodeAlreadyExists,
 			prepare: func() {
 				mockRegisterUsecase.
 					EXPECT().
 					Register(gomock.Any(), gomock.Eq(entity.Credentials{
 						Login:    "login1",
 						Password: "password1",
-					})).
-					Return(entity.Session{}, errors.NewDomainError(errors.ErrAlreadyExists, ""))
+					}), gomock.Any()).
+					Return(entity.TokenPair{}, errors.NewDomainError(errors.ErrAlreadyExists, ""))
+			},
+		},
+		{
+			name:    "negative, weak password",
+			reqBody: validRegisterReqBody,
+			code:    400,
+			problem: problem.CodeWeakPassword,
+			prepare: func() {
+				mockRegisterUsecase.
+					EXPECT().
+					Register(gomock.Any(), gomock.Eq(entity.Credentials{
+						Login:    "login1",
+						Password: "password1",
+					}), gomock.Any()).
+					Return(entity.TokenPair{}, errors.NewDomainError(errors.ErrWeakPassword, "password is too common"))
 			},
 		},
 		{
 			name:    "negative, some db err",
 			reqBody: validRegisterReqBody,
 			code:    500,
+			problem: problem.CodeInternal,
 			prepare: func() {
 				mockRegisterUsecase.
 					EXPECT().
 					Register(gomock.Any(), gomock.Eq(entity.Credentials{
 						Login:    "login1",
 						Password: "password1",
-					})).
-					Return(entity.Session{}, errors.NewDomainError(errors.ErrDB, ""))
+					}), gomock.Any()).
+					Return(entity.TokenPair{}, errors.NewDomainError(errors.ErrDB, ""))
 			},
 		},
 	}
@@ -108,21 +130,32 @@ func Test_registerHandler_Register(t *testing.T) {
 
 			tt.prepare()
 
-			resp, _ := TestRequest(t, "", server, "POST", "/api/v1/register", tt.reqBody)
+			resp, body := TestRequest(t, "", server, "POST", "/api/v1/register", tt.reqBody)
 			defer resp.Body.Close()
 
 			require.Equal(t, tt.code, resp.StatusCode)
 
 			if tt.code != 200 {
+				require.Equal(t, problem.ContentType, resp.Header.Get("Content-Type"))
+				var p problem.Problem
+				require.NoError(t, json.Unmarshal([]byte(body), &p))
+				require.Equal(t, tt.problem, p.Code)
 				return
 			}
 
 			cookies := resp.Cookies()
-			require.NotEqual(t, "0", len(cookies))
+			require.Len(t, cookies, 2)
 			require.Equal(t, "sessionToken", cookies[0].Name)
-			require.NotEmpty(t, cookies[0].Value)
+			require.Equal(t, "access", cookies[0].Value)
+			require.Equal(t, "refreshToken", cookies[1].Name)
+			require.Equal(t, "refresh", cookies[1].Value)
 			slog.Debug("received cookie", "cookie", cookies[0])
 
+			var tokens entity.TokenPair
+			require.NoError(t, json.Unmarshal([]byte(body), &tokens))
+			require.Equal(t, "access", tokens.AccessToken)
+			require.Equal(t, "refresh", tokens.RefreshToken)
+
 		})
 	}
 }
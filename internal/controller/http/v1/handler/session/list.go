@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	sessionsURL = "/api/v1/sessions"
+)
+
+type SessionsUsecase interface {
+	Sessions(ctx context.Context, userID, sessionID int64) ([]entity.SessionView, error)
+}
+
+type sessionsHandler struct {
+	usecase     SessionsUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewSessionsHandler(usecase SessionsUsecase) *sessionsHandler {
+	return &sessionsHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *sessionsHandler) AddToRouter(r *chi.Mux) {
+	r.Route(sessionsURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *sessionsHandler) Middlewares(md ...func(http.Handler) http.Handler) *sessionsHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP lists the active sessions of the current user.
+func (h *sessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	userID, ok := r.Context().Value(middleware.Key("userID")).(int64)
+	if !ok {
+		problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
+		return
+	}
+	sessionID, _ := r.Context().Value(middleware.Key("sessionID")).(int64)
+
+	sessions, err := h.usecase.Sessions(r.Context(), userID, sessionID)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(sessions)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sessionsHandler_ServeHTTP(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	sessions := []entity.SessionView{
+		{ID: 2, CreatedAt: now, LastUsedAt: now, Expiry: now.Add(time.Hour), IP: "127.0.0.1", UserAgent: "curl", Current: true},
+		{ID: 1, CreatedAt: now, LastUsedAt: now, Expiry: now.Add(time.Hour), IP: "10.0.0.1", UserAgent: "firefox"},
+	}
+
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockSessionsUsecase := mocks.NewMockSessionsUsecase(ctrl)
+	NewSessionsHandler(mockSessionsUsecase).
+		Middlewares(middleware.NewAuthMiddleware(mockAuthUsecase).Do).
+		AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		token   string
+		code    int
+		want    []entity.SessionView
+		prepare func()
+	}{
+		{
+			name:  "positive",
+			token: "123",
+			code:  200,
+			want:  sessions,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockSessionsUsecase.EXPECT().Sessions(gomock.Any(), int64(1), int64(2)).Return(sessions, nil)
+			},
+		},
+		{
+			name:    "not logged in",
+			code:    401,
+			prepare: func() {},
+		},
+		{
+			name:  "db error",
+			token: "123",
+			code:  500,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").
+					Return(entity.Session{ID: 2, UserID: 1, Token: "123"}, nil)
+				mockSessionsUsecase.EXPECT().Sessions(gomock.Any(), int64(1), int64(2)).
+					Return(nil, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, tt.token, server, "GET", "/api/v1/sessions", nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code != 200 {
+				return
+			}
+
+			var got []entity.SessionView
+			require.NoError(t, json.Unmarshal([]byte(body), &got))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	revokeSessionURL = "/api/v1/sessions/revoke/{id}"
+)
+
+type RevokeSessionUsecase interface {
+	RevokeSession(ctx context.Context, userID, sessionID int64) error
+}
+
+type revokeSessionHandler struct {
+	usecase     RevokeSessionUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewRevokeSessionHandler(usecase RevokeSessionUsecase) *revokeSessionHandler {
+	return &revokeSessionHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *revokeSessionHandler) AddToRouter(r *chi.Mux) {
+	r.Route(revokeSessionURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *revokeSessionHandler) Middlewares(md ...func(http.Handler) http.Handler) *revokeSessionHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP revokes one session of the current user. Revoking the session of
+// the request also drops its cookie.
+func (h *revokeSessionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	userID, ok := r.Context().Value(middleware.Key("userID")).(int64)
+	if !ok {
+		problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
+		return
+	}
+	currentID, _ := r.Context().Value(middleware.Key("sessionID")).(int64)
+
+	stringID := chi.URLParam(r, "id")
+	ID, err := strconv.ParseInt(stringID, 10, 64)
+	if err != nil {
+		slog.Error("error parsing id from param to int64", "error", err)
+		problem.Invalid(w, r, problem.FieldError{Field: "id", Message: "should be an integer"})
+		return
+	}
+
+	err = h.usecase.RevokeSession(r.Context(), userID, ID)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	if ID == currentID {
+		v1.ClearSessionCookie(w)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	revokeAllSessionsURL = "/api/v1/sessions/revokeAll"
+)
+
+type RevokeAllSessionsUsecase interface {
+	RevokeAllSessions(ctx context.Context, userID int64) error
+}
+
+type revokeAllSessionsHandler struct {
+	usecase     RevokeAllSessionsUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewRevokeAllSessionsHandler(usecase RevokeAllSessionsUsecase) *revokeAllSessionsHandler {
+	return &revokeAllSessionsHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *revokeAllSessionsHandler) AddToRouter(r *chi.Mux) {
+	r.Route(revokeAllSessionsURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *revokeAllSessionsHandler) Middlewares(md ...func(http.Handler) http.Handler) *revokeAllSessionsHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP logs the current user out everywhere, including this client.
+func (h *revokeAllSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	userID, ok := r.Context().Value(middleware.Key("userID")).(int64)
+	if !ok {
+		problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
+		return
+	}
+
+	err := h.usecase.RevokeAllSessions(r.Context(), userID)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	v1.ClearSessionCookie(w)
+
+	w.WriteHeader(http.StatusOK)
+}
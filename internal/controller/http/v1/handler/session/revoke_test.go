@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	middleware "github.com/The-Gleb/product_catalog/internal/controller/http/v1/middleware"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_revokeSessionHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockRevokeSessionUsecase := mocks.NewMockRevokeSessionUsecase(ctrl)
+	NewRevokeSessionHandler(mockRevokeSessionUsecase).
+		Middlewares(middleware.NewAuthMiddleware(mockAuthUsecase).Do).
+		AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	current := entity.Session{ID: 2, UserID: 1, Token: "123"}
+
+	tests := []struct {
+		name        string
+		path        string
+		code        int
+		clearCookie bool
+		prepare     func()
+	}{
+		{
+			name: "other session",
+			path: "/api/v1/sessions/revoke/1",
+			code: 200,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").Return(current, nil)
+				mockRevokeSessionUsecase.EXPECT().RevokeSession(gomock.Any(), int64(1), int64(1)).Return(nil)
+			},
+		},
+		{
+			name:        "current session",
+			path:        "/api/v1/sessions/revoke/2",
+			code:        200,
+			clearCookie: true,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").Return(current, nil)
+				mockRevokeSessionUsecase.EXPECT().RevokeSession(gomock.Any(), int64(1), int64(2)).Return(nil)
+			},
+		},
+		{
+			name: "invalid id",
+			path: "/api/v1/sessions/revoke/abc",
+			code: 400,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").Return(current, nil)
+			},
+		},
+		{
+			name: "session of another user",
+			path: "/api/v1/sessions/revoke/5",
+			code: 404,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "123").Return(current, nil)
+				mockRevokeSessionUsecase.EXPECT().RevokeSession(gomock.Any(), int64(1), int64(5)).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, _ := v1.TestRequest(t, "123", server, "POST", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			require.Equal(t, tt.clearCookie, len(resp.Cookies()) > 0)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	reconcileURL = "/api/v1/sync/reconcile"
+)
+
+type ReconcileUsecase interface {
+	Reconcile(ctx context.Context, source string) (entity.ReconcileReport, error)
+}
+
+type reconcileHandler struct {
+	usecase     ReconcileUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewReconcileHandler(usecase ReconcileUsecase) *reconcileHandler {
+	return &reconcileHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *reconcileHandler) AddToRouter(r *chi.Mux) {
+	r.Route(reconcileURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Post("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *reconcileHandler) Middlewares(md ...func(http.Handler) http.Handler) *reconcileHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP reconciles the source given by the source query parameter and
+// responds with the diff report of the run.
+func (h *reconcileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	source := r.URL.Query().Get("source")
+	if source == "" {
+		problem.Invalid(w, r, problem.FieldError{Field: "source", Message: "should not be empty"})
+		return
+	}
+
+	report, err := h.usecase.Reconcile(r.Context(), source)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reconcileHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockReconcileUsecase := mocks.NewMockReconcileUsecase(ctrl)
+	NewReconcileHandler(mockReconcileUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	report := entity.ReconcileReport{
+		ID:      1,
+		Source:  "dummyjson",
+		Mode:    entity.DeleteModeTombstone,
+		Added:   []entity.ReconciledProduct{{ExternalID: "7", Name: "galaxy"}},
+		Deleted: []entity.ReconciledProduct{{ExternalID: "4", Name: "nokia"}},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		prepare func()
+	}{
+		{
+			name: "positive",
+			path: "/api/v1/sync/reconcile?source=dummyjson",
+			code: 200,
+			prepare: func() {
+				mockReconcileUsecase.EXPECT().
+					Reconcile(gomock.Any(), "dummyjson").
+					Return(report, nil)
+			},
+		},
+		{
+			name:    "no source",
+			path:    "/api/v1/sync/reconcile",
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name: "unknown source",
+			path: "/api/v1/sync/reconcile?source=ftp",
+			code: 404,
+			prepare: func() {
+				mockReconcileUsecase.EXPECT().
+					Reconcile(gomock.Any(), "ftp").
+					Return(entity.ReconcileReport{}, errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name: "db error",
+			path: "/api/v1/sync/reconcile?source=dummyjson",
+			code: 500,
+			prepare: func() {
+				mockReconcileUsecase.EXPECT().
+					Reconcile(gomock.Any(), "dummyjson").
+					Return(entity.ReconcileReport{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "POST", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code != 200 {
+				return
+			}
+
+			var got entity.ReconcileReport
+			require.NoError(t, json.Unmarshal([]byte(body), &got))
+			require.Equal(t, report, got)
+		})
+	}
+}
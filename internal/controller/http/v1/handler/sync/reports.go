@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	reconcileReportsURL = "/api/v1/sync/reports"
+)
+
+type ReconcileReportsUsecase interface {
+	Reports(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error)
+}
+
+type reconcileReportsHandler struct {
+	usecase     ReconcileReportsUsecase
+	middlewares []func(http.Handler) http.Handler
+}
+
+func NewReconcileReportsHandler(usecase ReconcileReportsUsecase) *reconcileReportsHandler {
+	return &reconcileReportsHandler{
+		usecase:     usecase,
+		middlewares: make([]func(http.Handler) http.Handler, 0),
+	}
+}
+
+func (h *reconcileReportsHandler) AddToRouter(r *chi.Mux) {
+	r.Route(reconcileReportsURL, func(r chi.Router) {
+		r.Use(h.middlewares...)
+		r.Get("/", h.ServeHTTP)
+	})
+
+}
+
+func (h *reconcileReportsHandler) Middlewares(md ...func(http.Handler) http.Handler) *reconcileReportsHandler {
+	h.middlewares = append(h.middlewares, md...)
+	return h
+}
+
+// ServeHTTP lists the latest reconciliation reports, optionally of one source.
+func (h *reconcileReportsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var limit int
+	if s := r.URL.Query().Get("limit"); s != "" {
+		var err error
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit < 0 {
+			problem.Invalid(w, r, problem.FieldError{Field: "limit", Message: "should be a non-negative integer"})
+			return
+		}
+	}
+
+	reports, err := h.usecase.Reports(r.Context(), r.URL.Query().Get("source"), limit)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	body, err := json.Marshal(reports)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	_, err = w.Write(body)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/The-Gleb/product_catalog/internal/controller/http/v1/handler"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reconcileReportsHandler_ServeHTTP(t *testing.T) {
+	r := chi.NewRouter()
+
+	ctrl := gomock.NewController(t)
+	mockReportsUsecase := mocks.NewMockReconcileReportsUsecase(ctrl)
+	NewReconcileReportsHandler(mockReportsUsecase).AddToRouter(r)
+	server := httptest.NewServer(r)
+
+	reports := []entity.ReconcileReport{
+		{ID: 2, Source: "dummyjson", Mode: entity.DeleteModeTombstone, Unchanged: 30},
+		{ID: 1, Source: "dummyjson", Mode: entity.DeleteModeTombstone, Unchanged: 29},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		prepare func()
+	}{
+		{
+			name: "positive",
+			path: "/api/v1/sync/reports?source=dummyjson&limit=2",
+			code: 200,
+			prepare: func() {
+				mockReportsUsecase.EXPECT().
+					Reports(gomock.Any(), "dummyjson", 2).
+					Return(reports, nil)
+			},
+		},
+		{
+			name: "all sources",
+			path: "/api/v1/sync/reports",
+			code: 200,
+			prepare: func() {
+				mockReportsUsecase.EXPECT().
+					Reports(gomock.Any(), "", 0).
+					Return(reports, nil)
+			},
+		},
+		{
+			name:    "invalid limit",
+			path:    "/api/v1/sync/reports?limit=-1",
+			code:    400,
+			prepare: func() {},
+		},
+		{
+			name: "db error",
+			path: "/api/v1/sync/reports",
+			code: 500,
+			prepare: func() {
+				mockReportsUsecase.EXPECT().
+					Reports(gomock.Any(), "", 0).
+					Return(nil, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			resp, body := v1.TestRequest(t, "", server, "GET", tt.path, nil)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			if tt.code != 200 {
+				return
+			}
+
+			var got []entity.ReconcileReport
+			require.NoError(t, json.Unmarshal([]byte(body), &got))
+			require.Equal(t, reports, got)
+		})
+	}
+}
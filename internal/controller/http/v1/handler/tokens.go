@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+const (
+	accessTokenCookie  = "sessionToken"
+	refreshTokenCookie = "refreshToken"
+)
+
+// WriteTokens sets the token cookies for browsers and responds with the
+// tokens for clients that send them in an Authorization header.
+func WriteTokens(w http.ResponseWriter, r *http.Request, tokens entity.TokenPair) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     accessTokenCookie,
+		Value:    tokens.AccessToken,
+		Expires:  tokens.AccessExpiry,
+		Path:     "/",
+		HttpOnly: true,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    tokens.RefreshToken,
+		Expires:  tokens.RefreshExpiry,
+		Path:     refreshURL,
+		HttpOnly: true,
+	})
+
+	body, err := json.Marshal(tokens)
+	if err != nil {
+		problem.Error(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(body)
+	if err != nil {
+		slog.Error("error writing response", "error", err)
+	}
+}
+
+// ClearSessionCookie tells the client to drop its token cookies.
+func ClearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:   accessTokenCookie,
+		Value:  "",
+		Path:   "/",
+		MaxAge: -1,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:   refreshTokenCookie,
+		Value:  "",
+		Path:   refreshURL,
+		MaxAge: -1,
+	})
+}
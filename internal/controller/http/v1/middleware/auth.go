@@ -4,14 +4,18 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"strings"
 
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/The-Gleb/product_catalog/internal/errors"
 )
 
 type Key string
 
 type AuthUsecase interface {
-	Auth(ctx context.Context, token string) (int64, error)
+	Auth(ctx context.Context, token string) (entity.Session, error)
+	AuthAPIKey(ctx context.Context, key string) (entity.APIKey, error)
 }
 
 type authMiddleWare struct {
@@ -22,29 +26,75 @@ func NewAuthMiddleware(usecase AuthUsecase) *authMiddleWare {
 	return &authMiddleWare{usecase}
 }
 
+// Do authenticates the request with the access token of the sessionToken
+// cookie or with the access token or API key of an
+// "Authorization: Bearer" header.
 func (m *authMiddleWare) Do(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		slog.Debug("auth middleware working")
 
-		c, err := r.Cookie("sessionToken")
-		if err != nil {
-			slog.Error("error getting cookie", "error", err)
-			http.Error(w, string(errors.ErrUnauthorized), http.StatusUnauthorized)
+		token, ok := bearerToken(r)
+		if !ok {
+			c, err := r.Cookie("sessionToken")
+			if err != nil {
+				slog.Error("error getting cookie", "error", err)
+				problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
+				return
+			}
+			token = c.Value
+		}
+
+		if strings.HasPrefix(token, entity.APIKeyPrefix) {
+			m.apiKey(next, w, r, token)
 			return
 		}
-		slog.Debug("Cookie is", "cookie", c.Value)
 
-		userID, err := m.usecase.Auth(r.Context(), c.Value)
+		session, err := m.usecase.Auth(r.Context(), token)
 		if err != nil {
-			http.Error(w, string(errors.ErrUnauthorized), http.StatusUnauthorized)
+			if errors.Code(err) == errors.ErrSessionExpired {
+				problem.Error(w, r, err)
+				return
+			}
+			problem.Write(w, r, http.StatusUnauthorized, problem.CodeUnauthorized, "not logged in")
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), Key("userID"), userID)
-		ctx = context.WithValue(ctx, Key("token"), c.Value)
+		ctx := context.WithValue(r.Context(), Key("userID"), session.UserID)
+		ctx = context.WithValue(ctx, Key("sessionID"), session.ID)
+		ctx = context.WithValue(ctx, Key("token"), token)
 
 		r = r.WithContext(ctx)
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// apiKey serves a request authenticated with an API key. The key, rather
+// than a user, is put into the context.
+func (m *authMiddleWare) apiKey(next http.Handler, w http.ResponseWriter, r *http.Request, key string) {
+	apiKey, err := m.usecase.AuthAPIKey(r.Context(), key)
+	if err != nil {
+		slog.Error(err.Error())
+		problem.Error(w, r, err)
+		return
+	}
+
+	slog.Info("request authenticated with api key",
+		"key_id", apiKey.ID, "key_name", apiKey.Name,
+		"method", r.Method, "path", r.URL.Path,
+	)
+
+	apiKey.Hash = ""
+	ctx := context.WithValue(r.Context(), Key("apiKey"), apiKey)
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
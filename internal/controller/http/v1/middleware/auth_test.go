@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_authMiddleWare_Do(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+	var (
+		gotUserID int64
+		gotAPIKey entity.APIKey
+	)
+	r := chi.NewRouter()
+	r.With(NewAuthMiddleware(mockAuthUsecase).Do).Get("/", func(w http.ResponseWriter, r *http.Request) {
+		gotUserID, _ = r.Context().Value(Key("userID")).(int64)
+		gotAPIKey, _ = r.Context().Value(Key("apiKey")).(entity.APIKey)
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(r)
+
+	apiKey := "pck_secret"
+
+	tests := []struct {
+		name          string
+		authorization string
+		cookie        string
+		code          int
+		wantUserID    int64
+		wantAPIKeyID  int64
+		prepare       func()
+	}{
+		{
+			name:       "session cookie",
+			cookie:     "token",
+			code:       200,
+			wantUserID: 1,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").Return(entity.Session{ID: 7, UserID: 1}, nil)
+			},
+		},
+		{
+			name:          "bearer session token",
+			authorization: "Bearer token",
+			code:          200,
+			wantUserID:    1,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").Return(entity.Session{ID: 7, UserID: 1}, nil)
+			},
+		},
+		{
+			name:          "bearer header wins over cookie",
+			authorization: "bearer token",
+			cookie:        "other",
+			code:          200,
+			wantUserID:    1,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").Return(entity.Session{ID: 7, UserID: 1}, nil)
+			},
+		},
+		{
+			name:          "api key",
+			authorization: "Bearer " + apiKey,
+			code:          200,
+			wantAPIKeyID:  3,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().AuthAPIKey(gomock.Any(), apiKey).
+					Return(entity.APIKey{ID: 3, Name: "importer", Hash: "hash"}, nil)
+			},
+		},
+		{
+			name:          "revoked api key",
+			authorization: "Bearer " + apiKey,
+			code:          401,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().AuthAPIKey(gomock.Any(), apiKey).
+					Return(entity.APIKey{}, errors.NewDomainError(errors.ErrUnauthorized, ""))
+			},
+		},
+		{
+			name:          "unknown session token",
+			authorization: "Bearer token",
+			code:          401,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").
+					Return(entity.Session{}, errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:          "basic auth",
+			authorization: "Basic dXNlcjpwYXNz",
+			code:          401,
+			prepare:       func() {},
+		},
+		{
+			name:    "no credentials",
+			code:    401,
+			prepare: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUserID, gotAPIKey = 0, entity.APIKey{}
+			tt.prepare()
+
+			req, err := http.NewRequest("GET", server.URL, nil)
+			require.NoError(t, err)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "sessionToken", Value: tt.cookie})
+			}
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+			require.Equal(t, tt.wantUserID, gotUserID)
+			require.Equal(t, tt.wantAPIKeyID, gotAPIKey.ID)
+			require.Empty(t, gotAPIKey.Hash)
+		})
+	}
+}
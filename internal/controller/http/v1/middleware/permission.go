@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+type AuthorizeUsecase interface {
+	Authorize(ctx context.Context, userID int64, p entity.Permission) (entity.Role, error)
+}
+
+type permissionMiddleware struct {
+	auth    *authMiddleWare
+	usecase AuthorizeUsecase
+}
+
+func NewPermissionMiddleware(auth *authMiddleWare, usecase AuthorizeUsecase) *permissionMiddleware {
+	return &permissionMiddleware{auth, usecase}
+}
+
+// Require returns a middleware that authenticates the request with the auth
+// middleware and then lets through only the users whose role has permission p
+// and the API keys scoped to p.
+func (m *permissionMiddleware) Require(p entity.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return m.auth.Do(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKey, ok := r.Context().Value(Key("apiKey")).(entity.APIKey); ok {
+				if !apiKey.Can(p) {
+					slog.Error("api key has no permission", "key_id", apiKey.ID, "permission", p)
+					problem.Write(w, r, http.StatusForbidden, problem.CodeForbidden, "api key is not scoped to "+string(p))
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, _ := r.Context().Value(Key("userID")).(int64)
+
+			role, err := m.usecase.Authorize(r.Context(), userID, p)
+			if err != nil {
+				slog.Error(err.Error())
+				problem.Error(w, r, err)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), Key("role"), role)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}))
+	}
+}
@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_permissionMiddleware_Require(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockAuthorizeUsecase := mocks.NewMockAuthorizeUsecase(ctrl)
+
+	m := NewPermissionMiddleware(NewAuthMiddleware(mockAuthUsecase), mockAuthorizeUsecase)
+
+	r := chi.NewRouter()
+	r.With(m.Require(entity.PermissionCatalogWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, entity.RoleEditor, r.Context().Value(Key("role")))
+		require.Equal(t, int64(1), r.Context().Value(Key("userID")))
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name    string
+		token   string
+		code    int
+		prepare func()
+	}{
+		{
+			name:  "editor",
+			token: "token",
+			code:  200,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").Return(entity.Session{ID: 7, UserID: 1}, nil)
+				mockAuthorizeUsecase.EXPECT().
+					Authorize(gomock.Any(), int64(1), entity.PermissionCatalogWrite).
+					Return(entity.RoleEditor, nil)
+			},
+		},
+		{
+			name:  "viewer",
+			token: "token",
+			code:  403,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").Return(entity.Session{ID: 7, UserID: 1}, nil)
+				mockAuthorizeUsecase.EXPECT().
+					Authorize(gomock.Any(), int64(1), entity.PermissionCatalogWrite).
+					Return(entity.RoleViewer, errors.NewDomainError(errors.ErrForbidden, ""))
+			},
+		},
+		{
+			name:    "no session",
+			code:    401,
+			prepare: func() {},
+		},
+		{
+			name:  "expired session",
+			token: "token",
+			code:  401,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").
+					Return(entity.Session{}, errors.NewDomainError(errors.ErrSessionExpired, ""))
+			},
+		},
+		{
+			name:  "deleted user",
+			token: "token",
+			code:  401,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").Return(entity.Session{ID: 7, UserID: 1}, nil)
+				mockAuthorizeUsecase.EXPECT().
+					Authorize(gomock.Any(), int64(1), entity.PermissionCatalogWrite).
+					Return(entity.Role(""), errors.NewDomainError(errors.ErrUnauthorized, ""))
+			},
+		},
+		{
+			name:  "db error",
+			token: "token",
+			code:  500,
+			prepare: func() {
+				mockAuthUsecase.EXPECT().Auth(gomock.Any(), "token").Return(entity.Session{ID: 7, UserID: 1}, nil)
+				mockAuthorizeUsecase.EXPECT().
+					Authorize(gomock.Any(), int64(1), entity.PermissionCatalogWrite).
+					Return(entity.Role(""), errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			req, err := http.NewRequest("POST", server.URL, nil)
+			require.NoError(t, err)
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: "sessionToken", Value: tt.token})
+			}
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
+
+func Test_permissionMiddleware_Require_apiKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockAuthorizeUsecase := mocks.NewMockAuthorizeUsecase(ctrl)
+
+	m := NewPermissionMiddleware(NewAuthMiddleware(mockAuthUsecase), mockAuthorizeUsecase)
+
+	r := chi.NewRouter()
+	r.With(m.Require(entity.PermissionSyncManage)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(r)
+
+	tests := []struct {
+		name   string
+		scopes []entity.Permission
+		code   int
+	}{
+		{
+			name:   "scoped",
+			scopes: []entity.Permission{entity.PermissionCatalogWrite, entity.PermissionSyncManage},
+			code:   200,
+		},
+		{
+			name:   "not scoped",
+			scopes: []entity.Permission{entity.PermissionCatalogWrite},
+			code:   403,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUsecase.EXPECT().AuthAPIKey(gomock.Any(), "pck_secret").
+				Return(entity.APIKey{ID: 1, Scopes: tt.scopes}, nil)
+
+			req, err := http.NewRequest("POST", server.URL, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer pck_secret")
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/controller/http/v1/problem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	var gotID string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID, _ = r.Context().Value(Key("requestID")).(string)
+	}))
+
+	tests := []struct {
+		name     string
+		clientID string
+		keep     bool
+	}{
+		{name: "client id", clientID: "abc-123.x_y:z", keep: true},
+		{name: "no id"},
+		{name: "invalid characters", clientID: "abc\ndef"},
+		{name: "too long", clientID: strings.Repeat("a", maxRequestIDLen+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.clientID != "" {
+				r.Header.Set(problem.RequestIDHeader, tt.clientID)
+			}
+
+			handler.ServeHTTP(w, r)
+
+			require.NotEmpty(t, gotID)
+			require.Equal(t, gotID, w.Header().Get(problem.RequestIDHeader))
+			if tt.keep {
+				require.Equal(t, tt.clientID, gotID)
+			} else {
+				require.NotEqual(t, tt.clientID, gotID)
+			}
+		})
+	}
+}
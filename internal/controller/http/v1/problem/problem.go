@@ -0,0 +1,150 @@
+// Package problem writes error responses as RFC 7807 problem details with a
+// stable machine-readable code, the ID of the request and, for invalid
+// input, the errors of each field.
+package problem
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+const (
+	ContentType = "application/problem+json"
+	// RequestIDHeader carries the ID of the request. It is set on the
+	// response before the handler runs, so that the problems can include it.
+	RequestIDHeader = "X-Request-Id"
+)
+
+// Code identifies a kind of problem. Codes are part of the API: clients may
+// rely on them, so they don't change once published.
+type Code string
+
+const (
+	CodeInternal         Code = "internal"
+	CodeInvalidRequest   Code = "invalid_request"
+	CodeRequestTooLarge  Code = "request_too_large"
+	CodeValidationFailed Code = "validation_failed"
+	CodeNotFound         Code = "not_found"
+	CodeAlreadyExists    Code = "already_exists"
+	CodeCategoryNotFound Code = "category_not_found"
+	CodeCategoryCycle    Code = "category_cycle"
+	CodeUnauthorized     Code = "unauthorized"
+	CodeSessionExpired   Code = "session_expired"
+	CodeForbidden        Code = "forbidden"
+	CodeLastAdmin        Code = "last_admin"
+	CodeTooManyAttempts  Code = "too_many_attempts"
+	CodeWeakPassword     Code = "weak_password"
+)
+
+type mapping struct {
+	code   Code
+	status int
+}
+
+// mappings is the one place domain errors are given their HTTP status.
+// Errors without a mapping are internal.
+var mappings = map[errors.ErrorCode]mapping{
+	errors.ErrDB:               {CodeInternal, http.StatusInternalServerError},
+	errors.ErrNoDataFound:      {CodeNotFound, http.StatusNotFound},
+	errors.ErrAlreadyExists:    {CodeAlreadyExists, http.StatusConflict},
+	errors.ErrCategoryNotFound: {CodeCategoryNotFound, http.StatusBadRequest},
+	errors.ErrCategoryCycle:    {CodeCategoryCycle, http.StatusBadRequest},
+	errors.ErrUnauthorized:     {CodeUnauthorized, http.StatusUnauthorized},
+	errors.ErrSessionExpired:   {CodeSessionExpired, http.StatusUnauthorized},
+	errors.ErrForbidden:        {CodeForbidden, http.StatusForbidden},
+	errors.ErrLastAdmin:        {CodeLastAdmin, http.StatusConflict},
+	errors.ErrTooManyAttempts:  {CodeTooManyAttempts, http.StatusTooManyRequests},
+	errors.ErrWeakPassword:     {CodeWeakPassword, http.StatusBadRequest},
+}
+
+// FieldError tells what is wrong with a field of the request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      Code         `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// Status returns the HTTP status err is answered with.
+func Status(err error) int {
+	if m, ok := mappings[errors.Code(err)]; ok {
+		return m.status
+	}
+	return http.StatusInternalServerError
+}
+
+// Error answers with the problem err maps to. The details of internal
+// errors are logged, not sent.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	m, ok := mappings[errors.Code(err)]
+	if !ok {
+		m = mapping{CodeInternal, http.StatusInternalServerError}
+	}
+
+	detail := errors.Message(err)
+	if m.status >= http.StatusInternalServerError {
+		slog.Error("internal error", "error", err, "request_id", w.Header().Get(RequestIDHeader))
+		detail = ""
+	} else if detail == "" {
+		detail = string(errors.Code(err))
+	}
+
+	Write(w, r, m.status, m.code, detail)
+}
+
+// BadRequest answers that the request couldn't be read, e.g. that its body
+// isn't valid JSON.
+func BadRequest(w http.ResponseWriter, r *http.Request, detail string) {
+	Write(w, r, http.StatusBadRequest, CodeInvalidRequest, detail)
+}
+
+// Invalid answers that fields of the request are invalid.
+func Invalid(w http.ResponseWriter, r *http.Request, fields ...FieldError) {
+	writeProblem(w, r, Problem{
+		Status: http.StatusBadRequest,
+		Code:   CodeValidationFailed,
+		Detail: "request has invalid fields",
+		Errors: fields,
+	})
+}
+
+func Write(w http.ResponseWriter, r *http.Request, status int, code Code, detail string) {
+	writeProblem(w, r, Problem{
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	})
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, p Problem) {
+	p.Type = "about:blank"
+	p.Title = http.StatusText(p.Status)
+	p.Instance = r.URL.Path
+	p.RequestID = w.Header().Get(RequestIDHeader)
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		slog.Error("error marshaling problem", "error", err)
+		body = []byte(`{"type":"about:blank","status":500,"code":"internal"}`)
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_, err = w.Write(body)
+	if err != nil {
+		slog.Error("error writing problem", "error", err)
+	}
+}
@@ -0,0 +1,102 @@
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   Code
+		wantDetail string
+	}{
+		{
+			name:       "not found",
+			err:        errors.NewDomainError(errors.ErrNoDataFound, "product 3 not found"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeNotFound,
+			wantDetail: "product 3 not found",
+		},
+		{
+			name:       "no message",
+			err:        errors.NewDomainError(errors.ErrAlreadyExists, ""),
+			wantStatus: http.StatusConflict,
+			wantCode:   CodeAlreadyExists,
+			wantDetail: string(errors.ErrAlreadyExists),
+		},
+		{
+			name:       "weak password",
+			err:        errors.NewDomainError(errors.ErrWeakPassword, "password is too short"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeWeakPassword,
+			wantDetail: "password is too short",
+		},
+		{
+			name:       "db error detail is hidden",
+			err:        errors.NewDomainError(errors.ErrDB, "connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   CodeInternal,
+		},
+		{
+			name:       "plain error",
+			err:        fmt.Errorf("something broke"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   CodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			w.Header().Set(RequestIDHeader, "req-1")
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/product/add", nil)
+
+			Error(w, r, tt.err)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, tt.wantStatus, Status(tt.err))
+			require.Equal(t, ContentType, w.Header().Get("Content-Type"))
+
+			var p Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+			require.Equal(t, Problem{
+				Type:      "about:blank",
+				Title:     http.StatusText(tt.wantStatus),
+				Status:    tt.wantStatus,
+				Detail:    tt.wantDetail,
+				Instance:  "/api/v1/product/add",
+				Code:      tt.wantCode,
+				RequestID: "req-1",
+			}, p)
+		})
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/login", nil)
+
+	Invalid(w, r,
+		FieldError{Field: "Login", Message: "should not be empty"},
+		FieldError{Field: "Password", Message: "should not be empty"},
+	)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var p Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	require.Equal(t, CodeValidationFailed, p.Code)
+	require.Empty(t, p.RequestID)
+	require.Equal(t, []FieldError{
+		{Field: "Login", Message: "should not be empty"},
+		{Field: "Password", Message: "should not be empty"},
+	}, p.Errors)
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// APIKeyPrefix starts every API key, which tells keys apart from session
+// tokens in an Authorization header.
+const APIKeyPrefix = "pck_"
+
+// APIKey is a long-lived credential of a machine client. Only the hash of the
+// key is stored; Prefix is the start of the key, kept to recognize it.
+type APIKey struct {
+	ID         int64        `json:"id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	Hash       string       `json:"-"`
+	Scopes     []Permission `json:"scopes"`
+	CreatedBy  int64        `json:"created_by,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+}
+
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && k.ExpiresAt.Before(time.Now())
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Can reports whether p is in the scopes of the key.
+func (k *APIKey) Can(p Permission) bool {
+	for _, s := range k.Scopes {
+		if s == p {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateAPIKeyDTO struct {
+	Name      string       `validate:"required,max=255,nocontrol" mod:"trim"`
+	Scopes    []Permission `validate:"min=1,dive,oneof=catalog:write sync:manage users:manage"`
+	ExpiresAt *time.Time   `validate:"omitempty,gt"`
+	CreatedBy int64        `json:"-"`
+}
+
+// NewAPIKey is a just created API key. Key is the plain key, shown only once.
+type NewAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -1,15 +1,65 @@
 package entity
 
 type Category struct {
-	ID   int64
-	Name string
+	ID       int64
+	ParentID *int64
+	Name     string
+}
+
+// CategoryNode is a category together with all of its descendants.
+type CategoryNode struct {
+	Category
+	Children []CategoryNode
 }
 
 type AddCategoryDTO struct {
-	Name string
+	Name     string `validate:"required,max=255,nocontrol" mod:"trim"`
+	ParentID *int64 `validate:"omitempty,gt=0"`
 }
 
 type UpdateCategoryNameDTO struct {
-	CategoryID int64
-	NewName    string
+	CategoryID int64  `validate:"required,gt=0"`
+	NewName    string `validate:"required,max=255,nocontrol" mod:"trim"`
+}
+
+// MoveCategoryDTO re-parents a category together with its subtree.
+// A nil NewParentID makes the category a root.
+type MoveCategoryDTO struct {
+	CategoryID  int64  `validate:"required,gt=0"`
+	NewParentID *int64 `validate:"omitempty,gt=0"`
+}
+
+// BuildCategoryTree nests a flat list of categories by ParentID. Categories
+// whose parent is not in the list become roots, so a subtree returned by
+// storage produces a single root.
+func BuildCategoryTree(categories []Category) []CategoryNode {
+	known := make(map[int64]bool, len(categories))
+	children := make(map[int64][]Category, len(categories))
+	for _, c := range categories {
+		known[c.ID] = true
+	}
+
+	roots := make([]Category, 0)
+	for _, c := range categories {
+		if c.ParentID == nil || !known[*c.ParentID] {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+
+	var build func(c Category) CategoryNode
+	build = func(c Category) CategoryNode {
+		node := CategoryNode{Category: c, Children: make([]CategoryNode, 0, len(children[c.ID]))}
+		for _, child := range children[c.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	tree := make([]CategoryNode, 0, len(roots))
+	for _, c := range roots {
+		tree = append(tree, build(c))
+	}
+	return tree
 }
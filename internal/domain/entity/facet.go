@@ -0,0 +1,72 @@
+package entity
+
+// PriceBucketBounds split prices into the buckets of the price facet. Bucket
+// 0 is below the first bound, bucket len(PriceBucketBounds) is above the last.
+var PriceBucketBounds = []float64{50, 100, 500, 1000}
+
+// FacetFilter is the set of facet values selected by the user. Values of the
+// same facet are alternatives, different facets must all match. Empty Text
+// matches every product.
+type FacetFilter struct {
+	Text         string
+	CategoryIDs  []int64
+	Brands       []string
+	PriceBuckets []int
+	Attributes   map[string][]string
+}
+
+type FacetQuery struct {
+	FacetFilter
+	Limit int
+	Sort  ProductSort
+	After *ProductCursor
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type CategoryFacetCount struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// PriceFacetCount is the number of products in [Min, Max). Max is nil for
+// the last, open-ended bucket.
+type PriceFacetCount struct {
+	Bucket int      `json:"bucket"`
+	Min    float64  `json:"min"`
+	Max    *float64 `json:"max"`
+	Count  int64    `json:"count"`
+}
+
+// Facets counts the products matching all selected filters except the ones
+// of the facet being counted, so that alternatives to the selected values
+// keep their counts.
+type Facets struct {
+	Categories []CategoryFacetCount    `json:"categories"`
+	Brands     []FacetCount            `json:"brands"`
+	Prices     []PriceFacetCount       `json:"prices"`
+	Attributes map[string][]FacetCount `json:"attributes"`
+}
+
+type FacetPage struct {
+	Products   []ProductCategoryListItem `json:"products"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+	Total      int64                     `json:"total"`
+	Facets     Facets                    `json:"facets"`
+}
+
+func NewPriceFacetCount(bucket int, count int64) PriceFacetCount {
+	f := PriceFacetCount{Bucket: bucket, Count: count}
+	if bucket > 0 {
+		f.Min = PriceBucketBounds[bucket-1]
+	}
+	if bucket < len(PriceBucketBounds) {
+		max := PriceBucketBounds[bucket]
+		f.Max = &max
+	}
+	return f
+}
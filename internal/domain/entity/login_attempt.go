@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginAttempts counts the recent failed logins under a key, a login or a
+// client address. Logins under the key are refused until LockedUntil.
+type LoginAttempts struct {
+	Key         string
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+func (a *LoginAttempts) IsLocked(now time.Time) bool {
+	return now.Before(a.LockedUntil)
+}
+
+// LockoutError tells when a throttled login may be tried again.
+type LockoutError struct {
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("login is locked until %s", e.Until.Format(time.RFC3339))
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// MFA is the TOTP enrollment of a user. It is pending until the user
+// confirms it with a first code.
+type MFA struct {
+	UserID  int64
+	Secret  []byte
+	Enabled bool
+	// LastStep is the time step of the last accepted code, so that no code
+	// is accepted twice.
+	LastStep int64
+}
+
+// MFAEnrollment is shown once, when the user enrolls. The recovery codes
+// are only stored hashed.
+type MFAEnrollment struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAChallenge is returned by a login of a user enrolled in MFA, in place
+// of the tokens. It is exchanged along with a code for the tokens.
+type MFAChallenge struct {
+	Challenge string    `json:"challenge"`
+	Expiry    time.Time `json:"expiry"`
+}
+
+// LoginResult is the outcome of a login: the tokens, or a challenge when a
+// second factor is needed.
+type LoginResult struct {
+	Tokens    TokenPair
+	Challenge *MFAChallenge
+}
+
+type MFALoginDTO struct {
+	Challenge string `validate:"required,max=1024"`
+	Code      string `validate:"required,max=32" mod:"trim"`
+}
+
+type MFACodeDTO struct {
+	Code string `validate:"required,max=32" mod:"trim"`
+}
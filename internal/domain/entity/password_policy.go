@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// bcryptMaxLen is the length after which bcrypt ignores the rest of a
+// password.
+const bcryptMaxLen = 72
+
+// commonPasswords are banned whatever the configured list is.
+var commonPasswords = []string{
+	"123456", "123456789", "12345678", "1234567890", "password", "password1",
+	"qwerty", "qwerty123", "qwertyuiop", "111111", "123123", "abc123",
+	"iloveyou", "admin", "welcome", "letmein", "monkey", "dragon",
+	"football", "baseball", "sunshine", "princess", "passw0rd", "000000",
+}
+
+type PasswordPolicy struct {
+	MinLength int
+	banned    map[string]struct{}
+}
+
+// NewPasswordPolicy returns a policy that requires minLength characters and
+// bans the common passwords and the passwords of banned, case-insensitively.
+func NewPasswordPolicy(minLength int, banned []string) PasswordPolicy {
+	p := PasswordPolicy{
+		MinLength: minLength,
+		banned:    make(map[string]struct{}, len(commonPasswords)+len(banned)),
+	}
+	for _, list := range [][]string{commonPasswords, banned} {
+		for _, b := range list {
+			if b = strings.TrimSpace(b); b != "" {
+				p.banned[strings.ToLower(b)] = struct{}{}
+			}
+		}
+	}
+	return p
+}
+
+// Check returns the reason the password of login is refused, or "" if it
+// meets the policy.
+func (p PasswordPolicy) Check(login, password string) string {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return "password is shorter than the minimum length"
+	}
+	if len(password) > bcryptMaxLen {
+		return "password is longer than 72 bytes"
+	}
+	if strings.EqualFold(password, login) {
+		return "password is the same as the login"
+	}
+	if _, ok := p.banned[strings.ToLower(password)]; ok {
+		return "password is too common"
+	}
+	return ""
+}
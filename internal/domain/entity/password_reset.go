@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// PasswordReset is a reset token as stored: only its hash is kept.
+type PasswordReset struct {
+	Hash      string
+	UserID    int64
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type ChangePasswordDTO struct {
+	OldPassword string `validate:"required,max=1024"`
+	NewPassword string `validate:"required,max=1024"`
+}
+
+type RequestPasswordResetDTO struct {
+	Login string `validate:"required,max=255,nocontrol" mod:"trim"`
+}
+
+type ResetPasswordDTO struct {
+	Token       string `validate:"required,max=1024"`
+	NewPassword string `validate:"required,max=1024"`
+}
+
+// Notification is a message to a user.
+type Notification struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -1,16 +1,54 @@
 package entity
 
+import "github.com/The-Gleb/product_catalog/pkg/validate"
+
 type Product struct {
 	ID       int64
 	Name     string
 	Category Category
+	ProductDetails
+}
+
+// ProductDetails holds the descriptive fields of a product. The JSON names are
+// those of the catalog API, upstream sources map their own schemas in their adapters.
+type ProductDetails struct {
+	Description        string            `json:"description"`
+	Price              float64           `json:"price" validate:"gte=0"`
+	DiscountPercentage float64           `json:"discountPercentage" validate:"gte=0,lte=100"`
+	Rating             float64           `json:"rating" validate:"gte=0,lte=5"`
+	Stock              int32             `json:"stock" validate:"gte=0"`
+	Brand              string            `json:"brand" validate:"max=255,nocontrol" mod:"trim"`
+	SKU                string            `json:"sku" validate:"max=64,nocontrol" mod:"trim"`
+	Thumbnail          string            `json:"thumbnail" validate:"max=2048,nocontrol" mod:"trim"`
+	Images             []string          `json:"images" validate:"dive,max=2048,nocontrol"`
+	Attributes         ProductAttributes `json:"attributes" validate:"dive,keys,required,endkeys,scalar"`
 }
 
-// type ProductView struct {
-// 	ID           int64
-// 	Name         string
-// 	CategoryName string
-// }
+// ProductAttributes is a free-form set of product properties not covered by
+// ProductDetails, e.g. color or weight. Values are strings, numbers or booleans.
+type ProductAttributes map[string]interface{}
+
+// Validate checks d against the rules of its validate tags. Details read
+// from upstream sources aren't decoded by the API, so they are checked here.
+func (d ProductDetails) Validate() error {
+	return validate.Struct(d)
+}
+
+// ProductView is a product with every category it belongs to.
+type ProductView struct {
+	ID         int64
+	Name       string
+	Categories []Category
+	ProductSourceRef
+	ProductDetails
+}
+
+// ProductSourceRef identifies the upstream record a product was imported from.
+// Both fields are empty for the products created through the API.
+type ProductSourceRef struct {
+	Source     string `json:"source,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+}
 
 type ProductCategoryListItem struct {
 	ID   int64
@@ -18,22 +56,30 @@ type ProductCategoryListItem struct {
 }
 
 type AddProductDTO struct {
-	ProductName string
-	CategoryID  int64
+	ProductName string `validate:"required,max=255,nocontrol" mod:"trim"`
+	CategoryID  int64  `validate:"required,gt=0"`
+	ProductDetails
 }
 
 type AddOrUpdateProductDTO struct {
-	ProductName  string `json:"title"`
-	CategoryName string `json:"category"`
+	ProductName  string
+	CategoryName string
+	ProductSourceRef
+	ProductDetails
 }
 
 type UpdateProductNameDTO struct {
-	ProductID int64
-	NewName   string
+	ProductID int64  `validate:"required,gt=0"`
+	NewName   string `validate:"required,max=255,nocontrol" mod:"trim"`
+}
+
+type UpdateProductDetailsDTO struct {
+	ProductID int64 `validate:"required,gt=0"`
+	ProductDetails
 }
 
 type UpdateProductCategoryDTO struct {
-	ProductID     int64
-	OldCategoryID int64
-	NewCategoryID int64
+	ProductID     int64 `validate:"required,gt=0"`
+	OldCategoryID int64 `validate:"required,gt=0"`
+	NewCategoryID int64 `validate:"required,gt=0"`
 }
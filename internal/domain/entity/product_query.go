@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+type ProductSort string
+
+const (
+	SortByID        ProductSort = "id"
+	SortByName      ProductSort = "name"
+	SortByNameDesc  ProductSort = "-name"
+	SortByRelevance ProductSort = "relevance"
+)
+
+const (
+	DefaultProductPageLimit = 50
+	MaxProductPageLimit     = 500
+)
+
+func (s ProductSort) Valid() bool {
+	switch s {
+	case SortByID, SortByName, SortByNameDesc, SortByRelevance:
+		return true
+	}
+	return false
+}
+
+// ProductQuery describes one page of a product listing. After is the
+// position of the last product of the previous page, nil for the first page.
+// IncludeDescendants extends the listing to every subcategory of CategoryID.
+type ProductQuery struct {
+	CategoryID         int64
+	IncludeDescendants bool
+	Limit              int
+	Sort               ProductSort
+	NamePrefix         string
+	After              *ProductCursor
+}
+
+type ProductPage struct {
+	Products   []ProductCategoryListItem `json:"products"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+}
+
+// ProductCursor is the keyset position of a product in a listing ordered by
+// Sort. ID breaks ties between products with equal sort keys.
+type ProductCursor struct {
+	Sort ProductSort `json:"s"`
+	ID   int64       `json:"i"`
+	Name string      `json:"n,omitempty"`
+	Rank float32     `json:"r,omitempty"`
+}
+
+func (c ProductCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeProductCursor(s string) (ProductCursor, error) {
+	var c ProductCursor
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ProductCursor{}, fmt.Errorf("malformed cursor: %w", err)
+	}
+	err = json.Unmarshal(b, &c)
+	if err != nil {
+		return ProductCursor{}, fmt.Errorf("malformed cursor: %w", err)
+	}
+	if !c.Sort.Valid() {
+		return ProductCursor{}, fmt.Errorf("malformed cursor: unknown sort %q", c.Sort)
+	}
+
+	return c, nil
+}
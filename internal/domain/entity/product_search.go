@@ -0,0 +1,23 @@
+package entity
+
+// ProductSearchQuery is a page of catalog search results for Text. A zero
+// CategoryID searches the whole catalog.
+type ProductSearchQuery struct {
+	Text string
+	ProductQuery
+}
+
+// ProductSearchHit is a matched product. Highlight holds the matched
+// fragments of the name and description, HTML-escaped, with the matches
+// wrapped in <b></b>.
+type ProductSearchHit struct {
+	ID        int64
+	Name      string
+	Rank      float32
+	Highlight string
+}
+
+type ProductSearchPage struct {
+	Products   []ProductSearchHit `json:"products"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,196 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DeleteMode tells what reconciliation does with the products that are gone
+// from the upstream snapshot.
+type DeleteMode string
+
+const (
+	// DeleteModeTombstone marks the products deleted and hides them from the
+	// catalog. A product that reappears upstream is restored.
+	DeleteModeTombstone DeleteMode = "tombstone"
+	DeleteModeDelete    DeleteMode = "delete"
+)
+
+func (m DeleteMode) Valid() bool {
+	return m == DeleteModeTombstone || m == DeleteModeDelete
+}
+
+// SourceProduct is a product the catalog holds from an upstream source.
+type SourceProduct struct {
+	ID         int64
+	ExternalID string
+	Name       string
+	Categories []string
+	Deleted    bool
+}
+
+type ReconciledProduct struct {
+	ExternalID string `json:"external_id"`
+	Name       string `json:"name"`
+}
+
+type ProductRename struct {
+	ExternalID string `json:"external_id"`
+	OldName    string `json:"old_name"`
+	NewName    string `json:"new_name"`
+}
+
+type ProductMove struct {
+	ExternalID    string   `json:"external_id"`
+	Name          string   `json:"name"`
+	OldCategories []string `json:"old_categories"`
+	NewCategory   string   `json:"new_category"`
+}
+
+type ProductConflict struct {
+	ExternalID string `json:"external_id"`
+	Name       string `json:"name"`
+	Reason     string `json:"reason"`
+}
+
+// ReconcileReport is the diff applied by one reconciliation run of a source.
+type ReconcileReport struct {
+	ID         int64               `json:"id,omitempty"`
+	Source     string              `json:"source"`
+	Mode       DeleteMode          `json:"mode"`
+	StartedAt  time.Time           `json:"started_at"`
+	FinishedAt time.Time           `json:"finished_at"`
+	Added      []ReconciledProduct `json:"added"`
+	Renamed    []ProductRename     `json:"renamed"`
+	Moved      []ProductMove       `json:"moved"`
+	Restored   []ReconciledProduct `json:"restored"`
+	Deleted    []ReconciledProduct `json:"deleted"`
+	Conflicts  []ProductConflict   `json:"conflicts"`
+	Unchanged  int                 `json:"unchanged"`
+}
+
+// ReconcilePlan is what a reconciliation run writes: every snapshot product is
+// upserted, the products missing from the snapshot are deleted.
+type ReconcilePlan struct {
+	Source   string
+	Mode     DeleteMode
+	Upserts  []AddOrUpdateProductDTO
+	Deletes  []string
+	Snapshot int
+}
+
+// DiffSnapshot compares a full upstream snapshot of a source with the products
+// the catalog holds from it. Snapshot records are matched by external ID, the
+// last one wins if an ID repeats. Category names are compared ignoring case,
+// as they are unique regardless of case.
+func DiffSnapshot(source string, mode DeleteMode, current []SourceProduct, snapshot []AddOrUpdateProductDTO) (ReconcilePlan, ReconcileReport) {
+	plan := ReconcilePlan{Source: source, Mode: mode, Snapshot: len(snapshot)}
+	report := ReconcileReport{Source: source, Mode: mode}
+
+	upstream := make(map[string]AddOrUpdateProductDTO, len(snapshot))
+	for _, p := range snapshot {
+		if _, ok := upstream[p.ExternalID]; !ok {
+			plan.Upserts = append(plan.Upserts, p)
+		}
+		upstream[p.ExternalID] = p
+	}
+	for i, p := range plan.Upserts {
+		plan.Upserts[i] = upstream[p.ExternalID]
+	}
+
+	held := make(map[string]SourceProduct, len(current))
+	for _, p := range current {
+		held[p.ExternalID] = p
+	}
+
+	for _, p := range plan.Upserts {
+		cur, ok := held[p.ExternalID]
+		switch {
+		case !ok:
+			report.Added = append(report.Added, ReconciledProduct{ExternalID: p.ExternalID, Name: p.ProductName})
+			continue
+		case cur.Deleted:
+			report.Restored = append(report.Restored, ReconciledProduct{ExternalID: p.ExternalID, Name: p.ProductName})
+			continue
+		}
+
+		changed := false
+		if cur.Name != p.ProductName {
+			report.Renamed = append(report.Renamed, ProductRename{
+				ExternalID: p.ExternalID,
+				OldName:    cur.Name,
+				NewName:    p.ProductName,
+			})
+			changed = true
+		}
+		if len(cur.Categories) != 1 || !strings.EqualFold(cur.Categories[0], p.CategoryName) {
+			report.Moved = append(report.Moved, ProductMove{
+				ExternalID:    p.ExternalID,
+				Name:          p.ProductName,
+				OldCategories: cur.Categories,
+				NewCategory:   p.CategoryName,
+			})
+			changed = true
+		}
+		if !changed {
+			report.Unchanged++
+		}
+	}
+
+	for _, p := range current {
+		if _, ok := upstream[p.ExternalID]; ok || p.Deleted {
+			continue
+		}
+		plan.Deletes = append(plan.Deletes, p.ExternalID)
+		report.Deleted = append(report.Deleted, ReconciledProduct{ExternalID: p.ExternalID, Name: p.Name})
+	}
+	sort.Strings(plan.Deletes)
+	sort.Slice(report.Deleted, func(i, j int) bool {
+		return report.Deleted[i].ExternalID < report.Deleted[j].ExternalID
+	})
+
+	return plan, report
+}
+
+// DropConflicts removes the products that couldn't be written from the
+// change lists of the report and records them as conflicts.
+func (r *ReconcileReport) DropConflicts(conflicts []ProductConflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+	failed := make(map[string]bool, len(conflicts))
+	for _, c := range conflicts {
+		failed[c.ExternalID] = true
+	}
+
+	keep := func(list []ReconciledProduct) []ReconciledProduct {
+		res := list[:0]
+		for _, p := range list {
+			if !failed[p.ExternalID] {
+				res = append(res, p)
+			}
+		}
+		return res
+	}
+	r.Added = keep(r.Added)
+	r.Restored = keep(r.Restored)
+
+	renamed := r.Renamed[:0]
+	for _, p := range r.Renamed {
+		if !failed[p.ExternalID] {
+			renamed = append(renamed, p)
+		}
+	}
+	r.Renamed = renamed
+
+	moved := r.Moved[:0]
+	for _, p := range r.Moved {
+		if !failed[p.ExternalID] {
+			moved = append(moved, p)
+		}
+	}
+	r.Moved = moved
+
+	r.Conflicts = append(r.Conflicts, conflicts...)
+}
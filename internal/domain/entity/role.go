@@ -0,0 +1,54 @@
+package entity
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permission is an action a role may be allowed to do. Reading the catalog
+// needs no permission.
+type Permission string
+
+const (
+	PermissionCatalogWrite Permission = "catalog:write"
+	PermissionSyncManage   Permission = "sync:manage"
+	PermissionUsersManage  Permission = "users:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleViewer: {},
+	RoleEditor: {PermissionCatalogWrite},
+	RoleAdmin:  {PermissionCatalogWrite, PermissionSyncManage, PermissionUsersManage},
+}
+
+func (r Role) Can(p Permission) bool {
+	for _, rp := range rolePermissions[r] {
+		if rp == p {
+			return true
+		}
+	}
+	return false
+}
+
+type SetRoleDTO struct {
+	Login string `validate:"required,max=255,nocontrol" mod:"trim"`
+	Role  Role   `validate:"required,oneof=viewer editor admin"`
+}
+
+// Valid reports whether p is a known permission.
+func (p Permission) Valid() bool {
+	for _, rp := range rolePermissions[RoleAdmin] {
+		if rp == p {
+			return true
+		}
+	}
+	return false
+}
@@ -3,12 +3,42 @@ package entity
 import "time"
 
 type Session struct {
-	ID     int64
-	Token  string
-	UserID int64
-	Expiry time.Time
+	ID         int64
+	Token      string
+	UserID     int64
+	Expiry     time.Time
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ClientInfo
 }
 
 func (s *Session) IsExpired() bool {
 	return s.Expiry.Before(time.Now())
 }
+
+// ClientInfo describes the client a session was opened from.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// SessionView is a session as shown to its owner. It never carries the token.
+type SessionView struct {
+	ID         int64     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Expiry     time.Time `json:"expiry"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	Current    bool      `json:"current"`
+}
+
+// TokenPair is issued on login and on every refresh. The access token is
+// checked without the database; the refresh token is bound to a session and
+// valid for one use.
+type TokenPair struct {
+	AccessToken   string    `json:"access_token"`
+	AccessExpiry  time.Time `json:"access_expiry"`
+	RefreshToken  string    `json:"refresh_token"`
+	RefreshExpiry time.Time `json:"refresh_expiry"`
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+// SyncState is the checkpoint of the import from an upstream product source.
+// Offset is the number of upstream products already imported, Total is the
+// size of the upstream feed as of the last fetched page.
+type SyncState struct {
+	Source    string
+	Offset    int
+	Total     int
+	UpdatedAt time.Time
+}
+
+// ProductFeedPage is one page of an upstream product feed. Fetched is the
+// number of upstream records in the page, including the ones the adapter
+// skipped as invalid, so that the sync offset stays in step with the feed.
+type ProductFeedPage struct {
+	Products []AddOrUpdateProductDTO
+	Fetched  int
+	Total    int
+}
+
+type HealthStatus string
+
+const (
+	HealthOK       HealthStatus = "ok"
+	HealthDegraded HealthStatus = "degraded"
+)
+
+// SyncHealth describes the state of the upstream product synchronisation.
+type SyncHealth struct {
+	Status              HealthStatus      `json:"status"`
+	Breakers            map[string]string `json:"breakers,omitempty"`
+	ConsecutiveFailures int               `json:"consecutive_failures"`
+	LastError           string            `json:"last_error,omitempty"`
+	LastSuccess         *time.Time        `json:"last_success,omitempty"`
+	LastFailure         *time.Time        `json:"last_failure,omitempty"`
+}
+
+type Health struct {
+	Status HealthStatus `json:"status"`
+	Sync   SyncHealth   `json:"sync"`
+}
@@ -4,9 +4,16 @@ type User struct {
 	ID       int64
 	Login    string
 	Password string
+	Role     Role
+	// Email is where notifications such as password resets are sent. It is
+	// optional.
+	Email string
 }
 
+// Credentials are sent to log in and to register. Email is only read on
+// registration.
 type Credentials struct {
-	Login    string
-	Password string
+	Login    string `validate:"required,max=255,nocontrol" mod:"trim"`
+	Password string `validate:"required,max=1024"`
+	Email    string `validate:"omitempty,max=320,email" mod:"trim"`
 }
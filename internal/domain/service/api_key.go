@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ usecase.APIKeyService = new(apiKeyService)
+
+type APIKeyStorage interface {
+	Create(ctx context.Context, key entity.APIKey) (entity.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (entity.APIKey, error)
+	GetAll(ctx context.Context) ([]entity.APIKey, error)
+	Revoke(ctx context.Context, ID int64, revokedAt time.Time) error
+	Touch(ctx context.Context, ID int64, lastUsedAt time.Time) error
+}
+
+const (
+	// apiKeySecretLen is the number of random bytes in a key.
+	apiKeySecretLen = 32
+	// apiKeyShownLen is the length of the key start kept to recognize it.
+	apiKeyShownLen = len(entity.APIKeyPrefix) + 8
+	// apiKeyTouchInterval limits how often the last use of a key is written,
+	// so that authenticated requests don't each cost an UPDATE.
+	apiKeyTouchInterval = time.Minute
+)
+
+type apiKeyService struct {
+	storage APIKeyStorage
+}
+
+func NewAPIKeyService(s APIKeyStorage) *apiKeyService {
+	return &apiKeyService{storage: s}
+}
+
+// hashAPIKey hashes a key for storage. Keys are random, so a fast hash is
+// enough to make a leaked table useless.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func newAPIKeySecret() (string, error) {
+	b := make([]byte, apiKeySecretLen)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return entity.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (ks *apiKeyService) Create(ctx context.Context, dto entity.CreateAPIKeyDTO) (entity.NewAPIKey, error) {
+	for {
+		secret, err := newAPIKeySecret()
+		if err != nil {
+			return entity.NewAPIKey{}, err
+		}
+
+		key, err := ks.storage.Create(ctx, entity.APIKey{
+			Name:      dto.Name,
+			Prefix:    secret[:apiKeyShownLen],
+			Hash:      hashAPIKey(secret),
+			Scopes:    dto.Scopes,
+			CreatedBy: dto.CreatedBy,
+			CreatedAt: time.Now(),
+			ExpiresAt: dto.ExpiresAt,
+		})
+		if errors.Code(err) == errors.ErrAlreadyExists {
+			continue
+		}
+		if err != nil {
+			return entity.NewAPIKey{}, err
+		}
+
+		return entity.NewAPIKey{APIKey: key, Key: secret}, nil
+	}
+}
+
+// Authenticate returns the API key matching key. Unknown, revoked and
+// expired keys are unauthorized.
+func (ks *apiKeyService) Authenticate(ctx context.Context, key string) (entity.APIKey, error) {
+	if !strings.HasPrefix(key, entity.APIKeyPrefix) {
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrUnauthorized, "not an api key")
+	}
+
+	apiKey, err := ks.storage.GetByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return entity.APIKey{}, errors.NewDomainError(errors.ErrUnauthorized, "unknown api key")
+		}
+		return entity.APIKey{}, err
+	}
+	if apiKey.IsRevoked() {
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrUnauthorized, "api key %d is revoked", apiKey.ID)
+	}
+	if apiKey.IsExpired() {
+		return entity.APIKey{}, errors.NewDomainError(errors.ErrUnauthorized, "api key %d is expired", apiKey.ID)
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		err = ks.storage.Touch(ctx, apiKey.ID, now)
+		if err != nil {
+			return entity.APIKey{}, err
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return apiKey, nil
+}
+
+func (ks *apiKeyService) GetAll(ctx context.Context) ([]entity.APIKey, error) {
+	return ks.storage.GetAll(ctx)
+}
+
+func (ks *apiKeyService) Revoke(ctx context.Context, ID int64) error {
+	return ks.storage.Revoke(ctx, ID, time.Now())
+}
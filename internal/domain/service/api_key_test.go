@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_apiKeyService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockAPIKeyStorage(ctrl)
+	s := NewAPIKeyService(storage)
+
+	var stored entity.APIKey
+	gomock.InOrder(
+		storage.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(entity.APIKey{}, errors.NewDomainError(errors.ErrAlreadyExists, "")),
+		storage.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, key entity.APIKey) (entity.APIKey, error) {
+				stored = key
+				key.ID = 1
+				return key, nil
+			}),
+	)
+
+	key, err := s.Create(context.Background(), entity.CreateAPIKeyDTO{
+		Name:   "importer",
+		Scopes: []entity.Permission{entity.PermissionCatalogWrite},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), key.ID)
+	require.True(t, strings.HasPrefix(key.Key, entity.APIKeyPrefix))
+	require.True(t, strings.HasPrefix(key.Key, stored.Prefix))
+	require.Equal(t, hashAPIKey(key.Key), stored.Hash)
+	require.NotContains(t, stored.Hash, key.Key)
+}
+
+func Test_apiKeyService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockAPIKeyStorage(ctrl)
+	s := NewAPIKeyService(storage)
+
+	const key = "pck_secret"
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	recently := time.Now()
+
+	tests := []struct {
+		name      string
+		key       string
+		wantErr   bool
+		errorCode errors.ErrorCode
+		prepare   func()
+	}{
+		{
+			name: "valid key",
+			key:  key,
+			prepare: func() {
+				storage.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).
+					Return(entity.APIKey{ID: 1, ExpiresAt: &future}, nil)
+				storage.EXPECT().Touch(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "recently used key isn't touched",
+			key:  key,
+			prepare: func() {
+				storage.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).
+					Return(entity.APIKey{ID: 1, LastUsedAt: &recently}, nil)
+			},
+		},
+		{
+			name:      "not an api key",
+			key:       "123",
+			wantErr:   true,
+			errorCode: errors.ErrUnauthorized,
+			prepare:   func() {},
+		},
+		{
+			name:      "unknown key",
+			key:       key,
+			wantErr:   true,
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).
+					Return(entity.APIKey{}, errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:      "revoked key",
+			key:       key,
+			wantErr:   true,
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).
+					Return(entity.APIKey{ID: 1, RevokedAt: &past}, nil)
+			},
+		},
+		{
+			name:      "expired key",
+			key:       key,
+			wantErr:   true,
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).
+					Return(entity.APIKey{ID: 1, ExpiresAt: &past}, nil)
+			},
+		},
+		{
+			name:      "db error",
+			key:       key,
+			wantErr:   true,
+			errorCode: errors.ErrDB,
+			prepare: func() {
+				storage.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).
+					Return(entity.APIKey{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			got, err := s.Authenticate(context.Background(), tt.key)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(1), got.ID)
+		})
+	}
+}
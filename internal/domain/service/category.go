@@ -12,6 +12,9 @@ var _ usecase.CategoryService = new(categoryService)
 type CategoryStorage interface {
 	Add(ctx context.Context, Category entity.AddCategoryDTO) error
 	GetAll(ctx context.Context) ([]entity.Category, error)
+	GetSubtree(ctx context.Context, ID int64) ([]entity.Category, error)
+	GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error)
+	Move(ctx context.Context, category entity.MoveCategoryDTO) error
 	UpdateName(ctx context.Context, category entity.UpdateCategoryNameDTO) error
 	Delete(ctx context.Context, ID int64) error
 }
@@ -32,6 +35,30 @@ func (s *categoryService) GetAll(ctx context.Context) ([]entity.Category, error)
 	return s.storage.GetAll(ctx)
 }
 
+func (s *categoryService) GetTree(ctx context.Context) ([]entity.CategoryNode, error) {
+	categories, err := s.storage.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entity.BuildCategoryTree(categories), nil
+}
+
+func (s *categoryService) GetSubtree(ctx context.Context, ID int64) (entity.CategoryNode, error) {
+	categories, err := s.storage.GetSubtree(ctx, ID)
+	if err != nil {
+		return entity.CategoryNode{}, err
+	}
+	return entity.BuildCategoryTree(categories)[0], nil
+}
+
+func (s *categoryService) GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error) {
+	return s.storage.GetAncestors(ctx, ID)
+}
+
+func (s *categoryService) Move(ctx context.Context, category entity.MoveCategoryDTO) error {
+	return s.storage.Move(ctx, category)
+}
+
 func (s *categoryService) UpdateName(ctx context.Context, category entity.UpdateCategoryNameDTO) error {
 	return s.storage.UpdateName(ctx, category)
 }
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ usecase.LoginThrottleService = new(loginThrottleService)
+
+// LoginAttemptStorage keeps the failed login counters. It is shared by the
+// replicas of the catalog unless it is in memory.
+type LoginAttemptStorage interface {
+	Get(ctx context.Context, key string) (entity.LoginAttempts, error)
+	// RecordFailure counts a failure at now, starting over if the last one
+	// was more than window ago, and returns the updated counter.
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (entity.LoginAttempts, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+// LoginThrottleConfig sets how failed logins are throttled. After the n-th
+// failure in Window, the login is refused for Delay doubled n-1 times, up to
+// MaxDelay; from MaxFailures on it is locked out for Lockout. Client addresses
+// are throttled the same way from MaxIPFailures on; 0 turns it off.
+type LoginThrottleConfig struct {
+	MaxFailures   int
+	MaxIPFailures int
+	Window        time.Duration
+	Delay         time.Duration
+	MaxDelay      time.Duration
+	Lockout       time.Duration
+}
+
+type loginThrottleService struct {
+	storage LoginAttemptStorage
+	cfg     LoginThrottleConfig
+}
+
+func NewLoginThrottleService(s LoginAttemptStorage, cfg LoginThrottleConfig) *loginThrottleService {
+	return &loginThrottleService{storage: s, cfg: cfg}
+}
+
+func loginKey(login string) string {
+	return "login:" + strings.ToLower(login)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func (ts *loginThrottleService) keys(login, ip string) []string {
+	keys := []string{loginKey(login)}
+	if ts.cfg.MaxIPFailures > 0 && ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+	return keys
+}
+
+// Check refuses a login attempt while the login or the client address is
+// locked, with an error wrapping an *entity.LockoutError.
+func (ts *loginThrottleService) Check(ctx context.Context, login, ip string) error {
+	now := time.Now()
+	for _, key := range ts.keys(login, ip) {
+		attempts, err := ts.storage.Get(ctx, key)
+		if err != nil {
+			if errors.Code(err) == errors.ErrNoDataFound {
+				continue
+			}
+			return err
+		}
+		if attempts.IsLocked(now) {
+			return errors.WrapIntoDomainError(
+				&entity.LockoutError{Until: attempts.LockedUntil},
+				errors.ErrTooManyAttempts, "login throttled",
+			)
+		}
+	}
+	return nil
+}
+
+// Failure counts a failed login against the login and the client address and
+// locks them for the delay they earned.
+func (ts *loginThrottleService) Failure(ctx context.Context, login, ip string) error {
+	now := time.Now()
+	for _, key := range ts.keys(login, ip) {
+		attempts, err := ts.storage.RecordFailure(ctx, key, now, ts.cfg.Window)
+		if err != nil {
+			return err
+		}
+
+		maxFailures := ts.cfg.MaxFailures
+		if strings.HasPrefix(key, "ip:") {
+			maxFailures = ts.cfg.MaxIPFailures
+		}
+
+		until := now.Add(ts.delay(attempts.Failures, maxFailures))
+		if attempts.Failures >= maxFailures {
+			slog.Warn("login locked out", "key", key, "failures", attempts.Failures, "until", until)
+		}
+		err = ts.storage.Lock(ctx, key, until)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Success resets the counter of the login. The counter of the client address
+// is left to expire, so that an attacker can't clear it by logging in to an
+// account of their own between guesses.
+func (ts *loginThrottleService) Success(ctx context.Context, login string) error {
+	return ts.storage.Reset(ctx, loginKey(login))
+}
+
+func (ts *loginThrottleService) delay(failures, maxFailures int) time.Duration {
+	if failures >= maxFailures {
+		return ts.cfg.Lockout
+	}
+
+	d := ts.cfg.Delay
+	for i := 1; i < failures && d < ts.cfg.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > ts.cfg.MaxDelay {
+		d = ts.cfg.MaxDelay
+	}
+	return d
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var testThrottleConfig = LoginThrottleConfig{
+	MaxFailures:   5,
+	MaxIPFailures: 50,
+	Window:        15 * time.Minute,
+	Delay:         time.Second,
+	MaxDelay:      10 * time.Second,
+	Lockout:       15 * time.Minute,
+}
+
+func Test_loginThrottleService_delay(t *testing.T) {
+	ts := NewLoginThrottleService(nil, testThrottleConfig)
+
+	require.Equal(t, time.Second, ts.delay(1, 5))
+	require.Equal(t, 2*time.Second, ts.delay(2, 5))
+	require.Equal(t, 8*time.Second, ts.delay(4, 5))
+	require.Equal(t, 15*time.Minute, ts.delay(5, 5))
+	require.Equal(t, 10*time.Second, ts.delay(10, 50))
+}
+
+func Test_loginThrottleService_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockLoginAttemptStorage(ctrl)
+	ts := NewLoginThrottleService(storage, testThrottleConfig)
+
+	notFound := errors.NewDomainError(errors.ErrNoDataFound, "")
+	lockedUntil := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		errorCode errors.ErrorCode
+		prepare   func()
+	}{
+		{
+			name: "positive, no failures",
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), "login:login1").Return(entity.LoginAttempts{}, notFound)
+				storage.EXPECT().Get(gomock.Any(), "ip:10.0.0.1").Return(entity.LoginAttempts{}, notFound)
+			},
+		},
+		{
+			name: "positive, lock is over",
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), "login:login1").Return(entity.LoginAttempts{
+					Failures:    3,
+					LockedUntil: time.Now().Add(-time.Second),
+				}, nil)
+				storage.EXPECT().Get(gomock.Any(), "ip:10.0.0.1").Return(entity.LoginAttempts{}, notFound)
+			},
+		},
+		{
+			name:      "negative, login locked",
+			errorCode: errors.ErrTooManyAttempts,
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), "login:login1").Return(entity.LoginAttempts{
+					Failures:    5,
+					LockedUntil: lockedUntil,
+				}, nil)
+			},
+		},
+		{
+			name:      "negative, address locked",
+			errorCode: errors.ErrTooManyAttempts,
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), "login:login1").Return(entity.LoginAttempts{}, notFound)
+				storage.EXPECT().Get(gomock.Any(), "ip:10.0.0.1").Return(entity.LoginAttempts{
+					Failures:    50,
+					LockedUntil: lockedUntil,
+				}, nil)
+			},
+		},
+		{
+			name:      "negative, db error",
+			errorCode: errors.ErrDB,
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), "login:login1").
+					Return(entity.LoginAttempts{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			err := ts.Check(context.Background(), "Login1", "10.0.0.1")
+			require.Equal(t, tt.errorCode, errors.Code(err))
+			if tt.errorCode != errors.ErrTooManyAttempts {
+				return
+			}
+
+			var lockout *entity.LockoutError
+			require.True(t, stdErrors.As(errors.Unwrap(err), &lockout))
+			require.Equal(t, lockedUntil, lockout.Until)
+		})
+	}
+}
+
+func Test_loginThrottleService_Failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockLoginAttemptStorage(ctrl)
+	ts := NewLoginThrottleService(storage, testThrottleConfig)
+
+	storage.EXPECT().RecordFailure(gomock.Any(), "login:login1", gomock.Any(), 15*time.Minute).
+		Return(entity.LoginAttempts{Key: "login:login1", Failures: 5}, nil)
+	storage.EXPECT().Lock(gomock.Any(), "login:login1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, until time.Time) error {
+			require.WithinDuration(t, time.Now().Add(15*time.Minute), until, time.Second)
+			return nil
+		})
+	storage.EXPECT().RecordFailure(gomock.Any(), "ip:10.0.0.1", gomock.Any(), 15*time.Minute).
+		Return(entity.LoginAttempts{Key: "ip:10.0.0.1", Failures: 2}, nil)
+	storage.EXPECT().Lock(gomock.Any(), "ip:10.0.0.1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, until time.Time) error {
+			require.WithinDuration(t, time.Now().Add(2*time.Second), until, time.Second)
+			return nil
+		})
+
+	require.NoError(t, ts.Failure(context.Background(), "login1", "10.0.0.1"))
+}
+
+func Test_loginThrottleService_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockLoginAttemptStorage(ctrl)
+	ts := NewLoginThrottleService(storage, testThrottleConfig)
+
+	// Only the login is reset, the counter of the address is left as is.
+	storage.EXPECT().Reset(gomock.Any(), "login:login1").Return(nil)
+
+	require.NoError(t, ts.Success(context.Background(), "LOGIN1"))
+}
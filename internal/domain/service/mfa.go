@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	stdErrors "errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/token"
+	"github.com/The-Gleb/product_catalog/pkg/totp"
+	"github.com/google/uuid"
+)
+
+var _ usecase.MFAService = new(mfaService)
+
+type MFAStorage interface {
+	Get(ctx context.Context, userID int64) (entity.MFA, error)
+	// Save replaces a pending enrollment of the user, along with its
+	// recovery codes.
+	Save(ctx context.Context, mfa entity.MFA, recoveryCodes []string) error
+	Enable(ctx context.Context, userID int64) error
+	Delete(ctx context.Context, userID int64) error
+	// UseStep records that the code of step was accepted. It fails with
+	// ErrNoDataFound if a code of that step or a later one already was.
+	UseStep(ctx context.Context, userID, step int64) error
+	// UseRecoveryCode marks an unused recovery code used. It fails with
+	// ErrNoDataFound if there is no such unused code.
+	UseRecoveryCode(ctx context.Context, userID int64, hash string, usedAt time.Time) error
+}
+
+const (
+	tokenUseMFA = "mfa"
+
+	recoveryCodeCount = 10
+	// mfaSkew is the number of steps a code is accepted before or after its
+	// own, for clock drift.
+	mfaSkew = 1
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAConfig sets the issuer shown in authenticator apps and how long a
+// login challenge may be answered.
+type MFAConfig struct {
+	Issuer       string
+	ChallengeTTL time.Duration
+}
+
+type mfaService struct {
+	storage MFAStorage
+	signer  *token.Signer
+	cfg     MFAConfig
+}
+
+func NewMFAService(s MFAStorage, signer *token.Signer, cfg MFAConfig) *mfaService {
+	return &mfaService{storage: s, signer: signer, cfg: cfg}
+}
+
+// hashRecoveryCode hashes a recovery code for storage, ignoring the case
+// and the separators it is shown with.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// Enroll starts the enrollment of the user with a new secret and recovery
+// codes. It replaces a pending enrollment, but not a confirmed one.
+func (ms *mfaService) Enroll(ctx context.Context, user entity.User) (entity.MFAEnrollment, error) {
+	mfa, err := ms.storage.Get(ctx, user.ID)
+	if err != nil && errors.Code(err) != errors.ErrNoDataFound {
+		return entity.MFAEnrollment{}, err
+	}
+	if mfa.Enabled {
+		return entity.MFAEnrollment{}, errors.NewDomainError(errors.ErrAlreadyExists, "two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return entity.MFAEnrollment{}, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i], err = newRecoveryCode()
+		if err != nil {
+			return entity.MFAEnrollment{}, err
+		}
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	err = ms.storage.Save(ctx, entity.MFA{UserID: user.ID, Secret: secret}, hashes)
+	if err != nil {
+		return entity.MFAEnrollment{}, err
+	}
+
+	return entity.MFAEnrollment{
+		Secret:        totp.Encoding.EncodeToString(secret),
+		URI:           totp.URI(ms.cfg.Issuer, user.Login, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// Confirm enables a pending enrollment once the user proves, with a code,
+// that their authenticator has the secret.
+func (ms *mfaService) Confirm(ctx context.Context, userID int64, code string) error {
+	mfa, err := ms.storage.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa.Enabled {
+		return errors.NewDomainError(errors.ErrAlreadyExists, "two-factor authentication is already enabled")
+	}
+
+	err = ms.useCode(ctx, mfa, code)
+	if err != nil {
+		return err
+	}
+
+	return ms.storage.Enable(ctx, userID)
+}
+
+// Enabled reports whether the user confirmed an enrollment.
+func (ms *mfaService) Enabled(ctx context.Context, userID int64) (bool, error) {
+	mfa, err := ms.storage.Get(ctx, userID)
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return mfa.Enabled, nil
+}
+
+// Verify checks a second factor of the user: a code of the authenticator or
+// an unused recovery code. Either is accepted once.
+func (ms *mfaService) Verify(ctx context.Context, userID int64, code string) error {
+	mfa, err := ms.storage.Get(ctx, userID)
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return errors.NewDomainError(errors.ErrUnauthorized, "two-factor authentication is not enabled")
+		}
+		return err
+	}
+	if !mfa.Enabled {
+		return errors.NewDomainError(errors.ErrUnauthorized, "two-factor authentication is not enabled")
+	}
+
+	if len(code) == totp.Digits {
+		return ms.useCode(ctx, mfa, code)
+	}
+
+	err = ms.storage.UseRecoveryCode(ctx, userID, hashRecoveryCode(code), time.Now())
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return errors.NewDomainError(errors.ErrUnauthorized, "invalid recovery code")
+		}
+		return err
+	}
+
+	slog.Info("recovery code used", "user_id", userID)
+	return nil
+}
+
+func (ms *mfaService) useCode(ctx context.Context, mfa entity.MFA, code string) error {
+	step, ok := totp.Validate(mfa.Secret, code, time.Now(), mfaSkew)
+	if !ok {
+		return errors.NewDomainError(errors.ErrUnauthorized, "invalid code")
+	}
+
+	err := ms.storage.UseStep(ctx, mfa.UserID, step)
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return errors.NewDomainError(errors.ErrUnauthorized, "code already used")
+		}
+		return err
+	}
+	return nil
+}
+
+// Disable removes the enrollment of the user and its recovery codes.
+func (ms *mfaService) Disable(ctx context.Context, userID int64) error {
+	return ms.storage.Delete(ctx, userID)
+}
+
+// Challenge issues a login challenge for the user. It is signed rather than
+// stored, and is of no use without a second factor.
+func (ms *mfaService) Challenge(userID int64) (entity.MFAChallenge, error) {
+	now := time.Now()
+	expiry := now.Add(ms.cfg.ChallengeTTL)
+
+	challenge, err := ms.signer.Sign(token.Claims{
+		ID:        uuid.NewString(),
+		Subject:   strconv.FormatInt(userID, 10),
+		Use:       tokenUseMFA,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiry.Unix(),
+	})
+	if err != nil {
+		return entity.MFAChallenge{}, err
+	}
+
+	return entity.MFAChallenge{
+		Challenge: challenge,
+		Expiry:    time.Unix(expiry.Unix(), 0),
+	}, nil
+}
+
+// ParseChallenge verifies a login challenge and returns the user it was
+// issued to.
+func (ms *mfaService) ParseChallenge(challenge string) (int64, error) {
+	claims, err := ms.signer.Verify(challenge, time.Now())
+	if err != nil {
+		if stdErrors.Is(err, token.ErrExpired) {
+			return 0, errors.NewDomainError(errors.ErrUnauthorized, "challenge is expired")
+		}
+		return 0, errors.NewDomainError(errors.ErrUnauthorized, "%s", err)
+	}
+	if claims.Use != tokenUseMFA {
+		return 0, errors.NewDomainError(errors.ErrUnauthorized, "not a challenge")
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, errors.NewDomainError(errors.ErrUnauthorized, "invalid subject")
+	}
+	return userID, nil
+}
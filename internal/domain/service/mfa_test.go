@@ -0,0 +1,157 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/The-Gleb/product_catalog/pkg/token"
+	"github.com/The-Gleb/product_catalog/pkg/totp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMFAService(t *testing.T, storage MFAStorage) *mfaService {
+	signer, err := token.NewSigner(bytes.Repeat([]byte{1}, token.MinKeyLen))
+	require.NoError(t, err)
+	return NewMFAService(storage, signer, MFAConfig{Issuer: "Product Catalog", ChallengeTTL: time.Minute})
+}
+
+func Test_mfaService_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockMFAStorage(ctrl)
+	ms := newTestMFAService(t, storage)
+	user := entity.User{ID: 1, Login: "login1", Role: entity.RoleEditor}
+
+	var (
+		saved  entity.MFA
+		hashes []string
+	)
+	storage.EXPECT().Get(gomock.Any(), int64(1)).Return(entity.MFA{}, errors.NewDomainError(errors.ErrNoDataFound, ""))
+	storage.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, mfa entity.MFA, codes []string) error {
+			saved, hashes = mfa, codes
+			return nil
+		})
+
+	enrollment, err := ms.Enroll(context.Background(), user)
+	require.NoError(t, err)
+	require.Equal(t, totp.Encoding.EncodeToString(saved.Secret), enrollment.Secret)
+	require.False(t, saved.Enabled)
+	require.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/"))
+	require.Len(t, enrollment.RecoveryCodes, recoveryCodeCount)
+	for i, code := range enrollment.RecoveryCodes {
+		require.Equal(t, hashRecoveryCode(code), hashes[i])
+		require.Equal(t, hashes[i], hashRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", ""))))
+	}
+
+	storage.EXPECT().Get(gomock.Any(), int64(1)).Return(entity.MFA{UserID: 1, Enabled: true}, nil)
+	_, err = ms.Enroll(context.Background(), user)
+	require.Equal(t, errors.ErrAlreadyExists, errors.Code(err))
+}
+
+func Test_mfaService_Verify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockMFAStorage(ctrl)
+	ms := newTestMFAService(t, storage)
+
+	secret, err := totp.NewSecret()
+	require.NoError(t, err)
+	enabled := entity.MFA{UserID: 1, Secret: secret, Enabled: true}
+	code := totp.Code(secret, totp.Step(time.Now()))
+
+	tests := []struct {
+		name      string
+		code      string
+		errorCode errors.ErrorCode
+		prepare   func()
+	}{
+		{
+			name: "positive, code",
+			code: code,
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil)
+				storage.EXPECT().UseStep(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "positive, recovery code",
+			code: "aaaaa-bbbbb",
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil)
+				storage.EXPECT().UseRecoveryCode(gomock.Any(), int64(1), hashRecoveryCode("aaaaabbbbb"), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "negative, code used twice",
+			code:      code,
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil)
+				storage.EXPECT().UseStep(gomock.Any(), int64(1), gomock.Any()).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:      "negative, wrong code",
+			code:      "abcdef",
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil)
+			},
+		},
+		{
+			name:      "negative, used recovery code",
+			code:      "aaaaa-bbbbb",
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), int64(1)).Return(enabled, nil)
+				storage.EXPECT().UseRecoveryCode(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:      "negative, pending enrollment",
+			code:      code,
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().Get(gomock.Any(), int64(1)).Return(entity.MFA{UserID: 1, Secret: secret}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			err := ms.Verify(context.Background(), 1, tt.code)
+			require.Equal(t, tt.errorCode, errors.Code(err))
+		})
+	}
+}
+
+func Test_mfaService_Challenge(t *testing.T) {
+	ms := newTestMFAService(t, nil)
+
+	challenge, err := ms.Challenge(7)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Minute), challenge.Expiry, 2*time.Second)
+
+	userID, err := ms.ParseChallenge(challenge.Challenge)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), userID)
+
+	// Other tokens of the same signer are no challenges.
+	access, err := ms.signer.Sign(token.Claims{
+		Subject:   "7",
+		Use:       tokenUseAccess,
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+	_, err = ms.ParseChallenge(access)
+	require.Equal(t, errors.ErrUnauthorized, errors.Code(err))
+}
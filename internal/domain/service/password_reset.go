@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+var _ usecase.PasswordResetService = new(passwordResetService)
+
+type PasswordResetStorage interface {
+	// Create stores a reset token, replacing the other tokens of the user.
+	Create(ctx context.Context, reset entity.PasswordReset) error
+	// Get returns an unused reset token that isn't expired at now.
+	Get(ctx context.Context, hash string, now time.Time) (entity.PasswordReset, error)
+	// Use marks an unused reset token used. It fails with ErrNoDataFound if
+	// there is no such token, so that a token is only used once.
+	Use(ctx context.Context, hash string, now time.Time) error
+}
+
+// Notifier delivers notifications to users.
+type Notifier interface {
+	Notify(ctx context.Context, n entity.Notification) error
+}
+
+// PasswordResetConfig sets how long reset tokens are valid and the page the
+// link sent to users leads to; the token is added as the "token" parameter.
+type PasswordResetConfig struct {
+	TTL time.Duration
+	URL string
+}
+
+type passwordResetService struct {
+	storage  PasswordResetStorage
+	notifier Notifier
+	cfg      PasswordResetConfig
+}
+
+func NewPasswordResetService(s PasswordResetStorage, n Notifier, cfg PasswordResetConfig) *passwordResetService {
+	return &passwordResetService{storage: s, notifier: n, cfg: cfg}
+}
+
+func hashResetToken(t string) string {
+	sum := sha256.Sum256([]byte(t))
+	return hex.EncodeToString(sum[:])
+}
+
+// Request issues a reset token to the user and sends it to their email.
+func (rs *passwordResetService) Request(ctx context.Context, user entity.User) error {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return err
+	}
+	t := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	err = rs.storage.Create(ctx, entity.PasswordReset{
+		Hash:      hashResetToken(t),
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(rs.cfg.TTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := url.Parse(rs.cfg.URL)
+	if err != nil {
+		return err
+	}
+	q := link.Query()
+	q.Set("token", t)
+	link.RawQuery = q.Encode()
+
+	return rs.notifier.Notify(ctx, entity.Notification{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf(
+			"A password reset was requested for %s.\n\n"+
+				"Follow this link to choose a new password:\n%s\n\n"+
+				"The link expires in %s. If you didn't ask for it, ignore this message.\n",
+			user.Login, link, rs.cfg.TTL,
+		),
+	})
+}
+
+// Check returns the user a reset token was issued to, without using it.
+func (rs *passwordResetService) Check(ctx context.Context, t string) (int64, error) {
+	reset, err := rs.storage.Get(ctx, hashResetToken(t), time.Now())
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return 0, errors.NewDomainError(errors.ErrUnauthorized, "invalid or expired reset token")
+		}
+		return 0, err
+	}
+	return reset.UserID, nil
+}
+
+// Use spends a reset token.
+func (rs *passwordResetService) Use(ctx context.Context, t string) error {
+	err := rs.storage.Use(ctx, hashResetToken(t), time.Now())
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return errors.NewDomainError(errors.ErrUnauthorized, "invalid or expired reset token")
+		}
+		return err
+	}
+	return nil
+}
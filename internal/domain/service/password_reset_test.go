@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_passwordResetService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockPasswordResetStorage(ctrl)
+	notifier := mocks.NewMockNotifier(ctrl)
+	rs := NewPasswordResetService(storage, notifier, PasswordResetConfig{
+		TTL: time.Hour,
+		URL: "https://catalog.example.com/reset?lang=en",
+	})
+	user := entity.User{ID: 1, Login: "login1", Email: "user@example.com"}
+
+	var (
+		stored entity.PasswordReset
+		sent   entity.Notification
+	)
+	storage.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, reset entity.PasswordReset) error {
+			stored = reset
+			return nil
+		})
+	notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, n entity.Notification) error {
+			sent = n
+			return nil
+		})
+
+	require.NoError(t, rs.Request(context.Background(), user))
+	require.Equal(t, int64(1), stored.UserID)
+	require.Equal(t, time.Hour, stored.ExpiresAt.Sub(stored.CreatedAt))
+	require.Equal(t, "user@example.com", sent.To)
+
+	var link *url.URL
+	for _, field := range strings.Fields(sent.Body) {
+		if strings.HasPrefix(field, "https://") {
+			var err error
+			link, err = url.Parse(field)
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, link)
+	require.Equal(t, "en", link.Query().Get("lang"))
+	token := link.Query().Get("token")
+	require.Equal(t, hashResetToken(token), stored.Hash)
+	require.NotContains(t, stored.Hash, token)
+
+	storage.EXPECT().Get(gomock.Any(), stored.Hash, gomock.Any()).Return(stored, nil)
+	userID, err := rs.Check(context.Background(), token)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), userID)
+
+	storage.EXPECT().Use(gomock.Any(), stored.Hash, gomock.Any()).Return(nil)
+	require.NoError(t, rs.Use(context.Background(), token))
+
+	// A used or expired token is no longer found.
+	storage.EXPECT().Use(gomock.Any(), stored.Hash, gomock.Any()).
+		Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+	err = rs.Use(context.Background(), token)
+	require.Equal(t, errors.ErrUnauthorized, errors.Code(err))
+
+	storage.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(entity.PasswordReset{}, errors.NewDomainError(errors.ErrNoDataFound, ""))
+	_, err = rs.Check(context.Background(), "unknown")
+	require.Equal(t, errors.ErrUnauthorized, errors.Code(err))
+}
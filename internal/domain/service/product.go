@@ -2,10 +2,15 @@ package service
 
 import (
 	"context"
+	stdErrors "errors"
+	"fmt"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
+	"github.com/The-Gleb/product_catalog/internal/errors"
 )
 
 var _ usecase.ProductService = new(productService)
@@ -13,27 +18,60 @@ var _ usecase.ProductService = new(productService)
 type ProductStorage interface {
 	Add(ctx context.Context, products entity.AddProductDTO) error
 	AddOrUpdateProduct(ctx context.Context, products ...entity.AddOrUpdateProductDTO) error
-	GetByCategory(ctx context.Context, categoryID int64) ([]entity.ProductCategoryListItem, error)
+	GetByID(ctx context.Context, ID int64) (entity.ProductView, error)
+	GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error)
 	UpdateName(ctx context.Context, product entity.UpdateProductNameDTO) error
+	UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error
 	UpdateCategory(ctx context.Context, product entity.UpdateProductCategoryDTO) error
 	Delete(ctx context.Context, ID int64) error
+	GetSourceProducts(ctx context.Context, source string) ([]entity.SourceProduct, error)
+	ApplyReconcilePlan(ctx context.Context, plan entity.ReconcilePlan) ([]entity.ProductConflict, error)
 }
 
 type ProductClient interface {
-	GetNewProducts(ctx context.Context, offset int) ([]entity.AddOrUpdateProductDTO, error)
+	Source() string
+	GetNewProducts(ctx context.Context, offset, limit int) (entity.ProductFeedPage, error)
+}
+
+type SyncStateStorage interface {
+	Get(ctx context.Context, source string) (entity.SyncState, error)
+	Save(ctx context.Context, state entity.SyncState) error
+}
+
+type ReconcileReportStorage interface {
+	Save(ctx context.Context, report entity.ReconcileReport) (entity.ReconcileReport, error)
+	List(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error)
 }
 
 type productService struct {
-	storage        ProductStorage
-	client         ProductClient
-	updateInterval time.Duration
+	storage    ProductStorage
+	clients    []ProductClient
+	syncState  SyncStateStorage
+	reports    ReconcileReportStorage
+	pageSize   int
+	deleteMode entity.DeleteMode
+
+	// syncMu serialises the syncs and reconciliations of the sources.
+	syncMu sync.Mutex
 }
 
-func NewProductService(s ProductStorage, c ProductClient, interval time.Duration) *productService {
+// NewProductService creates the service. clients are the upstream sources the
+// products are synchronised from, each one with its own checkpoint.
+func NewProductService(
+	s ProductStorage,
+	clients []ProductClient,
+	ss SyncStateStorage,
+	rs ReconcileReportStorage,
+	pageSize int,
+	deleteMode entity.DeleteMode,
+) *productService {
 	return &productService{
-		storage:        s,
-		client:         c,
-		updateInterval: interval,
+		storage:    s,
+		clients:    clients,
+		syncState:  ss,
+		reports:    rs,
+		pageSize:   pageSize,
+		deleteMode: deleteMode,
 	}
 }
 
@@ -41,14 +79,37 @@ func (s *productService) Add(ctx context.Context, product entity.AddProductDTO)
 	return s.storage.Add(ctx, product)
 }
 
-func (s *productService) GetByCategory(ctx context.Context, categoryID int64) ([]entity.ProductCategoryListItem, error) {
-	return s.storage.GetByCategory(ctx, categoryID)
+func (s *productService) GetByID(ctx context.Context, ID int64) (entity.ProductView, error) {
+	return s.storage.GetByID(ctx, ID)
+}
+
+func (s *productService) GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error) {
+	query.Limit = pageLimit(query.Limit)
+	if query.Sort == "" {
+		query.Sort = entity.SortByID
+	}
+	return s.storage.GetByCategory(ctx, query)
+}
+
+// pageLimit applies the default and the maximum page size of the listings.
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return entity.DefaultProductPageLimit
+	}
+	if limit > entity.MaxProductPageLimit {
+		return entity.MaxProductPageLimit
+	}
+	return limit
 }
 
 func (s *productService) UpdateName(ctx context.Context, product entity.UpdateProductNameDTO) error {
 	return s.storage.UpdateName(ctx, product)
 }
 
+func (s *productService) UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error {
+	return s.storage.UpdateDetails(ctx, product)
+}
+
 func (s *productService) UpdateCategory(ctx context.Context, product entity.UpdateProductCategoryDTO) error {
 	return s.storage.UpdateCategory(ctx, product)
 }
@@ -57,34 +118,187 @@ func (s *productService) Delete(ctx context.Context, ID int64) error {
 	return s.storage.Delete(ctx, ID)
 }
 
-func (s *productService) CheckNewProducts(ctx context.Context) error {
+// SyncNewProducts imports the products added upstream since the last
+// checkpoint of every source. A failing source doesn't stop the others.
+func (s *productService) SyncNewProducts(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
 
-	ticker := time.NewTicker(s.updateInterval)
-	offset := 10
+	var errs []error
+	for _, client := range s.clients {
+		err := s.syncNewProducts(ctx, client)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source %s: %w", client.Source(), err))
+		}
+	}
+	return stdErrors.Join(errs...)
+}
 
-	newProducts, err := s.client.GetNewProducts(ctx, 0)
+func (s *productService) syncNewProducts(ctx context.Context, client ProductClient) error {
+	state, err := s.syncState.Get(ctx, client.Source())
 	if err != nil {
-		return err
+		if errors.Code(err) != errors.ErrNoDataFound {
+			return err
+		}
+		state = entity.SyncState{Source: client.Source()}
 	}
-	err = s.storage.AddOrUpdateProduct(ctx, newProducts...)
-	if err != nil {
-		return err
+
+	return s.syncFrom(ctx, client, state)
+}
+
+// FullResync imports every page of every source, starting from the first one.
+func (s *productService) FullResync(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	var errs []error
+	for _, client := range s.clients {
+		err := s.syncFrom(ctx, client, entity.SyncState{Source: client.Source()})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source %s: %w", client.Source(), err))
+		}
 	}
-	for {
-		select {
-		case <-ticker.C:
-			newProducts, err := s.client.GetNewProducts(ctx, offset)
-			if err != nil {
-				return err
-			}
-			err = s.storage.AddOrUpdateProduct(ctx, newProducts...)
+	return stdErrors.Join(errs...)
+}
+
+// syncFrom imports the feed page by page starting at state.Offset and saves
+// the checkpoint after every page, so an interrupted sync resumes where it stopped.
+func (s *productService) syncFrom(ctx context.Context, client ProductClient, state entity.SyncState) error {
+	for ctx.Err() == nil {
+		page, err := client.GetNewProducts(ctx, state.Offset, s.pageSize)
+		if err != nil {
+			return err
+		}
+
+		if len(page.Products) > 0 {
+			err = s.storage.AddOrUpdateProduct(ctx, page.Products...)
 			if err != nil {
 				return err
 			}
-			offset = offset + 10
-		case <-ctx.Done():
+		}
+
+		state.Offset += page.Fetched
+		state.Total = page.Total
+		err = s.syncState.Save(ctx, state)
+		if err != nil {
+			return err
+		}
+
+		if page.Fetched == 0 || state.Offset >= state.Total {
+			slog.Debug("product feed is synchronised",
+				"source", state.Source,
+				"offset", state.Offset,
+				"total", state.Total,
+			)
 			return nil
 		}
 	}
 
+	return nil
+}
+
+// Reconcile reconciles every source with its upstream snapshot.
+func (s *productService) Reconcile(ctx context.Context) error {
+	var errs []error
+	for _, client := range s.clients {
+		_, err := s.ReconcileSource(ctx, client.Source())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source %s: %w", client.Source(), err))
+		}
+	}
+	return stdErrors.Join(errs...)
+}
+
+// ReconcileSource makes the products of a source match a full upstream
+// snapshot: it adds, renames, moves and deletes them and saves the diff as a
+// report. Products created through the API have no source and are never touched.
+func (s *productService) ReconcileSource(ctx context.Context, source string) (entity.ReconcileReport, error) {
+	var client ProductClient
+	for _, c := range s.clients {
+		if c.Source() == source {
+			client = c
+		}
+	}
+	if client == nil {
+		return entity.ReconcileReport{}, errors.NewDomainError(errors.ErrNoDataFound, "unknown product source %s", source)
+	}
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	startedAt := time.Now()
+
+	snapshot, state, err := s.snapshot(ctx, client)
+	if err != nil {
+		return entity.ReconcileReport{}, err
+	}
+
+	current, err := s.storage.GetSourceProducts(ctx, source)
+	if err != nil {
+		return entity.ReconcileReport{}, err
+	}
+
+	plan, report := entity.DiffSnapshot(source, s.deleteMode, current, snapshot)
+	if plan.Snapshot == 0 && len(plan.Deletes) > 0 {
+		return entity.ReconcileReport{}, fmt.Errorf("upstream snapshot of %s is empty, refusing to delete %d products", source, len(plan.Deletes))
+	}
+
+	conflicts, err := s.storage.ApplyReconcilePlan(ctx, plan)
+	if err != nil {
+		return entity.ReconcileReport{}, err
+	}
+	report.DropConflicts(conflicts)
+
+	err = s.syncState.Save(ctx, state)
+	if err != nil {
+		return entity.ReconcileReport{}, err
+	}
+
+	report.StartedAt = startedAt
+	report.FinishedAt = time.Now()
+	report, err = s.reports.Save(ctx, report)
+	if err != nil {
+		return entity.ReconcileReport{}, err
+	}
+
+	slog.Info("source reconciled",
+		"source", source,
+		"added", len(report.Added),
+		"renamed", len(report.Renamed),
+		"moved", len(report.Moved),
+		"restored", len(report.Restored),
+		"deleted", len(report.Deleted),
+		"conflicts", len(report.Conflicts),
+		"unchanged", report.Unchanged,
+	)
+
+	return report, nil
+}
+
+// snapshot reads every page of a source. It returns the sync checkpoint of the
+// end of the feed, since the snapshot imports everything up to it.
+func (s *productService) snapshot(ctx context.Context, client ProductClient) ([]entity.AddOrUpdateProductDTO, entity.SyncState, error) {
+	state := entity.SyncState{Source: client.Source()}
+	var products []entity.AddOrUpdateProductDTO
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, entity.SyncState{}, err
+		}
+
+		page, err := client.GetNewProducts(ctx, state.Offset, s.pageSize)
+		if err != nil {
+			return nil, entity.SyncState{}, err
+		}
+		products = append(products, page.Products...)
+		state.Offset += page.Fetched
+		state.Total = page.Total
+
+		if page.Fetched == 0 || state.Offset >= state.Total {
+			return products, state, nil
+		}
+	}
+}
+
+func (s *productService) ReconcileReports(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error) {
+	return s.reports.List(ctx, source, pageLimit(limit))
 }
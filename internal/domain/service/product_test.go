@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func feedPage(total int, names ...string) entity.ProductFeedPage {
+	page := entity.ProductFeedPage{Total: total, Fetched: len(names)}
+	for _, name := range names {
+		page.Products = append(page.Products, entity.AddOrUpdateProductDTO{ProductName: name, CategoryName: "phones"})
+	}
+	return page
+}
+
+func Test_productService_SyncNewProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockProductStorage(ctrl)
+	client := mocks.NewMockProductClient(ctrl)
+	syncState := mocks.NewMockSyncStateStorage(ctrl)
+	client.EXPECT().Source().Return("dummyjson").AnyTimes()
+
+	s := NewProductService(storage, []ProductClient{client}, syncState, nil, 2, entity.DeleteModeTombstone)
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		prepare func()
+	}{
+		{
+			name: "resumes from checkpoint until the end of the feed",
+			prepare: func() {
+				gomock.InOrder(
+					syncState.EXPECT().Get(gomock.Any(), "dummyjson").
+						Return(entity.SyncState{Source: "dummyjson", Offset: 2, Total: 4}, nil),
+					client.EXPECT().GetNewProducts(gomock.Any(), 2, 2).Return(feedPage(5, "c", "d"), nil),
+					storage.EXPECT().AddOrUpdateProduct(gomock.Any(), gomock.Len(2)).Return(nil),
+					syncState.EXPECT().Save(gomock.Any(), entity.SyncState{Source: "dummyjson", Offset: 4, Total: 5}).Return(nil),
+					client.EXPECT().GetNewProducts(gomock.Any(), 4, 2).Return(feedPage(5, "e"), nil),
+					storage.EXPECT().AddOrUpdateProduct(gomock.Any(), gomock.Len(1)).Return(nil),
+					syncState.EXPECT().Save(gomock.Any(), entity.SyncState{Source: "dummyjson", Offset: 5, Total: 5}).Return(nil),
+				)
+			},
+		},
+		{
+			name: "no checkpoint yet",
+			prepare: func() {
+				gomock.InOrder(
+					syncState.EXPECT().Get(gomock.Any(), "dummyjson").
+						Return(entity.SyncState{}, errors.NewDomainError(errors.ErrNoDataFound, "")),
+					client.EXPECT().GetNewProducts(gomock.Any(), 0, 2).Return(feedPage(1, "a"), nil),
+					storage.EXPECT().AddOrUpdateProduct(gomock.Any(), gomock.Len(1)).Return(nil),
+					syncState.EXPECT().Save(gomock.Any(), entity.SyncState{Source: "dummyjson", Offset: 1, Total: 1}).Return(nil),
+				)
+			},
+		},
+		{
+			name: "feed is already synchronised",
+			prepare: func() {
+				gomock.InOrder(
+					syncState.EXPECT().Get(gomock.Any(), "dummyjson").
+						Return(entity.SyncState{Source: "dummyjson", Offset: 5, Total: 5}, nil),
+					client.EXPECT().GetNewProducts(gomock.Any(), 5, 2).Return(feedPage(5), nil),
+					syncState.EXPECT().Save(gomock.Any(), entity.SyncState{Source: "dummyjson", Offset: 5, Total: 5}).Return(nil),
+				)
+			},
+		},
+		{
+			name:    "client error keeps the checkpoint",
+			wantErr: true,
+			prepare: func() {
+				gomock.InOrder(
+					syncState.EXPECT().Get(gomock.Any(), "dummyjson").
+						Return(entity.SyncState{Source: "dummyjson", Offset: 2, Total: 4}, nil),
+					client.EXPECT().GetNewProducts(gomock.Any(), 2, 2).Return(entity.ProductFeedPage{}, fmt.Errorf("timeout")),
+				)
+			},
+		},
+		{
+			name:    "storage error",
+			wantErr: true,
+			prepare: func() {
+				syncState.EXPECT().Get(gomock.Any(), "dummyjson").
+					Return(entity.SyncState{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			err := s.SyncNewProducts(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_productService_FullResync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockProductStorage(ctrl)
+	client := mocks.NewMockProductClient(ctrl)
+	syncState := mocks.NewMockSyncStateStorage(ctrl)
+	client.EXPECT().Source().Return("dummyjson").AnyTimes()
+
+	s := NewProductService(storage, []ProductClient{client}, syncState, nil, 2, entity.DeleteModeTombstone)
+
+	gomock.InOrder(
+		client.EXPECT().GetNewProducts(gomock.Any(), 0, 2).Return(feedPage(3, "a", "b"), nil),
+		storage.EXPECT().AddOrUpdateProduct(gomock.Any(), gomock.Len(2)).Return(nil),
+		syncState.EXPECT().Save(gomock.Any(), entity.SyncState{Source: "dummyjson", Offset: 2, Total: 3}).Return(nil),
+		client.EXPECT().GetNewProducts(gomock.Any(), 2, 2).Return(feedPage(3, "c"), nil),
+		storage.EXPECT().AddOrUpdateProduct(gomock.Any(), gomock.Len(1)).Return(nil),
+		syncState.EXPECT().Save(gomock.Any(), entity.SyncState{Source: "dummyjson", Offset: 3, Total: 3}).Return(nil),
+	)
+
+	err := s.FullResync(context.Background())
+	require.NoError(t, err)
+}
+
+func Test_productService_SyncNewProducts_severalSources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockProductStorage(ctrl)
+	failing := mocks.NewMockProductClient(ctrl)
+	healthy := mocks.NewMockProductClient(ctrl)
+	syncState := mocks.NewMockSyncStateStorage(ctrl)
+	failing.EXPECT().Source().Return("feed").AnyTimes()
+	healthy.EXPECT().Source().Return("files").AnyTimes()
+
+	s := NewProductService(storage, []ProductClient{failing, healthy}, syncState, nil, 2, entity.DeleteModeTombstone)
+
+	syncState.EXPECT().Get(gomock.Any(), "feed").Return(entity.SyncState{Source: "feed"}, nil)
+	failing.EXPECT().GetNewProducts(gomock.Any(), 0, 2).Return(entity.ProductFeedPage{}, fmt.Errorf("502"))
+
+	skipped := feedPage(2, "a")
+	skipped.Fetched = 2
+	gomock.InOrder(
+		syncState.EXPECT().Get(gomock.Any(), "files").Return(entity.SyncState{Source: "files"}, nil),
+		healthy.EXPECT().GetNewProducts(gomock.Any(), 0, 2).Return(skipped, nil),
+		storage.EXPECT().AddOrUpdateProduct(gomock.Any(), gomock.Len(1)).Return(nil),
+		syncState.EXPECT().Save(gomock.Any(), entity.SyncState{Source: "files", Offset: 2, Total: 2}).Return(nil),
+	)
+
+	err := s.SyncNewProducts(context.Background())
+	require.ErrorContains(t, err, "source feed: 502")
+}
+
+func Test_productService_ReconcileSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockProductStorage(ctrl)
+	client := mocks.NewMockProductClient(ctrl)
+	syncState := mocks.NewMockSyncStateStorage(ctrl)
+	reports := mocks.NewMockReconcileReportStorage(ctrl)
+	client.EXPECT().Source().Return("dummyjson").AnyTimes()
+
+	s := NewProductService(storage, []ProductClient{client}, syncState, reports, 4, entity.DeleteModeTombstone)
+
+	product := func(id, name, category string) entity.AddOrUpdateProductDTO {
+		return entity.AddOrUpdateProductDTO{
+			ProductName:      name,
+			CategoryName:     category,
+			ProductSourceRef: entity.ProductSourceRef{Source: "dummyjson", ExternalID: id},
+		}
+	}
+	snapshot := []entity.AddOrUpdateProductDTO{
+		product("1", "redmi note", "phones"),
+		product("2", "iphone", "tablets"),
+		// Category names are compared ignoring case, like the storage does.
+		product("3", "pixel", "Phones"),
+		product("5", "lumia", "phones"),
+		product("7", "galaxy", "phones"),
+		product("8", "taken", "phones"),
+	}
+	current := []entity.SourceProduct{
+		{ID: 1, ExternalID: "1", Name: "redmi", Categories: []string{"phones"}},
+		{ID: 2, ExternalID: "2", Name: "iphone", Categories: []string{"phones"}},
+		{ID: 3, ExternalID: "3", Name: "pixel", Categories: []string{"phones"}},
+		{ID: 4, ExternalID: "4", Name: "nokia", Categories: []string{"phones"}},
+		{ID: 5, ExternalID: "5", Name: "lumia", Categories: []string{"phones"}, Deleted: true},
+		{ID: 6, ExternalID: "6", Name: "siemens", Categories: []string{"phones"}, Deleted: true},
+	}
+	conflicts := []entity.ProductConflict{{ExternalID: "8", Name: "taken", Reason: "violates product_name_key"}}
+
+	gomock.InOrder(
+		client.EXPECT().GetNewProducts(gomock.Any(), 0, 4).
+			Return(entity.ProductFeedPage{Products: snapshot[:4], Fetched: 4, Total: 6}, nil),
+		client.EXPECT().GetNewProducts(gomock.Any(), 4, 4).
+			Return(entity.ProductFeedPage{Products: snapshot[4:], Fetched: 2, Total: 6}, nil),
+		storage.EXPECT().GetSourceProducts(gomock.Any(), "dummyjson").Return(current, nil),
+		storage.EXPECT().ApplyReconcilePlan(gomock.Any(), entity.ReconcilePlan{
+			Source:   "dummyjson",
+			Mode:     entity.DeleteModeTombstone,
+			Upserts:  snapshot,
+			Deletes:  []string{"4"},
+			Snapshot: 6,
+		}).Return(conflicts, nil),
+		syncState.EXPECT().Save(gomock.Any(), entity.SyncState{Source: "dummyjson", Offset: 6, Total: 6}).Return(nil),
+		reports.EXPECT().Save(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, r entity.ReconcileReport) (entity.ReconcileReport, error) {
+				r.ID = 1
+				return r, nil
+			}),
+	)
+
+	report, err := s.ReconcileSource(context.Background(), "dummyjson")
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), report.ID)
+	require.Equal(t, []entity.ReconciledProduct{{ExternalID: "7", Name: "galaxy"}}, report.Added)
+	require.Equal(t, []entity.ProductRename{{ExternalID: "1", OldName: "redmi", NewName: "redmi note"}}, report.Renamed)
+	require.Equal(t, []entity.ProductMove{{
+		ExternalID: "2", Name: "iphone", OldCategories: []string{"phones"}, NewCategory: "tablets",
+	}}, report.Moved)
+	require.Equal(t, []entity.ReconciledProduct{{ExternalID: "5", Name: "lumia"}}, report.Restored)
+	require.Equal(t, []entity.ReconciledProduct{{ExternalID: "4", Name: "nokia"}}, report.Deleted)
+	require.Equal(t, conflicts, report.Conflicts)
+	require.Equal(t, 1, report.Unchanged)
+	require.False(t, report.FinishedAt.Before(report.StartedAt))
+}
+
+func Test_productService_ReconcileSource_errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockProductStorage(ctrl)
+	client := mocks.NewMockProductClient(ctrl)
+	syncState := mocks.NewMockSyncStateStorage(ctrl)
+	reports := mocks.NewMockReconcileReportStorage(ctrl)
+	client.EXPECT().Source().Return("dummyjson").AnyTimes()
+
+	s := NewProductService(storage, []ProductClient{client}, syncState, reports, 4, entity.DeleteModeDelete)
+
+	_, err := s.ReconcileSource(context.Background(), "ftp")
+	require.Equal(t, errors.ErrNoDataFound, errors.Code(err))
+
+	client.EXPECT().GetNewProducts(gomock.Any(), 0, 4).Return(entity.ProductFeedPage{}, nil)
+	storage.EXPECT().GetSourceProducts(gomock.Any(), "dummyjson").
+		Return([]entity.SourceProduct{{ID: 1, ExternalID: "1", Name: "redmi"}}, nil)
+
+	_, err = s.ReconcileSource(context.Background(), "dummyjson")
+	require.ErrorContains(t, err, "refusing to delete 1 products")
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
+)
+
+var _ usecase.SearchService = new(searchService)
+
+type ProductSearcher interface {
+	Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error)
+}
+
+type ProductFacetCounter interface {
+	Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error)
+}
+
+type searchService struct {
+	searcher ProductSearcher
+	facets   ProductFacetCounter
+}
+
+func NewSearchService(s ProductSearcher, f ProductFacetCounter) *searchService {
+	return &searchService{searcher: s, facets: f}
+}
+
+func (s *searchService) Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error) {
+	query.Limit = pageLimit(query.Limit)
+	if query.Sort == "" {
+		query.Sort = entity.SortByRelevance
+	}
+	return s.searcher.Search(ctx, query)
+}
+
+func (s *searchService) Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error) {
+	query.Limit = pageLimit(query.Limit)
+	if query.Sort == "" {
+		query.Sort = entity.SortByID
+	}
+	return s.facets.Facets(ctx, query)
+}
@@ -2,11 +2,18 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stdErrors "errors"
+	"log/slog"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
 	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
 	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/pkg/token"
 
 	"github.com/google/uuid"
 )
@@ -14,64 +21,298 @@ import (
 var _ usecase.SessionService = new(sessionService)
 
 type SessionStorage interface {
-	Create(ctx context.Context, session entity.Session) error
-	GetByToken(ctx context.Context, token string) (entity.Session, error)
-	Delete(ctx context.Context, token string) error
+	Create(ctx context.Context, session entity.Session) (entity.Session, error)
+	GetByID(ctx context.Context, ID int64) (entity.Session, error)
+	GetByUser(ctx context.Context, userID int64) ([]entity.Session, error)
+	Rotate(ctx context.Context, ID int64, oldToken, newToken string, lastUsedAt time.Time) error
+	DeleteByID(ctx context.Context, userID, ID int64) error
+	DeleteByUser(ctx context.Context, userID int64) error
+	DeleteOthers(ctx context.Context, userID, keepID int64) error
 
 	DeleteExpired(ctx context.Context) error
 }
 
+const (
+	tokenUseAccess  = "access"
+	tokenUseRefresh = "refresh"
+)
+
+// TokenConfig sets the lifetimes of the tokens. RefreshTTL is the lifetime of
+// a session: refreshing rotates its token but doesn't extend it.
+type TokenConfig struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
 type sessionService struct {
 	storage SessionStorage
+	signer  *token.Signer
+	cfg     TokenConfig
+
+	// revoked holds the sessions revoked by this process, until when their
+	// access tokens could still be valid.
+	mu      sync.Mutex
+	revoked map[int64]time.Time
 }
 
-func NewSessionService(s SessionStorage) *sessionService {
-	return &sessionService{storage: s}
+func NewSessionService(s SessionStorage, signer *token.Signer, cfg TokenConfig) *sessionService {
+	return &sessionService{storage: s, signer: signer, cfg: cfg, revoked: make(map[int64]time.Time)}
 }
 
-func (ss *sessionService) GetByToken(ctx context.Context, token string) (entity.Session, error) {
+// hashRefreshToken hashes a refresh token for storage in the session.
+func hashRefreshToken(t string) string {
+	sum := sha256.Sum256([]byte(t))
+	return hex.EncodeToString(sum[:])
+}
 
-	err := ss.storage.DeleteExpired(ctx)
+// Authenticate verifies an access token and returns the session it was
+// issued for. It doesn't touch the database: the sessions revoked by this
+// process are rejected at once, the ones revoked by another replica keep
+// working until their access token expires, AccessTTL at most.
+func (ss *sessionService) Authenticate(ctx context.Context, accessToken string) (entity.Session, error) {
+	claims, err := ss.verify(accessToken, tokenUseAccess)
 	if err != nil {
 		return entity.Session{}, err
 	}
 
-	session, err := ss.storage.GetByToken(ctx, token)
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
 	if err != nil {
-		return entity.Session{}, err
+		return entity.Session{}, errors.NewDomainError(errors.ErrUnauthorized, "invalid subject")
 	}
-	if session.IsExpired() {
-		return session, errors.NewDomainError(errors.ErrSessionExpired, "")
+	if ss.isRevoked(claims.SessionID) {
+		return entity.Session{}, errors.NewDomainError(errors.ErrUnauthorized, "session is revoked")
 	}
-	return session, nil
+
+	return entity.Session{
+		ID:     claims.SessionID,
+		UserID: userID,
+		Expiry: time.Unix(claims.ExpiresAt, 0),
+	}, nil
 }
 
-func (ss *sessionService) Create(ctx context.Context, userID int64) (entity.Session, error) {
+// Create opens a session for the user and issues its first tokens.
+func (ss *sessionService) Create(ctx context.Context, userID int64, client entity.ClientInfo) (entity.TokenPair, error) {
 
+	err := ss.storage.DeleteExpired(ctx)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	now := time.Now()
 	newSession := entity.Session{
-		Token:  uuid.NewString(),
-		UserID: userID,
-		Expiry: time.Now().Add(24 * time.Hour),
+		// The refresh token is signed with the session ID, so the session
+		// is stored with a unique placeholder first.
+		Token:      uuid.NewString(),
+		UserID:     userID,
+		Expiry:     now.Add(ss.cfg.RefreshTTL),
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ClientInfo: client,
 	}
 
 	for {
-		err := ss.storage.Create(ctx, newSession)
+		created, err := ss.storage.Create(ctx, newSession)
 		if errors.Code(err) == errors.ErrAlreadyExists {
 			newSession.Token = uuid.NewString()
 			continue
 		}
 		if err != nil {
-			return entity.Session{}, err
+			return entity.TokenPair{}, err
 		}
+		newSession = created
 		break
 	}
-	return newSession, nil
+
+	pair, err := ss.issue(newSession, now)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	err = ss.storage.Rotate(ctx, newSession.ID, newSession.Token, hashRefreshToken(pair.RefreshToken), now)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	return pair, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. A refresh token
+// that was already used means it leaked, so the whole session is revoked.
+func (ss *sessionService) Refresh(ctx context.Context, refreshToken string) (entity.TokenPair, error) {
+	claims, err := ss.verify(refreshToken, tokenUseRefresh)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	session, err := ss.storage.GetByID(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return entity.TokenPair{}, errors.NewDomainError(errors.ErrUnauthorized, "session is revoked")
+		}
+		return entity.TokenPair{}, err
+	}
+	if strconv.FormatInt(session.UserID, 10) != claims.Subject {
+		return entity.TokenPair{}, errors.NewDomainError(errors.ErrUnauthorized, "session of another user")
+	}
+	if session.Token != hashRefreshToken(refreshToken) {
+		return entity.TokenPair{}, ss.revokeReused(ctx, session)
+	}
+	if session.IsExpired() {
+		return entity.TokenPair{}, errors.NewDomainError(errors.ErrSessionExpired, "")
+	}
+
+	now := time.Now()
+	pair, err := ss.issue(session, now)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	err = ss.storage.Rotate(ctx, session.ID, session.Token, hashRefreshToken(pair.RefreshToken), now)
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			// Another request rotated the token between the read and the
+			// update, so the same token was used twice.
+			return entity.TokenPair{}, ss.revokeReused(ctx, session)
+		}
+		return entity.TokenPair{}, err
+	}
+
+	return pair, nil
+}
+
+func (ss *sessionService) revokeReused(ctx context.Context, session entity.Session) error {
+	slog.Warn("refresh token reuse detected, revoking session",
+		"session_id", session.ID, "user_id", session.UserID,
+	)
+
+	err := ss.storage.DeleteByID(ctx, session.UserID, session.ID)
+	if err != nil && errors.Code(err) != errors.ErrNoDataFound {
+		return err
+	}
+	ss.revoke(session.ID)
+	return errors.NewDomainError(errors.ErrUnauthorized, "refresh token reuse")
+}
+
+// issue signs a token pair for the session. No token outlives the session.
+func (ss *sessionService) issue(session entity.Session, now time.Time) (entity.TokenPair, error) {
+	accessExpiry := now.Add(ss.cfg.AccessTTL)
+	if accessExpiry.After(session.Expiry) {
+		accessExpiry = session.Expiry
+	}
+
+	subject := strconv.FormatInt(session.UserID, 10)
+	access, err := ss.signer.Sign(token.Claims{
+		Subject:   subject,
+		SessionID: session.ID,
+		Use:       tokenUseAccess,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: accessExpiry.Unix(),
+	})
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	refresh, err := ss.signer.Sign(token.Claims{
+		ID:        uuid.NewString(),
+		Subject:   subject,
+		SessionID: session.ID,
+		Use:       tokenUseRefresh,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: session.Expiry.Unix(),
+	})
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	return entity.TokenPair{
+		AccessToken:   access,
+		AccessExpiry:  time.Unix(accessExpiry.Unix(), 0),
+		RefreshToken:  refresh,
+		RefreshExpiry: time.Unix(session.Expiry.Unix(), 0),
+	}, nil
 }
 
-func (ss *sessionService) Delete(ctx context.Context, token string) error {
-	err := ss.storage.Delete(ctx, token)
+func (ss *sessionService) verify(t, use string) (token.Claims, error) {
+	claims, err := ss.signer.Verify(t, time.Now())
+	if err != nil {
+		if stdErrors.Is(err, token.ErrExpired) {
+			return token.Claims{}, errors.NewDomainError(errors.ErrSessionExpired, "")
+		}
+		return token.Claims{}, errors.NewDomainError(errors.ErrUnauthorized, "%s", err)
+	}
+	if claims.Use != use {
+		return token.Claims{}, errors.NewDomainError(errors.ErrUnauthorized, "not a %s token", use)
+	}
+	return claims, nil
+}
+
+func (ss *sessionService) GetByUser(ctx context.Context, userID int64) ([]entity.Session, error) {
+	return ss.storage.GetByUser(ctx, userID)
+}
+
+func (ss *sessionService) DeleteByID(ctx context.Context, userID, ID int64) error {
+	err := ss.storage.DeleteByID(ctx, userID, ID)
 	if err != nil {
 		return err
 	}
+	ss.revoke(ID)
 	return nil
 }
+
+func (ss *sessionService) DeleteByUser(ctx context.Context, userID int64) error {
+	sessions, err := ss.storage.GetByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = ss.storage.DeleteByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		ss.revoke(session.ID)
+	}
+	return nil
+}
+
+func (ss *sessionService) DeleteOthers(ctx context.Context, userID, keepID int64) error {
+	sessions, err := ss.storage.GetByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = ss.storage.DeleteOthers(ctx, userID, keepID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.ID != keepID {
+			ss.revoke(session.ID)
+		}
+	}
+	return nil
+}
+
+// revoke rejects the access tokens of the session from now on. A session is
+// forgotten once the access tokens issued before its revocation expired.
+func (ss *sessionService) revoke(ID int64) {
+	now := time.Now()
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	for id, until := range ss.revoked {
+		if now.After(until) {
+			delete(ss.revoked, id)
+		}
+	}
+	ss.revoked[ID] = now.Add(ss.cfg.AccessTTL)
+}
+
+func (ss *sessionService) isRevoked(ID int64) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	_, ok := ss.revoked[ID]
+	return ok
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"github.com/The-Gleb/product_catalog/internal/mocks"
+	"github.com/The-Gleb/product_catalog/pkg/token"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSessionService(t *testing.T, storage SessionStorage, cfg TokenConfig) *sessionService {
+	signer, err := token.NewSigner(bytes.Repeat([]byte{1}, token.MinKeyLen))
+	require.NoError(t, err)
+	return NewSessionService(storage, signer, cfg)
+}
+
+func Test_sessionService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockSessionStorage(ctrl)
+	s := newTestSessionService(t, storage, TokenConfig{AccessTTL: time.Minute, RefreshTTL: time.Hour})
+
+	var stored entity.Session
+	gomock.InOrder(
+		storage.EXPECT().DeleteExpired(gomock.Any()).Return(nil),
+		storage.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, session entity.Session) (entity.Session, error) {
+				session.ID = 7
+				stored = session
+				return session, nil
+			}),
+		storage.EXPECT().Rotate(gomock.Any(), int64(7), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, oldToken, newToken string, _ time.Time) error {
+				require.Equal(t, stored.Token, oldToken)
+				stored.Token = newToken
+				return nil
+			}),
+	)
+
+	tokens, err := s.Create(context.Background(), 1, entity.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+	require.Equal(t, hashRefreshToken(tokens.RefreshToken), stored.Token)
+	require.WithinDuration(t, time.Now().Add(time.Minute), tokens.AccessExpiry, 2*time.Second)
+	require.WithinDuration(t, time.Now().Add(time.Hour), tokens.RefreshExpiry, 2*time.Second)
+
+	session, err := s.Authenticate(context.Background(), tokens.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), session.ID)
+	require.Equal(t, int64(1), session.UserID)
+
+	_, err = s.Authenticate(context.Background(), tokens.RefreshToken)
+	require.Equal(t, errors.ErrUnauthorized, errors.Code(err))
+
+	_, err = s.Authenticate(context.Background(), "123")
+	require.Equal(t, errors.ErrUnauthorized, errors.Code(err))
+}
+
+func Test_sessionService_Authenticate_expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockSessionStorage(ctrl)
+	s := newTestSessionService(t, storage, TokenConfig{AccessTTL: -time.Minute, RefreshTTL: time.Hour})
+
+	tokens, err := s.issue(entity.Session{ID: 7, UserID: 1, Expiry: time.Now().Add(time.Hour)}, time.Now())
+	require.NoError(t, err)
+
+	_, err = s.Authenticate(context.Background(), tokens.AccessToken)
+	require.Equal(t, errors.ErrSessionExpired, errors.Code(err))
+}
+
+func Test_sessionService_revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockSessionStorage(ctrl)
+	s := newTestSessionService(t, storage, TokenConfig{AccessTTL: time.Minute, RefreshTTL: time.Hour})
+
+	accessToken := func(ID int64) string {
+		tokens, err := s.issue(entity.Session{ID: ID, UserID: 1, Expiry: time.Now().Add(time.Hour)}, time.Now())
+		require.NoError(t, err)
+		return tokens.AccessToken
+	}
+	authenticated := func(ID int64) bool {
+		_, err := s.Authenticate(context.Background(), accessToken(ID))
+		if err != nil {
+			require.Equal(t, errors.ErrUnauthorized, errors.Code(err))
+		}
+		return err == nil
+	}
+
+	require.True(t, authenticated(7))
+	storage.EXPECT().DeleteByID(gomock.Any(), int64(1), int64(7)).Return(nil)
+	require.NoError(t, s.DeleteByID(context.Background(), 1, 7))
+	require.False(t, authenticated(7))
+
+	sessions := []entity.Session{{ID: 8, UserID: 1}, {ID: 9, UserID: 1}}
+	gomock.InOrder(
+		storage.EXPECT().GetByUser(gomock.Any(), int64(1)).Return(sessions, nil),
+		storage.EXPECT().DeleteOthers(gomock.Any(), int64(1), int64(9)).Return(nil),
+	)
+	require.NoError(t, s.DeleteOthers(context.Background(), 1, 9))
+	require.False(t, authenticated(8))
+	require.True(t, authenticated(9))
+
+	gomock.InOrder(
+		storage.EXPECT().GetByUser(gomock.Any(), int64(1)).Return(sessions[1:], nil),
+		storage.EXPECT().DeleteByUser(gomock.Any(), int64(1)).Return(nil),
+	)
+	require.NoError(t, s.DeleteByUser(context.Background(), 1))
+	require.False(t, authenticated(9))
+}
+
+func Test_sessionService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockSessionStorage(ctrl)
+	s := newTestSessionService(t, storage, TokenConfig{AccessTTL: time.Minute, RefreshTTL: time.Hour})
+
+	session := entity.Session{ID: 7, UserID: 1, Expiry: time.Now().Add(time.Hour)}
+	current, err := s.issue(session, time.Now())
+	require.NoError(t, err)
+	session.Token = hashRefreshToken(current.RefreshToken)
+
+	stale, err := s.issue(session, time.Now())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		token     string
+		wantErr   bool
+		errorCode errors.ErrorCode
+		prepare   func()
+	}{
+		{
+			name:  "rotates the token",
+			token: current.RefreshToken,
+			prepare: func() {
+				storage.EXPECT().GetByID(gomock.Any(), int64(7)).Return(session, nil)
+				storage.EXPECT().Rotate(gomock.Any(), int64(7), session.Token, gomock.Not(session.Token), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name:      "reused token revokes the session",
+			token:     stale.RefreshToken,
+			wantErr:   true,
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().GetByID(gomock.Any(), int64(7)).Return(session, nil)
+				storage.EXPECT().DeleteByID(gomock.Any(), int64(1), int64(7)).Return(nil)
+			},
+		},
+		{
+			name:      "concurrent use revokes the session",
+			token:     current.RefreshToken,
+			wantErr:   true,
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().GetByID(gomock.Any(), int64(7)).Return(session, nil)
+				storage.EXPECT().Rotate(gomock.Any(), int64(7), session.Token, gomock.Any(), gomock.Any()).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+				storage.EXPECT().DeleteByID(gomock.Any(), int64(1), int64(7)).
+					Return(errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:      "revoked session",
+			token:     current.RefreshToken,
+			wantErr:   true,
+			errorCode: errors.ErrUnauthorized,
+			prepare: func() {
+				storage.EXPECT().GetByID(gomock.Any(), int64(7)).
+					Return(entity.Session{}, errors.NewDomainError(errors.ErrNoDataFound, ""))
+			},
+		},
+		{
+			name:      "access token",
+			token:     current.AccessToken,
+			wantErr:   true,
+			errorCode: errors.ErrUnauthorized,
+			prepare:   func() {},
+		},
+		{
+			name:      "db error",
+			token:     current.RefreshToken,
+			wantErr:   true,
+			errorCode: errors.ErrDB,
+			prepare: func() {
+				storage.EXPECT().GetByID(gomock.Any(), int64(7)).
+					Return(entity.Session{}, errors.NewDomainError(errors.ErrDB, ""))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+
+			got, err := s.Refresh(context.Background(), tt.token)
+			if tt.wantErr {
+				require.Equal(t, tt.errorCode, errors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.NotEqual(t, tt.token, got.RefreshToken)
+
+			authenticated, err := s.Authenticate(context.Background(), got.AccessToken)
+			require.NoError(t, err)
+			require.Equal(t, int64(7), authenticated.ID)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/domain/usecase"
+	"github.com/The-Gleb/product_catalog/pkg/backoff"
+	"github.com/The-Gleb/product_catalog/pkg/breaker"
+)
+
+var _ usecase.SyncHealthReporter = new(syncWorker)
+
+type ProductSyncer interface {
+	SyncNewProducts(ctx context.Context) error
+	FullResync(ctx context.Context) error
+	Reconcile(ctx context.Context) error
+}
+
+// SyncWorkerConfig configures the worker. With FullResync the first run walks
+// the whole feed, with Reconcile every run reconciles the sources with their
+// upstream snapshots instead of importing the new products only.
+type SyncWorkerConfig struct {
+	Interval   time.Duration
+	FullResync bool
+	Reconcile  bool
+	Backoff    backoff.Backoff
+}
+
+// syncWorker runs the product synchronisation in the background. A failed
+// sync is retried with backoff and reported through Health instead of
+// stopping the worker.
+type syncWorker struct {
+	syncer   ProductSyncer
+	breakers map[string]*breaker.Breaker
+	config   SyncWorkerConfig
+
+	mu     sync.Mutex
+	health entity.SyncHealth
+}
+
+// NewSyncWorker creates a worker. breakers are the circuit breakers guarding
+// the upstream clients by source name, they are only used to report their state.
+func NewSyncWorker(syncer ProductSyncer, breakers map[string]*breaker.Breaker, config SyncWorkerConfig) *syncWorker {
+	return &syncWorker{
+		syncer:   syncer,
+		breakers: breakers,
+		config:   config,
+		health:   entity.SyncHealth{Status: entity.HealthOK},
+	}
+}
+
+// Run synchronises the products on start and then every interval until ctx is done.
+func (w *syncWorker) Run(ctx context.Context) {
+	sync := w.syncer.SyncNewProducts
+	if w.config.Reconcile {
+		sync = w.syncer.Reconcile
+	}
+
+	first := sync
+	if w.config.FullResync {
+		first = w.syncer.FullResync
+	}
+	w.runWithRetry(ctx, first, sync)
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.runWithRetry(ctx, sync, sync)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runWithRetry runs sync and then retry until one succeeds or ctx is done.
+// An incremental retry resumes from the saved checkpoint, so an interrupted
+// full resync is not restarted.
+func (w *syncWorker) runWithRetry(ctx context.Context, sync, retry func(ctx context.Context) error) {
+	for attempt := 0; ; attempt++ {
+		err := sync(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			w.recordSuccess()
+			return
+		}
+		w.recordFailure(err)
+
+		delay := w.config.Backoff.Delay(attempt)
+		slog.Warn("product sync failed, retrying",
+			"error", err,
+			"attempt", attempt+1,
+			"retry_in", delay,
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+		sync = retry
+	}
+}
+
+func (w *syncWorker) recordSuccess() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	w.health.ConsecutiveFailures = 0
+	w.health.LastError = ""
+	w.health.LastSuccess = &now
+}
+
+func (w *syncWorker) recordFailure(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	w.health.ConsecutiveFailures++
+	w.health.LastError = err.Error()
+	w.health.LastFailure = &now
+}
+
+func (w *syncWorker) Health() entity.SyncHealth {
+	w.mu.Lock()
+	health := w.health
+	w.mu.Unlock()
+
+	health.Status = entity.HealthOK
+	if health.ConsecutiveFailures > 0 {
+		health.Status = entity.HealthDegraded
+	}
+	if len(w.breakers) > 0 {
+		health.Breakers = make(map[string]string, len(w.breakers))
+	}
+	for source, b := range w.breakers {
+		state := b.State()
+		health.Breakers[source] = state.String()
+		if state != breaker.Closed {
+			health.Status = entity.HealthDegraded
+		}
+	}
+
+	return health
+}
+
+var _ ProductClient = new(circuitBreakerClient)
+
+// circuitBreakerClient guards an upstream product client with a circuit breaker.
+type circuitBreakerClient struct {
+	client  ProductClient
+	breaker *breaker.Breaker
+}
+
+func NewCircuitBreakerClient(c ProductClient, b *breaker.Breaker) *circuitBreakerClient {
+	return &circuitBreakerClient{
+		client:  c,
+		breaker: b,
+	}
+}
+
+func (c *circuitBreakerClient) Source() string {
+	return c.client.Source()
+}
+
+func (c *circuitBreakerClient) GetNewProducts(ctx context.Context, offset, limit int) (entity.ProductFeedPage, error) {
+	var page entity.ProductFeedPage
+	err := c.breaker.Do(func() error {
+		var err error
+		page, err = c.client.GetNewProducts(ctx, offset, limit)
+		return err
+	})
+	return page, err
+}
@@ -13,6 +13,9 @@ type UserStorage interface {
 	Create(ctx context.Context, user entity.User) (entity.User, error)
 	GetByID(ctx context.Context, ID int64) (entity.User, error)
 	GetByLogin(ctx context.Context, login string) (entity.User, error)
+	SetRole(ctx context.Context, dto entity.SetRoleDTO) (entity.User, error)
+	HasAdmin(ctx context.Context) (bool, error)
+	SetPassword(ctx context.Context, ID int64, password string) error
 }
 
 type userService struct {
@@ -34,3 +37,15 @@ func (us *userService) Create(ctx context.Context, user entity.User) (entity.Use
 func (us *userService) GetByLogin(ctx context.Context, login string) (entity.User, error) {
 	return us.storage.GetByLogin(ctx, login)
 }
+
+func (us *userService) SetRole(ctx context.Context, dto entity.SetRoleDTO) (entity.User, error) {
+	return us.storage.SetRole(ctx, dto)
+}
+
+func (us *userService) HasAdmin(ctx context.Context) (bool, error) {
+	return us.storage.HasAdmin(ctx)
+}
+
+func (us *userService) SetPassword(ctx context.Context, ID int64, password string) error {
+	return us.storage.SetPassword(ctx, ID, password)
+}
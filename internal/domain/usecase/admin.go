@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type adminUsecase struct {
+	userService UserService
+}
+
+func NewAdminUsecase(us UserService) *adminUsecase {
+	return &adminUsecase{us}
+}
+
+func (uc *adminUsecase) GrantRole(ctx context.Context, dto entity.SetRoleDTO) error {
+	_, err := uc.userService.SetRole(ctx, dto)
+	return err
+}
+
+// RevokeRole takes the granted role away, leaving the user a viewer.
+func (uc *adminUsecase) RevokeRole(ctx context.Context, login string) error {
+	_, err := uc.userService.SetRole(ctx, entity.SetRoleDTO{Login: login, Role: entity.RoleViewer})
+	return err
+}
+
+// BootstrapAdmin makes sure the catalog has an admin. If there is none, it
+// creates the user with the credentials as an admin, or promotes the existing
+// user with this login if the password matches.
+func (uc *adminUsecase) BootstrapAdmin(ctx context.Context, credentials entity.Credentials) error {
+
+	exists, err := uc.userService.HasAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	user, err := uc.userService.GetByLogin(ctx, credentials.Login)
+	if err != nil {
+		if errors.Code(err) != errors.ErrNoDataFound {
+			return err
+		}
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(credentials.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		_, err = uc.userService.Create(ctx, entity.User{
+			Login:    credentials.Login,
+			Password: string(hashedPassword),
+			Role:     entity.RoleAdmin,
+		})
+		if err != nil {
+			return err
+		}
+		slog.Info("admin user created", "login", credentials.Login)
+		return nil
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(credentials.Password))
+	if err != nil {
+		return errors.NewDomainError(errors.ErrUnauthorized, "login %s is taken by a user with another password", credentials.Login)
+	}
+
+	_, err = uc.userService.SetRole(ctx, entity.SetRoleDTO{Login: credentials.Login, Role: entity.RoleAdmin})
+	if err != nil {
+		return err
+	}
+	slog.Info("user promoted to admin", "login", credentials.Login)
+	return nil
+}
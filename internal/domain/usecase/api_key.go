@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+type apiKeyUsecase struct {
+	apiKeyService APIKeyService
+}
+
+func NewAPIKeyUsecase(ks APIKeyService) *apiKeyUsecase {
+	return &apiKeyUsecase{ks}
+}
+
+func (uc *apiKeyUsecase) CreateAPIKey(ctx context.Context, dto entity.CreateAPIKeyDTO) (entity.NewAPIKey, error) {
+	key, err := uc.apiKeyService.Create(ctx, dto)
+	if err != nil {
+		return entity.NewAPIKey{}, err
+	}
+
+	slog.Info("api key created",
+		"id", key.ID, "name", key.Name, "prefix", key.Prefix,
+		"scopes", key.Scopes, "created_by", key.CreatedBy,
+	)
+	return key, nil
+}
+
+func (uc *apiKeyUsecase) APIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	return uc.apiKeyService.GetAll(ctx)
+}
+
+func (uc *apiKeyUsecase) RevokeAPIKey(ctx context.Context, ID int64) error {
+	err := uc.apiKeyService.Revoke(ctx, ID)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("api key revoked", "id", ID)
+	return nil
+}
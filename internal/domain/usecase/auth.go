@@ -2,22 +2,56 @@ package usecase
 
 import (
 	"context"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
 )
 
 type authUsecase struct {
 	sessionService SessionService
+	apiKeyService  APIKeyService
 }
 
-func NewAuthUsecase(ss SessionService) *authUsecase {
-	return &authUsecase{ss}
+func NewAuthUsecase(ss SessionService, ks APIKeyService) *authUsecase {
+	return &authUsecase{ss, ks}
 }
 
-func (uc *authUsecase) Auth(ctx context.Context, token string) (int64, error) {
+func (uc *authUsecase) Auth(ctx context.Context, token string) (entity.Session, error) {
 
-	session, err := uc.sessionService.GetByToken(ctx, token)
+	session, err := uc.sessionService.Authenticate(ctx, token)
 	if err != nil {
-		return 0, err
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (uc *authUsecase) AuthAPIKey(ctx context.Context, key string) (entity.APIKey, error) {
+	return uc.apiKeyService.Authenticate(ctx, key)
+}
+
+type authorizeUsecase struct {
+	userService UserService
+}
+
+func NewAuthorizeUsecase(us UserService) *authorizeUsecase {
+	return &authorizeUsecase{us}
+}
+
+// Authorize checks that the role of the user has permission p and returns the role.
+func (uc *authorizeUsecase) Authorize(ctx context.Context, userID int64, p entity.Permission) (entity.Role, error) {
+
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return "", errors.NewDomainError(errors.ErrUnauthorized, "")
+		}
+		return "", err
+	}
+
+	if !user.Role.Can(p) {
+		return user.Role, errors.NewDomainError(errors.ErrForbidden, "role %s has no %s permission", user.Role, p)
 	}
 
-	return session.UserID, nil
+	return user.Role, nil
 }
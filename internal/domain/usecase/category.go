@@ -24,6 +24,22 @@ func (s *categoryUsecase) GetAll(ctx context.Context) ([]entity.Category, error)
 	return s.categoryService.GetAll(ctx)
 }
 
+func (s *categoryUsecase) GetTree(ctx context.Context) ([]entity.CategoryNode, error) {
+	return s.categoryService.GetTree(ctx)
+}
+
+func (s *categoryUsecase) GetSubtree(ctx context.Context, ID int64) (entity.CategoryNode, error) {
+	return s.categoryService.GetSubtree(ctx, ID)
+}
+
+func (s *categoryUsecase) GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error) {
+	return s.categoryService.GetAncestors(ctx, ID)
+}
+
+func (s *categoryUsecase) Move(ctx context.Context, category entity.MoveCategoryDTO) error {
+	return s.categoryService.Move(ctx, category)
+}
+
 func (s *categoryUsecase) UpdateName(ctx context.Context, category entity.UpdateCategoryNameDTO) error {
 	return s.categoryService.UpdateName(ctx, category)
 }
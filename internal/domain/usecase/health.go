@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+type healthUsecase struct {
+	sync SyncHealthReporter
+}
+
+func NewHealthUsecase(s SyncHealthReporter) *healthUsecase {
+	return &healthUsecase{
+		sync: s,
+	}
+}
+
+// Health reports the service as degraded while the product sync is failing.
+// The API itself keeps serving the products already in the catalog.
+func (u *healthUsecase) Health(ctx context.Context) entity.Health {
+	sync := u.sync.Health()
+	return entity.Health{
+		Status: sync.Status,
+		Sync:   sync,
+	}
+}
@@ -7,21 +7,30 @@ import (
 )
 
 type SessionService interface {
-	Create(ctx context.Context, userID int64) (entity.Session, error)
-	GetByToken(ctx context.Context, token string) (entity.Session, error)
-	Delete(ctx context.Context, token string) error
+	Create(ctx context.Context, userID int64, client entity.ClientInfo) (entity.TokenPair, error)
+	Authenticate(ctx context.Context, accessToken string) (entity.Session, error)
+	Refresh(ctx context.Context, refreshToken string) (entity.TokenPair, error)
+	GetByUser(ctx context.Context, userID int64) ([]entity.Session, error)
+	DeleteByID(ctx context.Context, userID, ID int64) error
+	DeleteByUser(ctx context.Context, userID int64) error
+	DeleteOthers(ctx context.Context, userID, keepID int64) error
 }
 
 type UserService interface {
 	Create(ctx context.Context, user entity.User) (entity.User, error)
 	GetByID(ctx context.Context, ID int64) (entity.User, error)
 	GetByLogin(ctx context.Context, login string) (entity.User, error)
+	SetRole(ctx context.Context, dto entity.SetRoleDTO) (entity.User, error)
+	HasAdmin(ctx context.Context) (bool, error)
+	SetPassword(ctx context.Context, ID int64, password string) error
 }
 
 type ProductService interface {
 	Add(ctx context.Context, products entity.AddProductDTO) error
-	GetByCategory(ctx context.Context, categoryID int64) ([]entity.ProductCategoryListItem, error)
+	GetByID(ctx context.Context, ID int64) (entity.ProductView, error)
+	GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error)
 	UpdateName(ctx context.Context, product entity.UpdateProductNameDTO) error
+	UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error
 	UpdateCategory(ctx context.Context, product entity.UpdateProductCategoryDTO) error
 	Delete(ctx context.Context, ID int64) error
 }
@@ -29,6 +38,53 @@ type ProductService interface {
 type CategoryService interface {
 	Add(ctx context.Context, Category entity.AddCategoryDTO) error
 	GetAll(ctx context.Context) ([]entity.Category, error)
+	GetTree(ctx context.Context) ([]entity.CategoryNode, error)
+	GetSubtree(ctx context.Context, ID int64) (entity.CategoryNode, error)
+	GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error)
+	Move(ctx context.Context, category entity.MoveCategoryDTO) error
 	UpdateName(ctx context.Context, category entity.UpdateCategoryNameDTO) error
 	Delete(ctx context.Context, ID int64) error
 }
+
+type SearchService interface {
+	Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error)
+	Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error)
+}
+
+type SyncHealthReporter interface {
+	Health() entity.SyncHealth
+}
+
+type SyncService interface {
+	ReconcileSource(ctx context.Context, source string) (entity.ReconcileReport, error)
+	ReconcileReports(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error)
+}
+
+type APIKeyService interface {
+	Create(ctx context.Context, dto entity.CreateAPIKeyDTO) (entity.NewAPIKey, error)
+	Authenticate(ctx context.Context, key string) (entity.APIKey, error)
+	GetAll(ctx context.Context) ([]entity.APIKey, error)
+	Revoke(ctx context.Context, ID int64) error
+}
+
+type LoginThrottleService interface {
+	Check(ctx context.Context, login, ip string) error
+	Failure(ctx context.Context, login, ip string) error
+	Success(ctx context.Context, login string) error
+}
+
+type MFAService interface {
+	Enroll(ctx context.Context, user entity.User) (entity.MFAEnrollment, error)
+	Confirm(ctx context.Context, userID int64, code string) error
+	Enabled(ctx context.Context, userID int64) (bool, error)
+	Verify(ctx context.Context, userID int64, code string) error
+	Disable(ctx context.Context, userID int64) error
+	Challenge(userID int64) (entity.MFAChallenge, error)
+	ParseChallenge(challenge string) (int64, error)
+}
+
+type PasswordResetService interface {
+	Request(ctx context.Context, user entity.User) error
+	Check(ctx context.Context, token string) (int64, error)
+	Use(ctx context.Context, token string) error
+}
@@ -10,22 +10,31 @@ import (
 )
 
 type loginUsecase struct {
-	userService    UserService
-	sessionService SessionService
+	userService     UserService
+	sessionService  SessionService
+	throttleService LoginThrottleService
+	mfaService      MFAService
 }
 
-func NewLoginUsecase(us UserService, ss SessionService) *loginUsecase {
-	return &loginUsecase{us, ss}
+func NewLoginUsecase(us UserService, ss SessionService, ts LoginThrottleService, ms MFAService) *loginUsecase {
+	return &loginUsecase{us, ss, ts, ms}
 }
 
-func (uc *loginUsecase) Login(ctx context.Context, credentials entity.Credentials) (entity.Session, error) {
+// Login checks the credentials and opens a session, or returns a challenge
+// if the user enrolled in two-factor authentication.
+func (uc *loginUsecase) Login(ctx context.Context, credentials entity.Credentials, client entity.ClientInfo) (entity.LoginResult, error) {
+
+	err := uc.throttleService.Check(ctx, credentials.Login, client.IP)
+	if err != nil {
+		return entity.LoginResult{}, err
+	}
 
 	user, err := uc.userService.GetByLogin(ctx, credentials.Login)
 	if err != nil {
 		if errors.Code(err) == errors.ErrNoDataFound {
-			return entity.Session{}, errors.NewDomainError(errors.ErrUnauthorized, "")
+			return entity.LoginResult{}, uc.failure(ctx, credentials.Login, client.IP)
 		}
-		return entity.Session{}, err
+		return entity.LoginResult{}, err
 	}
 
 	slog.Debug("user", "struct", user)
@@ -33,13 +42,80 @@ func (uc *loginUsecase) Login(ctx context.Context, credentials entity.Credential
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(credentials.Password))
 	if err != nil {
 		slog.Error("error comparing passwords", "error", err)
-		return entity.Session{}, errors.NewDomainError(errors.ErrUnauthorized, "")
+		return entity.LoginResult{}, uc.failure(ctx, credentials.Login, client.IP)
+	}
+
+	mfaEnabled, err := uc.mfaService.Enabled(ctx, user.ID)
+	if err != nil {
+		return entity.LoginResult{}, err
+	}
+	if mfaEnabled {
+		// The failures aren't reset until the second factor is verified,
+		// or the password alone would buy unlimited guesses of codes.
+		challenge, err := uc.mfaService.Challenge(user.ID)
+		if err != nil {
+			return entity.LoginResult{}, err
+		}
+		return entity.LoginResult{Challenge: &challenge}, nil
+	}
+
+	tokens, err := uc.open(ctx, user, client)
+	if err != nil {
+		return entity.LoginResult{}, err
 	}
 
-	newSession, err := uc.sessionService.Create(ctx, user.ID)
+	return entity.LoginResult{Tokens: tokens}, nil
+}
+
+// LoginMFA exchanges a challenge and a code of the user's authenticator, or
+// a recovery code, for a session. Wrong codes count as failed logins.
+func (uc *loginUsecase) LoginMFA(ctx context.Context, dto entity.MFALoginDTO, client entity.ClientInfo) (entity.TokenPair, error) {
+
+	userID, err := uc.mfaService.ParseChallenge(dto.Challenge)
 	if err != nil {
-		return entity.Session{}, err
+		return entity.TokenPair{}, err
 	}
 
-	return newSession, nil
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			return entity.TokenPair{}, errors.NewDomainError(errors.ErrUnauthorized, "")
+		}
+		return entity.TokenPair{}, err
+	}
+
+	err = uc.throttleService.Check(ctx, user.Login, client.IP)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	err = uc.mfaService.Verify(ctx, user.ID, dto.Code)
+	if err != nil {
+		if errors.Code(err) == errors.ErrUnauthorized {
+			slog.Error("error verifying second factor", "error", err, "user_id", user.ID)
+			return entity.TokenPair{}, uc.failure(ctx, user.Login, client.IP)
+		}
+		return entity.TokenPair{}, err
+	}
+
+	return uc.open(ctx, user, client)
+}
+
+// open resets the failed logins of the user and opens a session.
+func (uc *loginUsecase) open(ctx context.Context, user entity.User, client entity.ClientInfo) (entity.TokenPair, error) {
+	err := uc.throttleService.Success(ctx, user.Login)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	return uc.sessionService.Create(ctx, user.ID, client)
+}
+
+// failure counts a failed login and returns the error to respond with.
+func (uc *loginUsecase) failure(ctx context.Context, login, ip string) error {
+	err := uc.throttleService.Failure(ctx, login, ip)
+	if err != nil {
+		return err
+	}
+	return errors.NewDomainError(errors.ErrUnauthorized, "")
 }
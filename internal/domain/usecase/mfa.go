@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+)
+
+type mfaUsecase struct {
+	userService UserService
+	mfaService  MFAService
+}
+
+func NewMFAUsecase(us UserService, ms MFAService) *mfaUsecase {
+	return &mfaUsecase{us, ms}
+}
+
+// EnrollMFA starts the enrollment of the user in two-factor authentication.
+// It is offered to the users who can change the catalog.
+func (uc *mfaUsecase) EnrollMFA(ctx context.Context, userID int64) (entity.MFAEnrollment, error) {
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return entity.MFAEnrollment{}, err
+	}
+	if !user.Role.Can(entity.PermissionCatalogWrite) {
+		return entity.MFAEnrollment{}, errors.NewDomainError(errors.ErrForbidden, "two-factor authentication is for editors and admins")
+	}
+
+	return uc.mfaService.Enroll(ctx, user)
+}
+
+func (uc *mfaUsecase) ConfirmMFA(ctx context.Context, userID int64, code string) error {
+	err := uc.mfaService.Confirm(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("two-factor authentication enabled", "user_id", userID)
+	return nil
+}
+
+// DisableMFA turns two-factor authentication off, given a code or a recovery
+// code.
+func (uc *mfaUsecase) DisableMFA(ctx context.Context, userID int64, code string) error {
+	err := uc.mfaService.Verify(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+
+	err = uc.mfaService.Disable(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("two-factor authentication disabled", "user_id", userID)
+	return nil
+}
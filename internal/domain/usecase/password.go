@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type passwordUsecase struct {
+	userService          UserService
+	sessionService       SessionService
+	throttleService      LoginThrottleService
+	passwordResetService PasswordResetService
+	passwordPolicy       entity.PasswordPolicy
+}
+
+func NewPasswordUsecase(us UserService, ss SessionService, ts LoginThrottleService, rs PasswordResetService, policy entity.PasswordPolicy) *passwordUsecase {
+	return &passwordUsecase{us, ss, ts, rs, policy}
+}
+
+// ChangePassword replaces the password of the user, given the current one,
+// and logs out the sessions but the one with ID sessionID. A wrong current
+// password counts as a failed login, so that a stolen access token doesn't
+// buy unlimited guesses.
+func (uc *passwordUsecase) ChangePassword(ctx context.Context, userID, sessionID int64, dto entity.ChangePasswordDTO, client entity.ClientInfo) error {
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.throttleService.Check(ctx, user.Login, client.IP)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(dto.OldPassword))
+	if err != nil {
+		err = uc.throttleService.Failure(ctx, user.Login, client.IP)
+		if err != nil {
+			return err
+		}
+		return errors.NewDomainError(errors.ErrUnauthorized, "wrong password")
+	}
+
+	err = uc.throttleService.Success(ctx, user.Login)
+	if err != nil {
+		return err
+	}
+
+	err = uc.setPassword(ctx, user, dto.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	err = uc.sessionService.DeleteOthers(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("password changed", "user_id", userID)
+	return nil
+}
+
+// RequestPasswordReset sends a reset link to the user with the login. It
+// succeeds whether the user exists or not, so that it doesn't tell which
+// logins do.
+func (uc *passwordUsecase) RequestPasswordReset(ctx context.Context, dto entity.RequestPasswordResetDTO) error {
+	user, err := uc.userService.GetByLogin(ctx, dto.Login)
+	if err != nil {
+		if errors.Code(err) == errors.ErrNoDataFound {
+			slog.Info("password reset requested for unknown login")
+			return nil
+		}
+		return err
+	}
+	if user.Email == "" {
+		slog.Warn("password reset requested for user without email", "user_id", user.ID)
+		return nil
+	}
+
+	err = uc.passwordResetService.Request(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("password reset requested", "user_id", user.ID)
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and logs the user out
+// everywhere. The token is only spent if the password is accepted.
+func (uc *passwordUsecase) ResetPassword(ctx context.Context, dto entity.ResetPasswordDTO) error {
+	userID, err := uc.passwordResetService.Check(ctx, dto.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if reason := uc.passwordPolicy.Check(user.Login, dto.NewPassword); reason != "" {
+		return errors.NewDomainError(errors.ErrWeakPassword, "%s", reason)
+	}
+
+	err = uc.passwordResetService.Use(ctx, dto.Token)
+	if err != nil {
+		return err
+	}
+
+	err = uc.setPassword(ctx, user, dto.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	err = uc.sessionService.DeleteByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("password reset", "user_id", userID)
+	return nil
+}
+
+func (uc *passwordUsecase) setPassword(ctx context.Context, user entity.User, password string) error {
+	if reason := uc.passwordPolicy.Check(user.Login, password); reason != "" {
+		return errors.NewDomainError(errors.ErrWeakPassword, "%s", reason)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return uc.userService.SetPassword(ctx, user.ID, string(hashedPassword))
+}
@@ -20,14 +20,22 @@ func (s *productUsecase) Add(ctx context.Context, product entity.AddProductDTO)
 	return s.productService.Add(ctx, product)
 }
 
-func (s *productUsecase) GetByCategory(ctx context.Context, categoryID int64) ([]entity.ProductCategoryListItem, error) {
-	return s.productService.GetByCategory(ctx, categoryID)
+func (s *productUsecase) GetByID(ctx context.Context, ID int64) (entity.ProductView, error) {
+	return s.productService.GetByID(ctx, ID)
+}
+
+func (s *productUsecase) GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error) {
+	return s.productService.GetByCategory(ctx, query)
 }
 
 func (s *productUsecase) UpdateName(ctx context.Context, product entity.UpdateProductNameDTO) error {
 	return s.productService.UpdateName(ctx, product)
 }
 
+func (s *productUsecase) UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error {
+	return s.productService.UpdateDetails(ctx, product)
+}
+
 func (s *productUsecase) UpdateCategory(ctx context.Context, product entity.UpdateProductCategoryDTO) error {
 	return s.productService.UpdateCategory(ctx, product)
 }
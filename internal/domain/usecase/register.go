@@ -5,23 +5,29 @@ import (
 	"log/slog"
 
 	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+	"github.com/The-Gleb/product_catalog/internal/errors"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type registerUsecase struct {
 	userService    UserService
 	sessionService SessionService
+	passwordPolicy entity.PasswordPolicy
 }
 
-func NewRegisterUsecase(us UserService, ss SessionService) *registerUsecase {
-	return &registerUsecase{us, ss}
+func NewRegisterUsecase(us UserService, ss SessionService, policy entity.PasswordPolicy) *registerUsecase {
+	return &registerUsecase{us, ss, policy}
 }
 
-func (uc *registerUsecase) Register(ctx context.Context, credentials entity.Credentials) (entity.Session, error) {
+func (uc *registerUsecase) Register(ctx context.Context, credentials entity.Credentials, client entity.ClientInfo) (entity.TokenPair, error) {
+
+	if reason := uc.passwordPolicy.Check(credentials.Login, credentials.Password); reason != "" {
+		return entity.TokenPair{}, errors.NewDomainError(errors.ErrWeakPassword, "%s", reason)
+	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(credentials.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return entity.Session{}, err
+		return entity.TokenPair{}, err
 	}
 	credentials.Password = string(hashedPassword)
 
@@ -30,15 +36,16 @@ func (uc *registerUsecase) Register(ctx context.Context, credentials entity.Cred
 	user, err := uc.userService.Create(ctx, entity.User{
 		Login:    credentials.Login,
 		Password: credentials.Password,
+		Email:    credentials.Email,
 	})
 	if err != nil {
-		return entity.Session{}, err
+		return entity.TokenPair{}, err
 	}
 
-	newSession, err := uc.sessionService.Create(ctx, user.ID)
+	tokens, err := uc.sessionService.Create(ctx, user.ID, client)
 	if err != nil {
-		return entity.Session{}, err
+		return entity.TokenPair{}, err
 	}
 
-	return newSession, nil
+	return tokens, nil
 }
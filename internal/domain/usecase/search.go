@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+type searchUsecase struct {
+	searchService SearchService
+}
+
+func NewSearchUsecase(s SearchService) *searchUsecase {
+	return &searchUsecase{
+		searchService: s,
+	}
+}
+
+func (s *searchUsecase) Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error) {
+	return s.searchService.Search(ctx, query)
+}
+
+func (s *searchUsecase) Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error) {
+	return s.searchService.Facets(ctx, query)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+type sessionUsecase struct {
+	sessionService SessionService
+}
+
+func NewSessionUsecase(ss SessionService) *sessionUsecase {
+	return &sessionUsecase{ss}
+}
+
+func (uc *sessionUsecase) Logout(ctx context.Context, userID, sessionID int64) error {
+	return uc.sessionService.DeleteByID(ctx, userID, sessionID)
+}
+
+func (uc *sessionUsecase) Refresh(ctx context.Context, refreshToken string) (entity.TokenPair, error) {
+	return uc.sessionService.Refresh(ctx, refreshToken)
+}
+
+// Sessions returns the active sessions of a user, marking the one with ID
+// sessionID as current.
+func (uc *sessionUsecase) Sessions(ctx context.Context, userID, sessionID int64) ([]entity.SessionView, error) {
+
+	sessions, err := uc.sessionService.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]entity.SessionView, 0, len(sessions))
+	for _, s := range sessions {
+		views = append(views, entity.SessionView{
+			ID:         s.ID,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			Expiry:     s.Expiry,
+			IP:         s.IP,
+			UserAgent:  s.UserAgent,
+			Current:    s.ID == sessionID,
+		})
+	}
+
+	return views, nil
+}
+
+func (uc *sessionUsecase) RevokeSession(ctx context.Context, userID, sessionID int64) error {
+	return uc.sessionService.DeleteByID(ctx, userID, sessionID)
+}
+
+func (uc *sessionUsecase) RevokeAllSessions(ctx context.Context, userID int64) error {
+	return uc.sessionService.DeleteByUser(ctx, userID)
+}
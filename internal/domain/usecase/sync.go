@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/The-Gleb/product_catalog/internal/domain/entity"
+)
+
+type syncUsecase struct {
+	syncService SyncService
+}
+
+func NewSyncUsecase(s SyncService) *syncUsecase {
+	return &syncUsecase{
+		syncService: s,
+	}
+}
+
+func (u *syncUsecase) Reconcile(ctx context.Context, source string) (entity.ReconcileReport, error) {
+	return u.syncService.ReconcileSource(ctx, source)
+}
+
+func (u *syncUsecase) Reports(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error) {
+	return u.syncService.ReconcileReports(ctx, source, limit)
+}
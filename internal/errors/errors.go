@@ -13,11 +13,17 @@ const (
 	ErrNoDataFound      ErrorCode = "no data found"
 	ErrAlreadyExists    ErrorCode = "already exists"
 	ErrCategoryNotFound ErrorCode = "category doesn't exist"
+	ErrCategoryCycle    ErrorCode = "category can't be moved into its own subtree"
 
 	ErrUnauthorized ErrorCode = "Unauthorized"
+	ErrForbidden    ErrorCode = "Forbidden"
+	ErrLastAdmin    ErrorCode = "the last admin can't be demoted"
 	// ErrNotUniqueToken ErrorCode = "session token already exists"
 
 	ErrSessionExpired ErrorCode = "session token is expired"
+
+	ErrTooManyAttempts ErrorCode = "too many failed login attempts"
+	ErrWeakPassword    ErrorCode = "password doesn't meet the password policy"
 )
 
 type domainError struct {
@@ -51,6 +57,17 @@ func Code(err error) ErrorCode {
 	return ""
 }
 
+// Message returns the message of a domain error without its code, or the
+// text of any other error.
+func Message(err error) string {
+	var dErr domainError
+	if stdErrors.As(err, &dErr) {
+		return dErr.error.Error()
+	}
+
+	return err.Error()
+}
+
 func NewDomainError(errorCode ErrorCode, format string, args ...interface{}) error {
 	return domainError{
 		error:     fmt.Errorf(format, args...),
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/admin/api_keys.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIKeysUsecase is a mock of APIKeysUsecase interface.
+type MockAPIKeysUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeysUsecaseMockRecorder
+}
+
+// MockAPIKeysUsecaseMockRecorder is the mock recorder for MockAPIKeysUsecase.
+type MockAPIKeysUsecaseMockRecorder struct {
+	mock *MockAPIKeysUsecase
+}
+
+// NewMockAPIKeysUsecase creates a new mock instance.
+func NewMockAPIKeysUsecase(ctrl *gomock.Controller) *MockAPIKeysUsecase {
+	mock := &MockAPIKeysUsecase{ctrl: ctrl}
+	mock.recorder = &MockAPIKeysUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeysUsecase) EXPECT() *MockAPIKeysUsecaseMockRecorder {
+	return m.recorder
+}
+
+// APIKeys mocks base method.
+func (m *MockAPIKeysUsecase) APIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys", ctx)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIKeys indicates an expected call of APIKeys.
+func (mr *MockAPIKeysUsecaseMockRecorder) APIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockAPIKeysUsecase)(nil).APIKeys), ctx)
+}
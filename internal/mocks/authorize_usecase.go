@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/middleware/permission.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthorizeUsecase is a mock of AuthorizeUsecase interface.
+type MockAuthorizeUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizeUsecaseMockRecorder
+}
+
+// MockAuthorizeUsecaseMockRecorder is the mock recorder for MockAuthorizeUsecase.
+type MockAuthorizeUsecaseMockRecorder struct {
+	mock *MockAuthorizeUsecase
+}
+
+// NewMockAuthorizeUsecase creates a new mock instance.
+func NewMockAuthorizeUsecase(ctrl *gomock.Controller) *MockAuthorizeUsecase {
+	mock := &MockAuthorizeUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuthorizeUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizeUsecase) EXPECT() *MockAuthorizeUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockAuthorizeUsecase) Authorize(ctx context.Context, userID int64, p entity.Permission) (entity.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, userID, p)
+	ret0, _ := ret[0].(entity.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockAuthorizeUsecaseMockRecorder) Authorize(ctx, userID, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuthorizeUsecase)(nil).Authorize), ctx, userID, p)
+}
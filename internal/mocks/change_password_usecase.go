@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/password/change.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChangePasswordUsecase is a mock of ChangePasswordUsecase interface.
+type MockChangePasswordUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangePasswordUsecaseMockRecorder
+}
+
+// MockChangePasswordUsecaseMockRecorder is the mock recorder for MockChangePasswordUsecase.
+type MockChangePasswordUsecaseMockRecorder struct {
+	mock *MockChangePasswordUsecase
+}
+
+// NewMockChangePasswordUsecase creates a new mock instance.
+func NewMockChangePasswordUsecase(ctrl *gomock.Controller) *MockChangePasswordUsecase {
+	mock := &MockChangePasswordUsecase{ctrl: ctrl}
+	mock.recorder = &MockChangePasswordUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangePasswordUsecase) EXPECT() *MockChangePasswordUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockChangePasswordUsecase) ChangePassword(ctx context.Context, userID, sessionID int64, dto entity.ChangePasswordDTO, client entity.ClientInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, sessionID, dto, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockChangePasswordUsecaseMockRecorder) ChangePassword(ctx, userID, sessionID, dto, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockChangePasswordUsecase)(nil).ChangePassword), ctx, userID, sessionID, dto, client)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/mfa/confirm.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConfirmMFAUsecase is a mock of ConfirmMFAUsecase interface.
+type MockConfirmMFAUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfirmMFAUsecaseMockRecorder
+}
+
+// MockConfirmMFAUsecaseMockRecorder is the mock recorder for MockConfirmMFAUsecase.
+type MockConfirmMFAUsecaseMockRecorder struct {
+	mock *MockConfirmMFAUsecase
+}
+
+// NewMockConfirmMFAUsecase creates a new mock instance.
+func NewMockConfirmMFAUsecase(ctrl *gomock.Controller) *MockConfirmMFAUsecase {
+	mock := &MockConfirmMFAUsecase{ctrl: ctrl}
+	mock.recorder = &MockConfirmMFAUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfirmMFAUsecase) EXPECT() *MockConfirmMFAUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ConfirmMFA mocks base method.
+func (m *MockConfirmMFAUsecase) ConfirmMFA(ctx context.Context, userID int64, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFA", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MockConfirmMFAUsecaseMockRecorder) ConfirmMFA(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*MockConfirmMFAUsecase)(nil).ConfirmMFA), ctx, userID, code)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/admin/create_api_key.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCreateAPIKeyUsecase is a mock of CreateAPIKeyUsecase interface.
+type MockCreateAPIKeyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreateAPIKeyUsecaseMockRecorder
+}
+
+// MockCreateAPIKeyUsecaseMockRecorder is the mock recorder for MockCreateAPIKeyUsecase.
+type MockCreateAPIKeyUsecaseMockRecorder struct {
+	mock *MockCreateAPIKeyUsecase
+}
+
+// NewMockCreateAPIKeyUsecase creates a new mock instance.
+func NewMockCreateAPIKeyUsecase(ctrl *gomock.Controller) *MockCreateAPIKeyUsecase {
+	mock := &MockCreateAPIKeyUsecase{ctrl: ctrl}
+	mock.recorder = &MockCreateAPIKeyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreateAPIKeyUsecase) EXPECT() *MockCreateAPIKeyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockCreateAPIKeyUsecase) CreateAPIKey(ctx context.Context, dto entity.CreateAPIKeyDTO) (entity.NewAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, dto)
+	ret0, _ := ret[0].(entity.NewAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockCreateAPIKeyUsecaseMockRecorder) CreateAPIKey(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockCreateAPIKeyUsecase)(nil).CreateAPIKey), ctx, dto)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/mfa/disable.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDisableMFAUsecase is a mock of DisableMFAUsecase interface.
+type MockDisableMFAUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisableMFAUsecaseMockRecorder
+}
+
+// MockDisableMFAUsecaseMockRecorder is the mock recorder for MockDisableMFAUsecase.
+type MockDisableMFAUsecaseMockRecorder struct {
+	mock *MockDisableMFAUsecase
+}
+
+// NewMockDisableMFAUsecase creates a new mock instance.
+func NewMockDisableMFAUsecase(ctrl *gomock.Controller) *MockDisableMFAUsecase {
+	mock := &MockDisableMFAUsecase{ctrl: ctrl}
+	mock.recorder = &MockDisableMFAUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisableMFAUsecase) EXPECT() *MockDisableMFAUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DisableMFA mocks base method.
+func (m *MockDisableMFAUsecase) DisableMFA(ctx context.Context, userID int64, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMFA", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockDisableMFAUsecaseMockRecorder) DisableMFA(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockDisableMFAUsecase)(nil).DisableMFA), ctx, userID, code)
+}
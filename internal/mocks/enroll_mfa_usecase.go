@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/mfa/enroll.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEnrollMFAUsecase is a mock of EnrollMFAUsecase interface.
+type MockEnrollMFAUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollMFAUsecaseMockRecorder
+}
+
+// MockEnrollMFAUsecaseMockRecorder is the mock recorder for MockEnrollMFAUsecase.
+type MockEnrollMFAUsecaseMockRecorder struct {
+	mock *MockEnrollMFAUsecase
+}
+
+// NewMockEnrollMFAUsecase creates a new mock instance.
+func NewMockEnrollMFAUsecase(ctrl *gomock.Controller) *MockEnrollMFAUsecase {
+	mock := &MockEnrollMFAUsecase{ctrl: ctrl}
+	mock.recorder = &MockEnrollMFAUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollMFAUsecase) EXPECT() *MockEnrollMFAUsecaseMockRecorder {
+	return m.recorder
+}
+
+// EnrollMFA mocks base method.
+func (m *MockEnrollMFAUsecase) EnrollMFA(ctx context.Context, userID int64) (entity.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", ctx, userID)
+	ret0, _ := ret[0].(entity.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockEnrollMFAUsecaseMockRecorder) EnrollMFA(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockEnrollMFAUsecase)(nil).EnrollMFA), ctx, userID)
+}
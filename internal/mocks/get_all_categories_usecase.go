@@ -49,3 +49,18 @@ func (mr *MockGetAllCategoriesUsecaseMockRecorder) GetAll(ctx interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGetAllCategoriesUsecase)(nil).GetAll), ctx)
 }
+
+// GetTree mocks base method.
+func (m *MockGetAllCategoriesUsecase) GetTree(ctx context.Context) ([]entity.CategoryNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", ctx)
+	ret0, _ := ret[0].([]entity.CategoryNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockGetAllCategoriesUsecaseMockRecorder) GetTree(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockGetAllCategoriesUsecase)(nil).GetTree), ctx)
+}
@@ -36,16 +36,16 @@ func (m *MockGetProductsByCategoryUsecase) EXPECT() *MockGetProductsByCategoryUs
 }
 
 // GetByCategory mocks base method.
-func (m *MockGetProductsByCategoryUsecase) GetByCategory(ctx context.Context, categoryID int64) ([]entity.ProductCategoryListItem, error) {
+func (m *MockGetProductsByCategoryUsecase) GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCategory", ctx, categoryID)
-	ret0, _ := ret[0].([]entity.ProductCategoryListItem)
+	ret := m.ctrl.Call(m, "GetByCategory", ctx, query)
+	ret0, _ := ret[0].(entity.ProductPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByCategory indicates an expected call of GetByCategory.
-func (mr *MockGetProductsByCategoryUsecaseMockRecorder) GetByCategory(ctx, categoryID interface{}) *gomock.Call {
+func (mr *MockGetProductsByCategoryUsecaseMockRecorder) GetByCategory(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockGetProductsByCategoryUsecase)(nil).GetByCategory), ctx, categoryID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockGetProductsByCategoryUsecase)(nil).GetByCategory), ctx, query)
 }
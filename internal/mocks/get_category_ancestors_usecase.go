@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/category/get_ancestors.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGetCategoryAncestorsUsecase is a mock of GetCategoryAncestorsUsecase interface.
+type MockGetCategoryAncestorsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetCategoryAncestorsUsecaseMockRecorder
+}
+
+// MockGetCategoryAncestorsUsecaseMockRecorder is the mock recorder for MockGetCategoryAncestorsUsecase.
+type MockGetCategoryAncestorsUsecaseMockRecorder struct {
+	mock *MockGetCategoryAncestorsUsecase
+}
+
+// NewMockGetCategoryAncestorsUsecase creates a new mock instance.
+func NewMockGetCategoryAncestorsUsecase(ctrl *gomock.Controller) *MockGetCategoryAncestorsUsecase {
+	mock := &MockGetCategoryAncestorsUsecase{ctrl: ctrl}
+	mock.recorder = &MockGetCategoryAncestorsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetCategoryAncestorsUsecase) EXPECT() *MockGetCategoryAncestorsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetAncestors mocks base method.
+func (m *MockGetCategoryAncestorsUsecase) GetAncestors(ctx context.Context, ID int64) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAncestors", ctx, ID)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAncestors indicates an expected call of GetAncestors.
+func (mr *MockGetCategoryAncestorsUsecaseMockRecorder) GetAncestors(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestors", reflect.TypeOf((*MockGetCategoryAncestorsUsecase)(nil).GetAncestors), ctx, ID)
+}
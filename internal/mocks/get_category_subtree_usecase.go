@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/category/get_subtree.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGetCategorySubtreeUsecase is a mock of GetCategorySubtreeUsecase interface.
+type MockGetCategorySubtreeUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetCategorySubtreeUsecaseMockRecorder
+}
+
+// MockGetCategorySubtreeUsecaseMockRecorder is the mock recorder for MockGetCategorySubtreeUsecase.
+type MockGetCategorySubtreeUsecaseMockRecorder struct {
+	mock *MockGetCategorySubtreeUsecase
+}
+
+// NewMockGetCategorySubtreeUsecase creates a new mock instance.
+func NewMockGetCategorySubtreeUsecase(ctrl *gomock.Controller) *MockGetCategorySubtreeUsecase {
+	mock := &MockGetCategorySubtreeUsecase{ctrl: ctrl}
+	mock.recorder = &MockGetCategorySubtreeUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetCategorySubtreeUsecase) EXPECT() *MockGetCategorySubtreeUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetSubtree mocks base method.
+func (m *MockGetCategorySubtreeUsecase) GetSubtree(ctx context.Context, ID int64) (entity.CategoryNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtree", ctx, ID)
+	ret0, _ := ret[0].(entity.CategoryNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtree indicates an expected call of GetSubtree.
+func (mr *MockGetCategorySubtreeUsecaseMockRecorder) GetSubtree(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtree", reflect.TypeOf((*MockGetCategorySubtreeUsecase)(nil).GetSubtree), ctx, ID)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/product/get_by_id.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGetProductByIDUsecase is a mock of GetProductByIDUsecase interface.
+type MockGetProductByIDUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetProductByIDUsecaseMockRecorder
+}
+
+// MockGetProductByIDUsecaseMockRecorder is the mock recorder for MockGetProductByIDUsecase.
+type MockGetProductByIDUsecaseMockRecorder struct {
+	mock *MockGetProductByIDUsecase
+}
+
+// NewMockGetProductByIDUsecase creates a new mock instance.
+func NewMockGetProductByIDUsecase(ctrl *gomock.Controller) *MockGetProductByIDUsecase {
+	mock := &MockGetProductByIDUsecase{ctrl: ctrl}
+	mock.recorder = &MockGetProductByIDUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetProductByIDUsecase) EXPECT() *MockGetProductByIDUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockGetProductByIDUsecase) GetByID(ctx context.Context, ID int64) (entity.ProductView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, ID)
+	ret0, _ := ret[0].(entity.ProductView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGetProductByIDUsecaseMockRecorder) GetByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGetProductByIDUsecase)(nil).GetByID), ctx, ID)
+}
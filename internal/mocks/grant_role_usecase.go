@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/admin/grant_role.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGrantRoleUsecase is a mock of GrantRoleUsecase interface.
+type MockGrantRoleUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrantRoleUsecaseMockRecorder
+}
+
+// MockGrantRoleUsecaseMockRecorder is the mock recorder for MockGrantRoleUsecase.
+type MockGrantRoleUsecaseMockRecorder struct {
+	mock *MockGrantRoleUsecase
+}
+
+// NewMockGrantRoleUsecase creates a new mock instance.
+func NewMockGrantRoleUsecase(ctrl *gomock.Controller) *MockGrantRoleUsecase {
+	mock := &MockGrantRoleUsecase{ctrl: ctrl}
+	mock.recorder = &MockGrantRoleUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrantRoleUsecase) EXPECT() *MockGrantRoleUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GrantRole mocks base method.
+func (m *MockGrantRoleUsecase) GrantRole(ctx context.Context, dto entity.SetRoleDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockGrantRoleUsecaseMockRecorder) GrantRole(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockGrantRoleUsecase)(nil).GrantRole), ctx, dto)
+}
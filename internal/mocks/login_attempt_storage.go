@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/service/login_throttle.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginAttemptStorage is a mock of LoginAttemptStorage interface.
+type MockLoginAttemptStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptStorageMockRecorder
+}
+
+// MockLoginAttemptStorageMockRecorder is the mock recorder for MockLoginAttemptStorage.
+type MockLoginAttemptStorageMockRecorder struct {
+	mock *MockLoginAttemptStorage
+}
+
+// NewMockLoginAttemptStorage creates a new mock instance.
+func NewMockLoginAttemptStorage(ctrl *gomock.Controller) *MockLoginAttemptStorage {
+	mock := &MockLoginAttemptStorage{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptStorage) EXPECT() *MockLoginAttemptStorageMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockLoginAttemptStorage) Get(ctx context.Context, key string) (entity.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(entity.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLoginAttemptStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoginAttemptStorage)(nil).Get), ctx, key)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLoginAttemptStorage) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (entity.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, key, now, window)
+	ret0, _ := ret[0].(entity.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLoginAttemptStorageMockRecorder) RecordFailure(ctx, key, now, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLoginAttemptStorage)(nil).RecordFailure), ctx, key, now, window)
+}
+
+// Lock mocks base method.
+func (m *MockLoginAttemptStorage) Lock(ctx context.Context, key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockLoginAttemptStorageMockRecorder) Lock(ctx, key, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockLoginAttemptStorage)(nil).Lock), ctx, key, until)
+}
+
+// Reset mocks base method.
+func (m *MockLoginAttemptStorage) Reset(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLoginAttemptStorageMockRecorder) Reset(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLoginAttemptStorage)(nil).Reset), ctx, key)
+}
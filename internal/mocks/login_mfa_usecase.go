@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/login_mfa.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginMFAUsecase is a mock of LoginMFAUsecase interface.
+type MockLoginMFAUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginMFAUsecaseMockRecorder
+}
+
+// MockLoginMFAUsecaseMockRecorder is the mock recorder for MockLoginMFAUsecase.
+type MockLoginMFAUsecaseMockRecorder struct {
+	mock *MockLoginMFAUsecase
+}
+
+// NewMockLoginMFAUsecase creates a new mock instance.
+func NewMockLoginMFAUsecase(ctrl *gomock.Controller) *MockLoginMFAUsecase {
+	mock := &MockLoginMFAUsecase{ctrl: ctrl}
+	mock.recorder = &MockLoginMFAUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginMFAUsecase) EXPECT() *MockLoginMFAUsecaseMockRecorder {
+	return m.recorder
+}
+
+// LoginMFA mocks base method.
+func (m *MockLoginMFAUsecase) LoginMFA(ctx context.Context, dto entity.MFALoginDTO, client entity.ClientInfo) (entity.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginMFA", ctx, dto, client)
+	ret0, _ := ret[0].(entity.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginMFA indicates an expected call of LoginMFA.
+func (mr *MockLoginMFAUsecaseMockRecorder) LoginMFA(ctx, dto, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginMFA", reflect.TypeOf((*MockLoginMFAUsecase)(nil).LoginMFA), ctx, dto, client)
+}
@@ -36,16 +36,16 @@ func (m *MockLoginUsecase) EXPECT() *MockLoginUsecaseMockRecorder {
 }
 
 // Login mocks base method.
-func (m *MockLoginUsecase) Login(ctx context.Context, credentials entity.Credentials) (entity.Session, error) {
+func (m *MockLoginUsecase) Login(ctx context.Context, credentials entity.Credentials, client entity.ClientInfo) (entity.LoginResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, credentials)
-	ret0, _ := ret[0].(entity.Session)
+	ret := m.ctrl.Call(m, "Login", ctx, credentials, client)
+	ret0, _ := ret[0].(entity.LoginResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockLoginUsecaseMockRecorder) Login(ctx, credentials interface{}) *gomock.Call {
+func (mr *MockLoginUsecaseMockRecorder) Login(ctx, credentials, client interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockLoginUsecase)(nil).Login), ctx, credentials)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockLoginUsecase)(nil).Login), ctx, credentials, client)
 }
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/logout.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLogoutUsecase is a mock of LogoutUsecase interface.
+type MockLogoutUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogoutUsecaseMockRecorder
+}
+
+// MockLogoutUsecaseMockRecorder is the mock recorder for MockLogoutUsecase.
+type MockLogoutUsecaseMockRecorder struct {
+	mock *MockLogoutUsecase
+}
+
+// NewMockLogoutUsecase creates a new mock instance.
+func NewMockLogoutUsecase(ctrl *gomock.Controller) *MockLogoutUsecase {
+	mock := &MockLogoutUsecase{ctrl: ctrl}
+	mock.recorder = &MockLogoutUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogoutUsecase) EXPECT() *MockLogoutUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Logout mocks base method.
+func (m *MockLogoutUsecase) Logout(ctx context.Context, userID, sessionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockLogoutUsecaseMockRecorder) Logout(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockLogoutUsecase)(nil).Logout), ctx, userID, sessionID)
+}
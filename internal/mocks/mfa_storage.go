@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/service/mfa.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMFAStorage is a mock of MFAStorage interface.
+type MockMFAStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAStorageMockRecorder
+}
+
+// MockMFAStorageMockRecorder is the mock recorder for MockMFAStorage.
+type MockMFAStorageMockRecorder struct {
+	mock *MockMFAStorage
+}
+
+// NewMockMFAStorage creates a new mock instance.
+func NewMockMFAStorage(ctrl *gomock.Controller) *MockMFAStorage {
+	mock := &MockMFAStorage{ctrl: ctrl}
+	mock.recorder = &MockMFAStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAStorage) EXPECT() *MockMFAStorageMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockMFAStorage) Get(ctx context.Context, userID int64) (entity.MFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(entity.MFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMFAStorageMockRecorder) Get(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMFAStorage)(nil).Get), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockMFAStorage) Save(ctx context.Context, mfa entity.MFA, recoveryCodes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, mfa, recoveryCodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMFAStorageMockRecorder) Save(ctx, mfa, recoveryCodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMFAStorage)(nil).Save), ctx, mfa, recoveryCodes)
+}
+
+// Enable mocks base method.
+func (m *MockMFAStorage) Enable(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockMFAStorageMockRecorder) Enable(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockMFAStorage)(nil).Enable), ctx, userID)
+}
+
+// Delete mocks base method.
+func (m *MockMFAStorage) Delete(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMFAStorageMockRecorder) Delete(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMFAStorage)(nil).Delete), ctx, userID)
+}
+
+// UseStep mocks base method.
+func (m *MockMFAStorage) UseStep(ctx context.Context, userID, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockMFAStorageMockRecorder) UseStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockMFAStorage)(nil).UseStep), ctx, userID, step)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMFAStorage) UseRecoveryCode(ctx context.Context, userID int64, hash string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, hash, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMFAStorageMockRecorder) UseRecoveryCode(ctx, userID, hash, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMFAStorage)(nil).UseRecoveryCode), ctx, userID, hash, usedAt)
+}
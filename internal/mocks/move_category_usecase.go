@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/category/move.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMoveCategoryUsecase is a mock of MoveCategoryUsecase interface.
+type MockMoveCategoryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMoveCategoryUsecaseMockRecorder
+}
+
+// MockMoveCategoryUsecaseMockRecorder is the mock recorder for MockMoveCategoryUsecase.
+type MockMoveCategoryUsecaseMockRecorder struct {
+	mock *MockMoveCategoryUsecase
+}
+
+// NewMockMoveCategoryUsecase creates a new mock instance.
+func NewMockMoveCategoryUsecase(ctrl *gomock.Controller) *MockMoveCategoryUsecase {
+	mock := &MockMoveCategoryUsecase{ctrl: ctrl}
+	mock.recorder = &MockMoveCategoryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMoveCategoryUsecase) EXPECT() *MockMoveCategoryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Move mocks base method.
+func (m *MockMoveCategoryUsecase) Move(ctx context.Context, category entity.MoveCategoryDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockMoveCategoryUsecaseMockRecorder) Move(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockMoveCategoryUsecase)(nil).Move), ctx, category)
+}
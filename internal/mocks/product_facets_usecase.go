@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/product/facets.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProductFacetsUsecase is a mock of ProductFacetsUsecase interface.
+type MockProductFacetsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductFacetsUsecaseMockRecorder
+}
+
+// MockProductFacetsUsecaseMockRecorder is the mock recorder for MockProductFacetsUsecase.
+type MockProductFacetsUsecaseMockRecorder struct {
+	mock *MockProductFacetsUsecase
+}
+
+// NewMockProductFacetsUsecase creates a new mock instance.
+func NewMockProductFacetsUsecase(ctrl *gomock.Controller) *MockProductFacetsUsecase {
+	mock := &MockProductFacetsUsecase{ctrl: ctrl}
+	mock.recorder = &MockProductFacetsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductFacetsUsecase) EXPECT() *MockProductFacetsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Facets mocks base method.
+func (m *MockProductFacetsUsecase) Facets(ctx context.Context, query entity.FacetQuery) (entity.FacetPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Facets", ctx, query)
+	ret0, _ := ret[0].(entity.FacetPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Facets indicates an expected call of Facets.
+func (mr *MockProductFacetsUsecaseMockRecorder) Facets(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Facets", reflect.TypeOf((*MockProductFacetsUsecase)(nil).Facets), ctx, query)
+}
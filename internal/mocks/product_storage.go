@@ -0,0 +1,342 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/service/product.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProductStorage is a mock of ProductStorage interface.
+type MockProductStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductStorageMockRecorder
+}
+
+// MockProductStorageMockRecorder is the mock recorder for MockProductStorage.
+type MockProductStorageMockRecorder struct {
+	mock *MockProductStorage
+}
+
+// NewMockProductStorage creates a new mock instance.
+func NewMockProductStorage(ctrl *gomock.Controller) *MockProductStorage {
+	mock := &MockProductStorage{ctrl: ctrl}
+	mock.recorder = &MockProductStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductStorage) EXPECT() *MockProductStorageMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockProductStorage) Add(ctx context.Context, products entity.AddProductDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, products)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockProductStorageMockRecorder) Add(ctx, products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockProductStorage)(nil).Add), ctx, products)
+}
+
+// AddOrUpdateProduct mocks base method.
+func (m *MockProductStorage) AddOrUpdateProduct(ctx context.Context, products ...entity.AddOrUpdateProductDTO) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range products {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddOrUpdateProduct", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrUpdateProduct indicates an expected call of AddOrUpdateProduct.
+func (mr *MockProductStorageMockRecorder) AddOrUpdateProduct(ctx interface{}, products ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, products...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrUpdateProduct", reflect.TypeOf((*MockProductStorage)(nil).AddOrUpdateProduct), varargs...)
+}
+
+// GetByID mocks base method.
+func (m *MockProductStorage) GetByID(ctx context.Context, ID int64) (entity.ProductView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, ID)
+	ret0, _ := ret[0].(entity.ProductView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockProductStorageMockRecorder) GetByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProductStorage)(nil).GetByID), ctx, ID)
+}
+
+// GetByCategory mocks base method.
+func (m *MockProductStorage) GetByCategory(ctx context.Context, query entity.ProductQuery) (entity.ProductPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCategory", ctx, query)
+	ret0, _ := ret[0].(entity.ProductPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCategory indicates an expected call of GetByCategory.
+func (mr *MockProductStorageMockRecorder) GetByCategory(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockProductStorage)(nil).GetByCategory), ctx, query)
+}
+
+// UpdateName mocks base method.
+func (m *MockProductStorage) UpdateName(ctx context.Context, product entity.UpdateProductNameDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateName", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateName indicates an expected call of UpdateName.
+func (mr *MockProductStorageMockRecorder) UpdateName(ctx, product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateName", reflect.TypeOf((*MockProductStorage)(nil).UpdateName), ctx, product)
+}
+
+// UpdateDetails mocks base method.
+func (m *MockProductStorage) UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDetails", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDetails indicates an expected call of UpdateDetails.
+func (mr *MockProductStorageMockRecorder) UpdateDetails(ctx, product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDetails", reflect.TypeOf((*MockProductStorage)(nil).UpdateDetails), ctx, product)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockProductStorage) UpdateCategory(ctx context.Context, product entity.UpdateProductCategoryDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockProductStorageMockRecorder) UpdateCategory(ctx, product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockProductStorage)(nil).UpdateCategory), ctx, product)
+}
+
+// Delete mocks base method.
+func (m *MockProductStorage) Delete(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductStorageMockRecorder) Delete(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductStorage)(nil).Delete), ctx, ID)
+}
+
+// GetSourceProducts mocks base method.
+func (m *MockProductStorage) GetSourceProducts(ctx context.Context, source string) ([]entity.SourceProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSourceProducts", ctx, source)
+	ret0, _ := ret[0].([]entity.SourceProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSourceProducts indicates an expected call of GetSourceProducts.
+func (mr *MockProductStorageMockRecorder) GetSourceProducts(ctx, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceProducts", reflect.TypeOf((*MockProductStorage)(nil).GetSourceProducts), ctx, source)
+}
+
+// ApplyReconcilePlan mocks base method.
+func (m *MockProductStorage) ApplyReconcilePlan(ctx context.Context, plan entity.ReconcilePlan) ([]entity.ProductConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyReconcilePlan", ctx, plan)
+	ret0, _ := ret[0].([]entity.ProductConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyReconcilePlan indicates an expected call of ApplyReconcilePlan.
+func (mr *MockProductStorageMockRecorder) ApplyReconcilePlan(ctx, plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyReconcilePlan", reflect.TypeOf((*MockProductStorage)(nil).ApplyReconcilePlan), ctx, plan)
+}
+
+// MockProductClient is a mock of ProductClient interface.
+type MockProductClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductClientMockRecorder
+}
+
+// MockProductClientMockRecorder is the mock recorder for MockProductClient.
+type MockProductClientMockRecorder struct {
+	mock *MockProductClient
+}
+
+// NewMockProductClient creates a new mock instance.
+func NewMockProductClient(ctrl *gomock.Controller) *MockProductClient {
+	mock := &MockProductClient{ctrl: ctrl}
+	mock.recorder = &MockProductClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductClient) EXPECT() *MockProductClientMockRecorder {
+	return m.recorder
+}
+
+// Source mocks base method.
+func (m *MockProductClient) Source() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Source")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Source indicates an expected call of Source.
+func (mr *MockProductClientMockRecorder) Source() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Source", reflect.TypeOf((*MockProductClient)(nil).Source))
+}
+
+// GetNewProducts mocks base method.
+func (m *MockProductClient) GetNewProducts(ctx context.Context, offset, limit int) (entity.ProductFeedPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewProducts", ctx, offset, limit)
+	ret0, _ := ret[0].(entity.ProductFeedPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNewProducts indicates an expected call of GetNewProducts.
+func (mr *MockProductClientMockRecorder) GetNewProducts(ctx, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewProducts", reflect.TypeOf((*MockProductClient)(nil).GetNewProducts), ctx, offset, limit)
+}
+
+// MockSyncStateStorage is a mock of SyncStateStorage interface.
+type MockSyncStateStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncStateStorageMockRecorder
+}
+
+// MockSyncStateStorageMockRecorder is the mock recorder for MockSyncStateStorage.
+type MockSyncStateStorageMockRecorder struct {
+	mock *MockSyncStateStorage
+}
+
+// NewMockSyncStateStorage creates a new mock instance.
+func NewMockSyncStateStorage(ctrl *gomock.Controller) *MockSyncStateStorage {
+	mock := &MockSyncStateStorage{ctrl: ctrl}
+	mock.recorder = &MockSyncStateStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncStateStorage) EXPECT() *MockSyncStateStorageMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSyncStateStorage) Get(ctx context.Context, source string) (entity.SyncState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, source)
+	ret0, _ := ret[0].(entity.SyncState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSyncStateStorageMockRecorder) Get(ctx, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSyncStateStorage)(nil).Get), ctx, source)
+}
+
+// Save mocks base method.
+func (m *MockSyncStateStorage) Save(ctx context.Context, state entity.SyncState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSyncStateStorageMockRecorder) Save(ctx, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSyncStateStorage)(nil).Save), ctx, state)
+}
+
+// MockReconcileReportStorage is a mock of ReconcileReportStorage interface.
+type MockReconcileReportStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconcileReportStorageMockRecorder
+}
+
+// MockReconcileReportStorageMockRecorder is the mock recorder for MockReconcileReportStorage.
+type MockReconcileReportStorageMockRecorder struct {
+	mock *MockReconcileReportStorage
+}
+
+// NewMockReconcileReportStorage creates a new mock instance.
+func NewMockReconcileReportStorage(ctrl *gomock.Controller) *MockReconcileReportStorage {
+	mock := &MockReconcileReportStorage{ctrl: ctrl}
+	mock.recorder = &MockReconcileReportStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconcileReportStorage) EXPECT() *MockReconcileReportStorageMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockReconcileReportStorage) Save(ctx context.Context, report entity.ReconcileReport) (entity.ReconcileReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, report)
+	ret0, _ := ret[0].(entity.ReconcileReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockReconcileReportStorageMockRecorder) Save(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockReconcileReportStorage)(nil).Save), ctx, report)
+}
+
+// List mocks base method.
+func (m *MockReconcileReportStorage) List(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, source, limit)
+	ret0, _ := ret[0].([]entity.ReconcileReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockReconcileReportStorageMockRecorder) List(ctx, source, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReconcileReportStorage)(nil).List), ctx, source, limit)
+}
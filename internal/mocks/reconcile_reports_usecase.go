@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/sync/reports.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReconcileReportsUsecase is a mock of ReconcileReportsUsecase interface.
+type MockReconcileReportsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconcileReportsUsecaseMockRecorder
+}
+
+// MockReconcileReportsUsecaseMockRecorder is the mock recorder for MockReconcileReportsUsecase.
+type MockReconcileReportsUsecaseMockRecorder struct {
+	mock *MockReconcileReportsUsecase
+}
+
+// NewMockReconcileReportsUsecase creates a new mock instance.
+func NewMockReconcileReportsUsecase(ctrl *gomock.Controller) *MockReconcileReportsUsecase {
+	mock := &MockReconcileReportsUsecase{ctrl: ctrl}
+	mock.recorder = &MockReconcileReportsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconcileReportsUsecase) EXPECT() *MockReconcileReportsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Reports mocks base method.
+func (m *MockReconcileReportsUsecase) Reports(ctx context.Context, source string, limit int) ([]entity.ReconcileReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reports", ctx, source, limit)
+	ret0, _ := ret[0].([]entity.ReconcileReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reports indicates an expected call of Reports.
+func (mr *MockReconcileReportsUsecaseMockRecorder) Reports(ctx, source, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reports", reflect.TypeOf((*MockReconcileReportsUsecase)(nil).Reports), ctx, source, limit)
+}
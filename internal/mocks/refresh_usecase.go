@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/refresh.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRefreshUsecase is a mock of RefreshUsecase interface.
+type MockRefreshUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshUsecaseMockRecorder
+}
+
+// MockRefreshUsecaseMockRecorder is the mock recorder for MockRefreshUsecase.
+type MockRefreshUsecaseMockRecorder struct {
+	mock *MockRefreshUsecase
+}
+
+// NewMockRefreshUsecase creates a new mock instance.
+func NewMockRefreshUsecase(ctrl *gomock.Controller) *MockRefreshUsecase {
+	mock := &MockRefreshUsecase{ctrl: ctrl}
+	mock.recorder = &MockRefreshUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshUsecase) EXPECT() *MockRefreshUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockRefreshUsecase) Refresh(ctx context.Context, refreshToken string) (entity.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(entity.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockRefreshUsecaseMockRecorder) Refresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRefreshUsecase)(nil).Refresh), ctx, refreshToken)
+}
@@ -36,16 +36,16 @@ func (m *MockRegisterUsecase) EXPECT() *MockRegisterUsecaseMockRecorder {
 }
 
 // Register mocks base method.
-func (m *MockRegisterUsecase) Register(ctx context.Context, credentials entity.Credentials) (entity.Session, error) {
+func (m *MockRegisterUsecase) Register(ctx context.Context, credentials entity.Credentials, client entity.ClientInfo) (entity.TokenPair, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", ctx, credentials)
-	ret0, _ := ret[0].(entity.Session)
+	ret := m.ctrl.Call(m, "Register", ctx, credentials, client)
+	ret0, _ := ret[0].(entity.TokenPair)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockRegisterUsecaseMockRecorder) Register(ctx, credentials interface{}) *gomock.Call {
+func (mr *MockRegisterUsecaseMockRecorder) Register(ctx, credentials, client interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockRegisterUsecase)(nil).Register), ctx, credentials)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockRegisterUsecase)(nil).Register), ctx, credentials, client)
 }
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/password/request_reset.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRequestPasswordResetUsecase is a mock of RequestPasswordResetUsecase interface.
+type MockRequestPasswordResetUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestPasswordResetUsecaseMockRecorder
+}
+
+// MockRequestPasswordResetUsecaseMockRecorder is the mock recorder for MockRequestPasswordResetUsecase.
+type MockRequestPasswordResetUsecaseMockRecorder struct {
+	mock *MockRequestPasswordResetUsecase
+}
+
+// NewMockRequestPasswordResetUsecase creates a new mock instance.
+func NewMockRequestPasswordResetUsecase(ctrl *gomock.Controller) *MockRequestPasswordResetUsecase {
+	mock := &MockRequestPasswordResetUsecase{ctrl: ctrl}
+	mock.recorder = &MockRequestPasswordResetUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRequestPasswordResetUsecase) EXPECT() *MockRequestPasswordResetUsecaseMockRecorder {
+	return m.recorder
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockRequestPasswordResetUsecase) RequestPasswordReset(ctx context.Context, dto entity.RequestPasswordResetDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockRequestPasswordResetUsecaseMockRecorder) RequestPasswordReset(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockRequestPasswordResetUsecase)(nil).RequestPasswordReset), ctx, dto)
+}
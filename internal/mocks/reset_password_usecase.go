@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/password/reset.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockResetPasswordUsecase is a mock of ResetPasswordUsecase interface.
+type MockResetPasswordUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockResetPasswordUsecaseMockRecorder
+}
+
+// MockResetPasswordUsecaseMockRecorder is the mock recorder for MockResetPasswordUsecase.
+type MockResetPasswordUsecaseMockRecorder struct {
+	mock *MockResetPasswordUsecase
+}
+
+// NewMockResetPasswordUsecase creates a new mock instance.
+func NewMockResetPasswordUsecase(ctrl *gomock.Controller) *MockResetPasswordUsecase {
+	mock := &MockResetPasswordUsecase{ctrl: ctrl}
+	mock.recorder = &MockResetPasswordUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResetPasswordUsecase) EXPECT() *MockResetPasswordUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ResetPassword mocks base method.
+func (m *MockResetPasswordUsecase) ResetPassword(ctx context.Context, dto entity.ResetPasswordDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockResetPasswordUsecaseMockRecorder) ResetPassword(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockResetPasswordUsecase)(nil).ResetPassword), ctx, dto)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/session/revoke_all.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRevokeAllSessionsUsecase is a mock of RevokeAllSessionsUsecase interface.
+type MockRevokeAllSessionsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokeAllSessionsUsecaseMockRecorder
+}
+
+// MockRevokeAllSessionsUsecaseMockRecorder is the mock recorder for MockRevokeAllSessionsUsecase.
+type MockRevokeAllSessionsUsecaseMockRecorder struct {
+	mock *MockRevokeAllSessionsUsecase
+}
+
+// NewMockRevokeAllSessionsUsecase creates a new mock instance.
+func NewMockRevokeAllSessionsUsecase(ctrl *gomock.Controller) *MockRevokeAllSessionsUsecase {
+	mock := &MockRevokeAllSessionsUsecase{ctrl: ctrl}
+	mock.recorder = &MockRevokeAllSessionsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokeAllSessionsUsecase) EXPECT() *MockRevokeAllSessionsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockRevokeAllSessionsUsecase) RevokeAllSessions(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockRevokeAllSessionsUsecaseMockRecorder) RevokeAllSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockRevokeAllSessionsUsecase)(nil).RevokeAllSessions), ctx, userID)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/admin/revoke_api_key.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRevokeAPIKeyUsecase is a mock of RevokeAPIKeyUsecase interface.
+type MockRevokeAPIKeyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokeAPIKeyUsecaseMockRecorder
+}
+
+// MockRevokeAPIKeyUsecaseMockRecorder is the mock recorder for MockRevokeAPIKeyUsecase.
+type MockRevokeAPIKeyUsecaseMockRecorder struct {
+	mock *MockRevokeAPIKeyUsecase
+}
+
+// NewMockRevokeAPIKeyUsecase creates a new mock instance.
+func NewMockRevokeAPIKeyUsecase(ctrl *gomock.Controller) *MockRevokeAPIKeyUsecase {
+	mock := &MockRevokeAPIKeyUsecase{ctrl: ctrl}
+	mock.recorder = &MockRevokeAPIKeyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokeAPIKeyUsecase) EXPECT() *MockRevokeAPIKeyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockRevokeAPIKeyUsecase) RevokeAPIKey(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockRevokeAPIKeyUsecaseMockRecorder) RevokeAPIKey(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockRevokeAPIKeyUsecase)(nil).RevokeAPIKey), ctx, ID)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/admin/revoke_role.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRevokeRoleUsecase is a mock of RevokeRoleUsecase interface.
+type MockRevokeRoleUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokeRoleUsecaseMockRecorder
+}
+
+// MockRevokeRoleUsecaseMockRecorder is the mock recorder for MockRevokeRoleUsecase.
+type MockRevokeRoleUsecaseMockRecorder struct {
+	mock *MockRevokeRoleUsecase
+}
+
+// NewMockRevokeRoleUsecase creates a new mock instance.
+func NewMockRevokeRoleUsecase(ctrl *gomock.Controller) *MockRevokeRoleUsecase {
+	mock := &MockRevokeRoleUsecase{ctrl: ctrl}
+	mock.recorder = &MockRevokeRoleUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokeRoleUsecase) EXPECT() *MockRevokeRoleUsecaseMockRecorder {
+	return m.recorder
+}
+
+// RevokeRole mocks base method.
+func (m *MockRevokeRoleUsecase) RevokeRole(ctx context.Context, login string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockRevokeRoleUsecaseMockRecorder) RevokeRole(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockRevokeRoleUsecase)(nil).RevokeRole), ctx, login)
+}
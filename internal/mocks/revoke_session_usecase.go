@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/session/revoke.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRevokeSessionUsecase is a mock of RevokeSessionUsecase interface.
+type MockRevokeSessionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokeSessionUsecaseMockRecorder
+}
+
+// MockRevokeSessionUsecaseMockRecorder is the mock recorder for MockRevokeSessionUsecase.
+type MockRevokeSessionUsecaseMockRecorder struct {
+	mock *MockRevokeSessionUsecase
+}
+
+// NewMockRevokeSessionUsecase creates a new mock instance.
+func NewMockRevokeSessionUsecase(ctrl *gomock.Controller) *MockRevokeSessionUsecase {
+	mock := &MockRevokeSessionUsecase{ctrl: ctrl}
+	mock.recorder = &MockRevokeSessionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokeSessionUsecase) EXPECT() *MockRevokeSessionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// RevokeSession mocks base method.
+func (m *MockRevokeSessionUsecase) RevokeSession(ctx context.Context, userID, sessionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockRevokeSessionUsecaseMockRecorder) RevokeSession(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockRevokeSessionUsecase)(nil).RevokeSession), ctx, userID, sessionID)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/product/search.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchProductsUsecase is a mock of SearchProductsUsecase interface.
+type MockSearchProductsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchProductsUsecaseMockRecorder
+}
+
+// MockSearchProductsUsecaseMockRecorder is the mock recorder for MockSearchProductsUsecase.
+type MockSearchProductsUsecaseMockRecorder struct {
+	mock *MockSearchProductsUsecase
+}
+
+// NewMockSearchProductsUsecase creates a new mock instance.
+func NewMockSearchProductsUsecase(ctrl *gomock.Controller) *MockSearchProductsUsecase {
+	mock := &MockSearchProductsUsecase{ctrl: ctrl}
+	mock.recorder = &MockSearchProductsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchProductsUsecase) EXPECT() *MockSearchProductsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchProductsUsecase) Search(ctx context.Context, query entity.ProductSearchQuery) (entity.ProductSearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].(entity.ProductSearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchProductsUsecaseMockRecorder) Search(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchProductsUsecase)(nil).Search), ctx, query)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -36,11 +37,12 @@ func (m *MockSessionStorage) EXPECT() *MockSessionStorageMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockSessionStorage) Create(ctx context.Context, session entity.Session) error {
+func (m *MockSessionStorage) Create(ctx context.Context, session entity.Session) (entity.Session, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, session)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
@@ -49,45 +51,102 @@ func (mr *MockSessionStorageMockRecorder) Create(ctx, session interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionStorage)(nil).Create), ctx, session)
 }
 
-// Delete mocks base method.
-func (m *MockSessionStorage) Delete(ctx context.Context, token string) error {
+// GetByID mocks base method.
+func (m *MockSessionStorage) GetByID(ctx context.Context, ID int64) (entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, ID)
+	ret0, _ := ret[0].(entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSessionStorageMockRecorder) GetByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSessionStorage)(nil).GetByID), ctx, ID)
+}
+
+// GetByUser mocks base method.
+func (m *MockSessionStorage) GetByUser(ctx context.Context, userID int64) ([]entity.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, token)
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockSessionStorageMockRecorder) GetByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockSessionStorage)(nil).GetByUser), ctx, userID)
+}
+
+// Rotate mocks base method.
+func (m *MockSessionStorage) Rotate(ctx context.Context, ID int64, oldToken, newToken string, lastUsedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, ID, oldToken, newToken, lastUsedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockSessionStorageMockRecorder) Delete(ctx, token interface{}) *gomock.Call {
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionStorageMockRecorder) Rotate(ctx, ID, oldToken, newToken, lastUsedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionStorage)(nil).Delete), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSessionStorage)(nil).Rotate), ctx, ID, oldToken, newToken, lastUsedAt)
 }
 
-// DeleteExpired mocks base method.
-func (m *MockSessionStorage) DeleteExpired(ctx context.Context) error {
+// DeleteByID mocks base method.
+func (m *MockSessionStorage) DeleteByID(ctx context.Context, userID, ID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, userID, ID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteExpired indicates an expected call of DeleteExpired.
-func (mr *MockSessionStorageMockRecorder) DeleteExpired(ctx interface{}) *gomock.Call {
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSessionStorageMockRecorder) DeleteByID(ctx, userID, ID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockSessionStorage)(nil).DeleteExpired), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSessionStorage)(nil).DeleteByID), ctx, userID, ID)
 }
 
-// GetByToken mocks base method.
-func (m *MockSessionStorage) GetByToken(ctx context.Context, token string) (entity.Session, error) {
+// DeleteByUser mocks base method.
+func (m *MockSessionStorage) DeleteByUser(ctx context.Context, userID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByToken", ctx, token)
-	ret0, _ := ret[0].(entity.Session)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteByUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser.
+func (mr *MockSessionStorageMockRecorder) DeleteByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockSessionStorage)(nil).DeleteByUser), ctx, userID)
 }
 
-// GetByToken indicates an expected call of GetByToken.
-func (mr *MockSessionStorageMockRecorder) GetByToken(ctx, token interface{}) *gomock.Call {
+// DeleteOthers mocks base method.
+func (m *MockSessionStorage) DeleteOthers(ctx context.Context, userID, keepID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOthers", ctx, userID, keepID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOthers indicates an expected call of DeleteOthers.
+func (mr *MockSessionStorageMockRecorder) DeleteOthers(ctx, userID, keepID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockSessionStorage)(nil).GetByToken), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOthers", reflect.TypeOf((*MockSessionStorage)(nil).DeleteOthers), ctx, userID, keepID)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockSessionStorage) DeleteExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockSessionStorageMockRecorder) DeleteExpired(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockSessionStorage)(nil).DeleteExpired), ctx)
 }
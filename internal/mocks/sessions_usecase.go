@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/session/list.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionsUsecase is a mock of SessionsUsecase interface.
+type MockSessionsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionsUsecaseMockRecorder
+}
+
+// MockSessionsUsecaseMockRecorder is the mock recorder for MockSessionsUsecase.
+type MockSessionsUsecaseMockRecorder struct {
+	mock *MockSessionsUsecase
+}
+
+// NewMockSessionsUsecase creates a new mock instance.
+func NewMockSessionsUsecase(ctrl *gomock.Controller) *MockSessionsUsecase {
+	mock := &MockSessionsUsecase{ctrl: ctrl}
+	mock.recorder = &MockSessionsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionsUsecase) EXPECT() *MockSessionsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Sessions mocks base method.
+func (m *MockSessionsUsecase) Sessions(ctx context.Context, userID, sessionID int64) ([]entity.SessionView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions", ctx, userID, sessionID)
+	ret0, _ := ret[0].([]entity.SessionView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockSessionsUsecaseMockRecorder) Sessions(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockSessionsUsecase)(nil).Sessions), ctx, userID, sessionID)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/handler/product/update_details.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/The-Gleb/product_catalog/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUpdateProductDetailsUsecase is a mock of UpdateProductDetailsUsecase interface.
+type MockUpdateProductDetailsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpdateProductDetailsUsecaseMockRecorder
+}
+
+// MockUpdateProductDetailsUsecaseMockRecorder is the mock recorder for MockUpdateProductDetailsUsecase.
+type MockUpdateProductDetailsUsecaseMockRecorder struct {
+	mock *MockUpdateProductDetailsUsecase
+}
+
+// NewMockUpdateProductDetailsUsecase creates a new mock instance.
+func NewMockUpdateProductDetailsUsecase(ctrl *gomock.Controller) *MockUpdateProductDetailsUsecase {
+	mock := &MockUpdateProductDetailsUsecase{ctrl: ctrl}
+	mock.recorder = &MockUpdateProductDetailsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpdateProductDetailsUsecase) EXPECT() *MockUpdateProductDetailsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// UpdateDetails mocks base method.
+func (m *MockUpdateProductDetailsUsecase) UpdateDetails(ctx context.Context, product entity.UpdateProductDetailsDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDetails", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDetails indicates an expected call of UpdateDetails.
+func (mr *MockUpdateProductDetailsUsecaseMockRecorder) UpdateDetails(ctx, product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDetails", reflect.TypeOf((*MockUpdateProductDetailsUsecase)(nil).UpdateDetails), ctx, product)
+}
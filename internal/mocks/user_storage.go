@@ -79,3 +79,47 @@ func (mr *MockUserStorageMockRecorder) GetByLogin(ctx, login interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLogin", reflect.TypeOf((*MockUserStorage)(nil).GetByLogin), ctx, login)
 }
+
+// SetRole mocks base method.
+func (m *MockUserStorage) SetRole(ctx context.Context, dto entity.SetRoleDTO) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, dto)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockUserStorageMockRecorder) SetRole(ctx, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockUserStorage)(nil).SetRole), ctx, dto)
+}
+
+// HasAdmin mocks base method.
+func (m *MockUserStorage) HasAdmin(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasAdmin", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasAdmin indicates an expected call of HasAdmin.
+func (mr *MockUserStorageMockRecorder) HasAdmin(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasAdmin", reflect.TypeOf((*MockUserStorage)(nil).HasAdmin), ctx)
+}
+
+// SetPassword mocks base method.
+func (m *MockUserStorage) SetPassword(ctx context.Context, ID int64, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, ID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockUserStorageMockRecorder) SetPassword(ctx, ID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockUserStorage)(nil).SetPassword), ctx, ID, password)
+}
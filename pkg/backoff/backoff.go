@@ -0,0 +1,35 @@
+// Package backoff computes exponential retry delays with jitter.
+package backoff
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Delay returns the delay before the retry number attempt, counting from 0.
+// The delay doubles with every attempt up to Max, and its upper half is
+// randomised so that retrying clients don't hit the upstream in lockstep.
+func (b Backoff) Delay(attempt int) time.Duration {
+	d := b.Initial
+	for i := 0; i < attempt; i++ {
+		if d >= b.Max || d > math.MaxInt64/2 {
+			break
+		}
+		d *= 2
+	}
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
@@ -0,0 +1,65 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := New(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	errUpstream := errors.New("502")
+	fail := func() error { return errUpstream }
+	succeed := func() error { return nil }
+
+	require.Equal(t, errUpstream, b.Do(fail))
+	require.Equal(t, Closed, b.State())
+
+	require.NoError(t, b.Do(succeed))
+	require.Equal(t, errUpstream, b.Do(fail))
+	require.Equal(t, Closed, b.State(), "success resets the failure count")
+
+	require.Equal(t, errUpstream, b.Do(fail))
+	require.Equal(t, Open, b.State())
+
+	called := false
+	err := b.Do(func() error { called = true; return nil })
+	require.Equal(t, ErrOpen, err)
+	require.False(t, called)
+
+	now = now.Add(time.Minute)
+	require.Equal(t, errUpstream, b.Do(fail))
+	require.Equal(t, Open, b.State(), "failed trial opens the breaker again")
+	require.Equal(t, ErrOpen, b.Do(succeed))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, b.Do(succeed))
+	require.Equal(t, Closed, b.State())
+
+	require.Equal(t, context.Canceled, b.Do(func() error { return context.Canceled }))
+	require.Equal(t, context.Canceled, b.Do(func() error { return context.Canceled }))
+	require.Equal(t, Closed, b.State(), "cancellation is not a failure")
+}
+
+func TestBreaker_SingleTrial(t *testing.T) {
+	now := time.Now()
+	b := New(1, time.Second)
+	b.now = func() time.Time { return now }
+
+	require.Error(t, b.Do(func() error { return errors.New("502") }))
+	now = now.Add(time.Second)
+
+	err := b.Do(func() error {
+		require.Equal(t, HalfOpen, b.State())
+		require.Equal(t, ErrOpen, b.Do(func() error { return nil }))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, Closed, b.State())
+}
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/The-Gleb/product_catalog/internal/config"
+	_ "modernc.org/sqlite"
+)
+
+// NewClient opens the SQLite file of sc.Path, creating it if needed. SQLite
+// has one writer at a time, so the pool keeps a single connection and
+// transactions take the write lock when they begin rather than failing to
+// upgrade it halfway through.
+func NewClient(ctx context.Context, sc config.Database) (*sql.DB, error) {
+	dsn := fmt.Sprintf(
+		"file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(wal)&_txlock=immediate",
+		url.PathEscape(sc.Path),
+	)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		slog.Error(err.Error())
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,55 @@
+package token
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, MinKeyLen)
+	s, err := NewSigner(key)
+	require.NoError(t, err)
+
+	now := time.Now()
+	claims := Claims{
+		Subject:   "1",
+		SessionID: 2,
+		Use:       "access",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+	}
+	tok, err := s.Sign(claims)
+	require.NoError(t, err)
+
+	got, err := s.Verify(tok, now)
+	require.NoError(t, err)
+	require.Equal(t, claims, got)
+
+	_, err = s.Verify(tok, now.Add(time.Minute))
+	require.ErrorIs(t, err, ErrExpired)
+
+	other, err := NewSigner(bytes.Repeat([]byte{2}, MinKeyLen))
+	require.NoError(t, err)
+	_, err = other.Verify(tok, now)
+	require.ErrorIs(t, err, ErrInvalid)
+
+	parts := strings.Split(tok, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"2","use":"access","exp":9999999999}`))
+	_, err = s.Verify(parts[0]+"."+forged+"."+parts[2], now)
+	require.ErrorIs(t, err, ErrInvalid)
+
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	_, err = s.Verify(none+"."+parts[1]+".", now)
+	require.ErrorIs(t, err, ErrInvalid)
+
+	_, err = s.Verify("not a token", now)
+	require.ErrorIs(t, err, ErrInvalid)
+
+	_, err = NewSigner([]byte("short"))
+	require.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 secret of the test vectors of RFC 6238.
+var rfcSecret = []byte("12345678901234567890")
+
+func TestHOTP_RFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		step := Step(time.Unix(tt.unix, 0))
+		require.Equal(t, tt.code, hotp(rfcSecret, uint64(step), 8))
+		require.Equal(t, tt.code[2:], Code(rfcSecret, step))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+
+	got, ok := Validate(rfcSecret, Code(rfcSecret, step), now, 1)
+	require.True(t, ok)
+	require.Equal(t, step, got)
+
+	got, ok = Validate(rfcSecret, Code(rfcSecret, step-1), now, 1)
+	require.True(t, ok)
+	require.Equal(t, step-1, got)
+
+	_, ok = Validate(rfcSecret, Code(rfcSecret, step-2), now, 1)
+	require.False(t, ok)
+
+	_, ok = Validate(rfcSecret, "12345", now, 1)
+	require.False(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, SecretSize)
+
+	u, err := url.Parse(URI("Product Catalog", "login1", secret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/Product Catalog:login1", u.Path)
+	require.Equal(t, "Product Catalog", u.Query().Get("issuer"))
+
+	decoded, err := Encoding.DecodeString(u.Query().Get("secret"))
+	require.NoError(t, err)
+	require.Equal(t, secret, decoded)
+}
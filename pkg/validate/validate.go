@@ -0,0 +1,173 @@
+// Package validate checks structs against the rules of their validate tags,
+// the tags of github.com/go-playground/validator, and reports what is wrong
+// with each field in plain words.
+//
+// Besides the stock rules it knows "nocontrol", which rejects control
+// characters, and "scalar", which accepts only strings, numbers and booleans.
+// String fields tagged mod:"trim" have their surrounding whitespace removed
+// by Trim.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError tells what is wrong with a field. Field is the JSON name of the
+// field, indexed for the items of lists and maps, e.g. "Scopes[1]".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Errors are the errors of the invalid fields of a struct.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, f := range e {
+		s[i] = f.Field + " " + f.Message
+	}
+	return strings.Join(s, "; ")
+}
+
+var v = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(jsonName)
+
+	err := v.RegisterValidation("nocontrol", func(fl validator.FieldLevel) bool {
+		return strings.IndexFunc(fl.Field().String(), unicode.IsControl) < 0
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = v.RegisterValidation("scalar", func(fl validator.FieldLevel) bool {
+		switch fl.Field().Kind() {
+		case reflect.String, reflect.Float64, reflect.Bool:
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// Struct validates s, a struct or a pointer to one. The returned error is
+// Errors if fields are invalid.
+func Struct(s interface{}) error {
+	err := v.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	fieldErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	errs := make(Errors, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		errs[i] = FieldError{Field: fe.Field(), Message: message(fe)}
+	}
+	return errs
+}
+
+// Trim removes the surrounding whitespace of the string fields of the struct
+// s points to that are tagged mod:"trim", embedded structs included.
+func Trim(s interface{}) {
+	rv := reflect.ValueOf(s)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	trim(rv.Elem())
+}
+
+func trim(rv reflect.Value) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+		switch {
+		case f.Anonymous && fv.Kind() == reflect.Struct:
+			trim(fv)
+		case fv.Kind() == reflect.String && f.Tag.Get("mod") == "trim":
+			fv.SetString(strings.TrimSpace(fv.String()))
+		}
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
+
+func message(fe validator.FieldError) string {
+	kind := fe.Kind()
+	sized := kind == reflect.String || kind == reflect.Slice || kind == reflect.Map
+
+	switch fe.Tag() {
+	case "required":
+		if sized {
+			return "should not be empty"
+		}
+		return "should be set"
+	case "min":
+		if sized && fe.Param() == "1" {
+			return "should not be empty"
+		}
+		return "should be at least " + fe.Param() + unit(kind)
+	case "max":
+		return "should be at most " + fe.Param() + unit(kind)
+	case "gt":
+		if fe.Param() == "" {
+			return "should be in the future"
+		}
+		return "should be greater than " + fe.Param()
+	case "gte":
+		if fe.Param() == "0" {
+			return "should not be negative"
+		}
+		return "should be at least " + fe.Param()
+	case "lte":
+		return "should be at most " + fe.Param()
+	case "oneof":
+		return "should be one of " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "email":
+		return "should be an email address"
+	case "nocontrol":
+		return "should not contain control characters"
+	case "scalar":
+		return "should be a string, number or boolean"
+	}
+	return fmt.Sprintf("should satisfy %s", fe.Tag())
+}
+
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Map:
+		return " items"
+	}
+	return ""
+}
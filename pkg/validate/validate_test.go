@@ -0,0 +1,92 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name     string                 `json:"name" validate:"required,max=5,nocontrol" mod:"trim"`
+	Password string                 `validate:"required"`
+	Count    int                    `json:"count" validate:"gte=0,lte=10"`
+	Tags     []string               `json:"tags" validate:"min=1,dive,oneof=a b"`
+	Extra    map[string]interface{} `json:"extra" validate:"dive,keys,required,endkeys,scalar"`
+	Expiry   *time.Time             `json:"expiry" validate:"omitempty,gt"`
+}
+
+func TestStruct(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name string
+		item item
+		want Errors
+	}{
+		{
+			name: "valid",
+			item: item{Name: "abc", Password: "p", Tags: []string{"a"}, Extra: map[string]interface{}{"k": 1.5}, Expiry: &future},
+		},
+		{
+			name: "empty",
+			item: item{},
+			want: Errors{
+				{Field: "name", Message: "should not be empty"},
+				{Field: "Password", Message: "should not be empty"},
+				{Field: "tags", Message: "should not be empty"},
+			},
+		},
+		{
+			name: "out of range",
+			item: item{Name: "abcdef", Password: "p", Count: -1, Tags: []string{"a", "c"}, Expiry: &past},
+			want: Errors{
+				{Field: "name", Message: "should be at most 5 characters"},
+				{Field: "count", Message: "should not be negative"},
+				{Field: "tags[1]", Message: "should be one of a, b"},
+				{Field: "expiry", Message: "should be in the future"},
+			},
+		},
+		{
+			name: "control characters and non scalar values",
+			item: item{Name: "a\x00b", Password: "p", Count: 11, Tags: []string{"b"}, Extra: map[string]interface{}{"k": []interface{}{}}},
+			want: Errors{
+				{Field: "name", Message: "should not contain control characters"},
+				{Field: "count", Message: "should be at most 10"},
+				{Field: "extra[k]", Message: "should be a string, number or boolean"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.item)
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestTrim(t *testing.T) {
+	type Details struct {
+		Brand string `mod:"trim"`
+	}
+	s := struct {
+		Name     string `mod:"trim"`
+		Password string
+		Details
+	}{
+		Name:     "  name\t",
+		Password: " secret ",
+		Details:  Details{Brand: " brand "},
+	}
+
+	Trim(&s)
+
+	require.Equal(t, "name", s.Name)
+	require.Equal(t, " secret ", s.Password)
+	require.Equal(t, "brand", s.Brand)
+}